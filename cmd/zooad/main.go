@@ -4,23 +4,26 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	_ "github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal"
 	"github.com/mi-raf/zooad/internal/api"
+	"github.com/mi-raf/zooad/internal/auth"
+	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/metrics"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/mi-raf/zooad/internal/tracing"
+	"github.com/mi-raf/zooad/internal/transport/grpc"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"github.com/xlab/closer"
 )
 
 func main() {
-	defer closer.Close()
-
-	closer.Bind(func() {
-		log.Info().Msg("shutdown")
-	})
-
 	cfg, err := initConfig()
 	if err != nil {
 		log.Fatal().Err(err).Msg("Can't init config")
@@ -31,20 +34,99 @@ func main() {
 	}
 
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	closer.Bind(cancelCtx)
+	defer cancelCtx()
+
+	shutdownTracing, err := tracing.Init(ctx, &tracing.Config{
+		ServiceName: cfg.ServiceName,
+		Exporter:    cfg.TraceExporter,
+		File:        cfg.TraceFile,
+		SampleRatio: cfg.TraceSampleRatio,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Can't init tracing")
+	}
+	defer shutdownTracing()
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, cfg, os.Args[2:]); err != nil {
+			log.Fatal().Err(err).Msg("Can't migrate")
+		}
+		return
+	}
 
 	a, cleanup, err := initApp(ctx, cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Can't init app")
 	}
-	closer.Bind(cleanup)
-	closer.Bind(func() {
-		if err := a.Close(); err != nil {
-			log.Error().Err(err).Msg("Can't stop web application")
+	defer cleanup()
+
+	// Application владеет жизненным циклом: инициализирует ресурсы из ServiceKeeper,
+	// пингует их и останавливает серверы по сигналу ОС или если ресурс отвалился
+	app := internal.Application{
+		MainFunc:           a.run,
+		Resources:          a.keeper,
+		TerminationTimeout: cfg.TerminationTimeout,
+	}
+	if err := app.Run(); err != nil {
+		log.Error().Err(err).Msg("application stopped with error")
+		cleanup()
+		shutdownTracing()
+		os.Exit(1)
+	}
+	log.Info().Msg("shutdown")
+}
+
+type application struct {
+	api    *api.API
+	grpc   *grpc.Server
+	keeper *internal.ServiceKeeper
+	// сколько ждать завершения текущих запросов при остановке
+	shutdownTimeout time.Duration
+}
+
+func newApplication(cfg *config, a *api.API, g *grpc.Server, k *internal.ServiceKeeper) *application {
+	return &application{api: a, grpc: g, keeper: k, shutdownTimeout: cfg.TerminationTimeout}
+}
+
+// run - основной поток: поднимает HTTP и gRPC и держит их, пока не закроется holdOn
+func (a *application) run(ctx context.Context, holdOn <-chan struct{}) error {
+	var errSrv = make(chan error, 2)
+	go func() {
+		if err := a.grpc.Start(); err != nil {
+			errSrv <- fmt.Errorf("grpc server: %w", err)
 		}
-	})
-	if err := a.Start(); err != nil {
-		log.Fatal().Err(err).Msg("Can't start app")
+	}()
+	go func() {
+		if err := a.api.Start(); err != nil {
+			errSrv <- fmt.Errorf("web application: %w", err)
+		}
+	}()
+
+	var err error
+	select {
+	case <-holdOn:
+	case err = <-errSrv:
+	}
+	log.Info().Msg("stopping servers")
+	shCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+	if err := a.api.Shutdown(shCtx); err != nil {
+		log.Error().Err(err).Msg("Can't stop web application")
+	}
+	if err := a.grpc.Close(); err != nil {
+		log.Error().Err(err).Msg("Can't stop grpc server")
+	}
+	return err
+}
+
+// newServiceKeeper регистрирует ресурсы, которые пингуются во время работы
+func newServiceKeeper(cfg *config, st *storage, mood *service.MoodEngine, feeding *service.FeedingChecker, m *metrics.Metrics) *internal.ServiceKeeper {
+	return &internal.ServiceKeeper{
+		Services:        append(st.Services, mood, feeding),
+		PingPeriod:      cfg.PingPeriod,
+		PingTimeout:     cfg.PingTimeout,
+		ShutdownTimeout: cfg.TerminationTimeout,
+		OnCheck:         m.ObserveCheck,
 	}
 }
 
@@ -67,8 +149,12 @@ func initLogger(c *config) error {
 }
 
 func initPostgresConnection(ctx context.Context, cfg *config) (*pgxpool.Pool, func(), error) {
-	//todo write New rep
-	pg, err := pgxpool.New(ctx, cfg.DbAddr)
+	poolCfg, err := pgxpool.ParseConfig(cfg.DbAddr)
+	if err != nil {
+		return nil, nil, err
+	}
+	poolCfg.ConnConfig.Tracer = tracing.NewPgxTracer()
+	pg, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -76,6 +162,10 @@ func initPostgresConnection(ctx context.Context, cfg *config) (*pgxpool.Pool, fu
 	if err != nil {
 		return nil, nil, err
 	}
+	if err = migrateOnStart(ctx, cfg, pg); err != nil {
+		pg.Close()
+		return nil, nil, err
+	}
 
 	return pg, pg.Close, nil
 }
@@ -83,3 +173,111 @@ func initPostgresConnection(ctx context.Context, cfg *config) (*pgxpool.Pool, fu
 func initApiConfig(cfg *config) *api.Config {
 	return &api.Config{Addr: cfg.Listen}
 }
+
+func initPageTokenCodec(cfg *config) (*service.PageTokenCodec, error) {
+	if cfg.PageTokenSecret == "" {
+		log.Warn().Msg("PAGE_TOKEN_SECRET is empty, page tokens will not survive restart")
+	}
+	return service.NewPageTokenCodec(cfg.PageTokenSecret)
+}
+
+func initFeedingConfig(cfg *config) (*service.FeedingConfig, error) {
+	loc, err := time.LoadLocation(cfg.FeedingTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("feeding timezone: %w", err)
+	}
+	return &service.FeedingConfig{Grace: cfg.FeedingGrace, Location: loc}, nil
+}
+
+func newFeedingChecker(cfg *config, s *service.FeedingService, m *metrics.Metrics) *service.FeedingChecker {
+	c := service.NewFeedingChecker(s, cfg.FeedingCheckPeriod)
+	c.OnOverdue = m.ObserveOverdue
+	return c
+}
+
+func initMeasurementConfig(cfg *config) *service.MeasurementConfig {
+	return &service.MeasurementConfig{Window: cfg.MeasurementWindow, MaxLoss: cfg.WeightLossAlert, MaxGain: cfg.WeightGainAlert}
+}
+
+func initBreedingConfig(cfg *config) *service.BreedingConfig {
+	return &service.BreedingConfig{MaxInbreeding: cfg.MaxInbreeding}
+}
+
+func initMoodConfig(cfg *config) (*service.MoodConfig, error) {
+	if cfg.MoodNightFrom < 0 || cfg.MoodNightFrom > 23 || cfg.MoodNightTo < 0 || cfg.MoodNightTo > 23 {
+		return nil, fmt.Errorf("mood night hours must be from 0 to 23")
+	}
+	loc, err := time.LoadLocation(cfg.FeedingTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("mood timezone: %w", err)
+	}
+	return &service.MoodConfig{
+		HungryAfter:       cfg.MoodHungryAfter,
+		FedRecently:       cfg.MoodFedRecently,
+		MedicalWindow:     cfg.MoodMedicalWindow,
+		ObservationWindow: cfg.MoodObservationWindow,
+		NightFrom:         cfg.MoodNightFrom,
+		NightTo:           cfg.MoodNightTo,
+		Location:          loc,
+		RecordEvery:       cfg.MoodRecordEvery,
+	}, nil
+}
+
+// initAuthenticator - роли аутентифицированных субъектов берутся из справочника users
+func initAuthenticator(cfg *config, users database.UserRepository) (auth.Authenticator, error) {
+	var chain auth.Chain
+	if len(cfg.APIKeys) > 0 {
+		keys := make(map[string]string, len(cfg.APIKeys))
+		for i, kv := range cfg.APIKeys {
+			// сам ключ в ошибку не попадает
+			subject, key, ok := strings.Cut(kv, ":")
+			if !ok {
+				return nil, fmt.Errorf("API_KEYS entry #%d must be subject:key", i+1)
+			}
+			if _, dup := keys[subject]; dup {
+				return nil, fmt.Errorf("api key of %q is given twice", subject)
+			}
+			keys[subject] = key
+		}
+		apiKeys, err := auth.NewAPIKeys(keys)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, apiKeys)
+	}
+	if cfg.JWTHS256SecretFile != "" || cfg.JWTRS256PubKeyFile != "" {
+		jwtCfg := &auth.JWTConfig{Issuer: cfg.JWTIssuer, Audience: cfg.JWTAudience, Leeway: cfg.JWTLeeway}
+		var err error
+		if cfg.JWTHS256SecretFile != "" {
+			if jwtCfg.HS256Secret, err = auth.ReadHS256Secret(cfg.JWTHS256SecretFile); err != nil {
+				return nil, fmt.Errorf("jwt hs256 secret: %w", err)
+			}
+		}
+		if cfg.JWTRS256PubKeyFile != "" {
+			if jwtCfg.RS256Key, err = auth.ReadRS256PublicKey(cfg.JWTRS256PubKeyFile); err != nil {
+				return nil, fmt.Errorf("jwt rs256 public key: %w", err)
+			}
+		}
+		j, err := auth.NewJWT(jwtCfg)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, j)
+	}
+	if cfg.AuthAnonymous {
+		role := models.Role(cfg.AuthAnonymousRole)
+		if !slices.Contains(service.Roles, role) {
+			return nil, fmt.Errorf("unknown AUTH_ANONYMOUS_ROLE %q", role)
+		}
+		log.Warn().Str("role", string(role)).Msg("AUTH_ANONYMOUS is set, requests without credentials are allowed")
+		chain = append(chain, auth.Anonymous{Role: role})
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no authentication configured: set API_KEYS, JWT_HS256_SECRET_FILE or JWT_RS256_PUBLIC_KEY_FILE, or AUTH_ANONYMOUS=true")
+	}
+	return service.NewRoleResolver(chain, users, cfg.AuthAdmins), nil
+}
+
+func initGrpcConfig(cfg *config) *grpc.Config {
+	return &grpc.Config{Addr: cfg.GrpcListen}
+}
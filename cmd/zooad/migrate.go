@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/rs/zerolog/log"
+)
+
+const migrateUsage = "usage: zooad migrate up|down|status"
+
+// runMigrate выполняет подкоманду `zooad migrate up|down|status`
+func runMigrate(ctx context.Context, cfg *config, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+	pg, err := pgxpool.New(ctx, cfg.DbAddr)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+	m, err := database.NewMigrator(pg)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		n, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		log.Info().Int("applied", n).Msg("migrations applied")
+	case "down":
+		mig, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		log.Info().Int64("version", mig.Version).Str("name", mig.Name).Msg("migration rolled back")
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range statuses {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d %-30s %s\n", st.Version, st.Name, applied)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+	return nil
+}
+
+func migrateOnStart(ctx context.Context, cfg *config, pg *pgxpool.Pool) error {
+	if !cfg.MigrateOnStart {
+		return nil
+	}
+	m, err := database.NewMigrator(pg)
+	if err != nil {
+		return err
+	}
+	n, err := m.Up(ctx)
+	if err != nil {
+		return err
+	}
+	log.Info().Int("applied", n).Msg("migrations applied")
+	return nil
+}
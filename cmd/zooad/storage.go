@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/metrics"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	storagePostgres = "postgres"
+	storageMemory   = "memory"
+)
+
+// storage - набор репозиториев выбранного через STORAGE хранилища
+type storage struct {
+	Animals      database.AnimalRepository
+	Species      database.SpeciesRepository
+	Enclosures   database.EnclosureRepository
+	Feedings     database.FeedingRepository
+	Medical      database.MedicalRepository
+	Measures     database.MeasurementRepository
+	Lineage      database.LineageRepository
+	Moods        database.MoodRepository
+	Observations database.ObservationRepository
+	Users        database.UserRepository
+	Keepers      database.KeeperRepository
+	// ресурсы хранилища для ServiceKeeper
+	Services []service.Service
+}
+
+// initStorage оборачивает репозитории замерами времени для /metrics
+func initStorage(ctx context.Context, cfg *config, m *metrics.Metrics) (*storage, func(), error) {
+	switch cfg.Storage {
+	case storagePostgres:
+		pool, cleanup, err := initPostgresConnection(ctx, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		animals, err := database.NewAnimalRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		species, err := database.NewSpeciesRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		enclosures, err := database.NewEnclosureRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		feedings, err := database.NewFeedingRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		medical, err := database.NewMedicalRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		measures, err := database.NewMeasurementRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		lineage, err := database.NewLineageRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		moods, err := database.NewMoodRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		observations, err := database.NewObservationRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		users, err := database.NewUserRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		keepers, err := database.NewKeeperRepository(ctx, pool)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		if err := m.Register(metrics.NewPoolCollector(pool)); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		return &storage{
+			Animals:      metrics.NewAnimalRepository(animals, m),
+			Species:      metrics.NewSpeciesRepository(species, m),
+			Enclosures:   metrics.NewEnclosureRepository(enclosures, m),
+			Feedings:     metrics.NewFeedingRepository(feedings, m),
+			Medical:      metrics.NewMedicalRepository(medical, m),
+			Measures:     metrics.NewMeasurementRepository(measures, m),
+			Lineage:      metrics.NewLineageRepository(lineage, m),
+			Moods:        metrics.NewMoodRepository(moods, m),
+			Observations: metrics.NewObservationRepository(observations, m),
+			Users:        metrics.NewUserRepository(users, m),
+			Keepers:      metrics.NewKeeperRepository(keepers, m),
+			Services:     []service.Service{animals},
+		}, cleanup, nil
+	case storageMemory:
+		log.Warn().Msg("using in-memory storage, data will be lost on exit")
+		st := database.NewMemStorage()
+		s := &storage{
+			Animals:      metrics.NewAnimalRepository(database.NewMemAnimalRepository(st), m),
+			Species:      metrics.NewSpeciesRepository(database.NewMemSpeciesRepository(st), m),
+			Enclosures:   metrics.NewEnclosureRepository(database.NewMemEnclosureRepository(st), m),
+			Feedings:     metrics.NewFeedingRepository(database.NewMemFeedingRepository(st), m),
+			Medical:      metrics.NewMedicalRepository(database.NewMemMedicalRepository(st), m),
+			Measures:     metrics.NewMeasurementRepository(database.NewMemMeasurementRepository(st), m),
+			Lineage:      metrics.NewLineageRepository(database.NewMemLineageRepository(st), m),
+			Moods:        metrics.NewMoodRepository(database.NewMemMoodRepository(st), m),
+			Observations: metrics.NewObservationRepository(database.NewMemObservationRepository(st), m),
+			Users:        metrics.NewUserRepository(database.NewMemUserRepository(st), m),
+			Keepers:      metrics.NewKeeperRepository(database.NewMemKeeperRepository(st), m),
+			Services:     []service.Service{st},
+		}
+		if err := seedDemo(ctx, s); err != nil {
+			return nil, nil, err
+		}
+		return s, func() {}, nil
+	}
+	return nil, nil, fmt.Errorf("unknown storage %s", cfg.Storage)
+}
+
+// seedDemo заполняет пустое хранилище теми же данными, что testdata/init-db.sql
+func seedDemo(ctx context.Context, s *storage) error {
+	for _, sp := range []models.Specie{
+		{Title: "cat", Descrip: "The party gave out a bowl of rice and a cat wife"},
+		{Title: "dog", Descrip: "I ll buy you a dog"},
+		{Title: "rat", Descrip: "You are a rat, and I am a rat"},
+	} {
+		if _, err := s.Species.Add(ctx, &sp); err != nil {
+			return err
+		}
+	}
+	for _, an := range []models.Animal{
+		{NameAn: "Klepa", BirthDate: date(2011, 3, 14), BirthPrecision: "exact", Gender: "f", Title: "cat"},
+		{NameAn: "Wahaha", BirthDate: date(2025, 6, 1), BirthPrecision: "month", Gender: "m", Title: "cat"},
+		{NameAn: "Zu", BirthDate: date(2002, 1, 1), BirthPrecision: "year", Gender: "f", Title: "cat"},
+		{NameAn: "Zina", BirthDate: date(2024, 4, 20), BirthPrecision: "estimated", Gender: "f", Title: "cat"},
+		{NameAn: "Tom", BirthDate: date(1994, 9, 1), BirthPrecision: "month", Gender: "m", Title: "cat"},
+	} {
+		if _, err := s.Animals.Add(ctx, &an); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -6,21 +6,47 @@ import (
 	"context"
 
 	"github.com/google/wire"
+	"github.com/mi-raf/zooad/internal"
 	"github.com/mi-raf/zooad/internal/api"
-	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/metrics"
 	"github.com/mi-raf/zooad/internal/service"
+	"github.com/mi-raf/zooad/internal/transport/grpc"
 )
 
-func initApp(ctx context.Context, cfg *config) (a *api.API, closer func(), err error) {
+func initApp(ctx context.Context, cfg *config) (a *application, closer func(), err error) {
 	wire.Build(
 		initApiConfig,
-		initPostgresConnection,
-		database.NewAnimalRepository,
-		wire.Bind(new(database.AnimalRepository), new(*database.PgAnimalRepository)),
-		service.NewMoodService,
-		wire.Bind(new(service.MoodService), new(*service.MoodServiceImpl)),
+		initGrpcConfig,
+		metrics.New,
+		initStorage,
+		wire.FieldsOf(new(*storage), "Animals", "Species", "Enclosures", "Feedings", "Medical", "Measures", "Lineage", "Moods", "Observations", "Users", "Keepers"),
+		service.NewValidator,
+		service.NewSpeciesService,
+		service.NewEnclosureService,
+		initFeedingConfig,
+		service.NewFeedingService,
+		newFeedingChecker,
+		service.NewMedicalService,
+		initMeasurementConfig,
+		service.NewMeasurementService,
+		initBreedingConfig,
+		service.NewLineageService,
+		initMoodConfig,
+		service.DefaultMoodRules,
+		service.NewMoodEngine,
+		wire.Bind(new(service.MoodService), new(*service.MoodEngine)),
+		service.NewMoodHistoryService,
+		service.NewObservationService,
+		service.NewUserService,
+		service.NewKeeperService,
+		initPageTokenCodec,
+		initAuthenticator,
 		service.NewAnimalService,
 		api.New,
+		grpc.New,
+		newServiceKeeper,
+		wire.Bind(new(api.Readiness), new(*internal.ServiceKeeper)),
+		newApplication,
 	)
 
 	return nil, nil, nil
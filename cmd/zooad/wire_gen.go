@@ -1,6 +1,6 @@
 // Code generated by Wire. DO NOT EDIT.
 
-//go:generate go run github.com/google/wire/cmd/wire
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
 //go:build !wireinject
 // +build !wireinject
 
@@ -9,8 +9,9 @@ package main
 import (
 	"context"
 	"github.com/mi-raf/zooad/internal/api"
-	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/metrics"
 	"github.com/mi-raf/zooad/internal/service"
+	"github.com/mi-raf/zooad/internal/transport/grpc"
 )
 
 import (
@@ -19,25 +20,75 @@ import (
 
 // Injectors from wire.go:
 
-func initApp(ctx context.Context, cfg *config) (*api.API, func(), error) {
+func initApp(ctx context.Context, cfg *config) (*application, func(), error) {
 	apiConfig := initApiConfig(cfg)
-	pool, cleanup, err := initPostgresConnection(ctx, cfg)
+	metricsMetrics := metrics.New()
+	mainStorage, cleanup, err := initStorage(ctx, cfg, metricsMetrics)
 	if err != nil {
 		return nil, nil, err
 	}
-	pgAnimalRepository, err := database.NewAnimalRepository(ctx, pool)
+	animalRepository := mainStorage.Animals
+	enclosureRepository := mainStorage.Enclosures
+	lineageRepository := mainStorage.Lineage
+	keeperRepository := mainStorage.Keepers
+	moodConfig, err := initMoodConfig(cfg)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
-	moodServiceImpl := service.NewMoodService()
-	animalService := service.NewAnimalService(pgAnimalRepository, moodServiceImpl)
-	apiAPI, err := api.New(ctx, apiConfig, animalService)
+	v := service.DefaultMoodRules(moodConfig)
+	feedingRepository := mainStorage.Feedings
+	medicalRepository := mainStorage.Medical
+	observationRepository := mainStorage.Observations
+	moodRepository := mainStorage.Moods
+	moodEngine := service.NewMoodEngine(v, feedingRepository, medicalRepository, enclosureRepository, observationRepository, moodRepository, moodConfig)
+	pageTokenCodec, err := initPageTokenCodec(cfg)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
-	return apiAPI, func() {
+	validator := service.NewValidator()
+	animalService := service.NewAnimalService(animalRepository, enclosureRepository, lineageRepository, keeperRepository, moodEngine, pageTokenCodec, validator)
+	speciesRepository := mainStorage.Species
+	speciesService := service.NewSpeciesService(speciesRepository, validator)
+	enclosureService := service.NewEnclosureService(enclosureRepository, keeperRepository, validator)
+	feedingConfig, err := initFeedingConfig(cfg)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	feedingService := service.NewFeedingService(feedingRepository, animalRepository, validator, feedingConfig)
+	medicalService := service.NewMedicalService(medicalRepository, animalRepository, validator)
+	measurementRepository := mainStorage.Measures
+	measurementConfig := initMeasurementConfig(cfg)
+	measurementService := service.NewMeasurementService(measurementRepository, animalRepository, validator, measurementConfig)
+	breedingConfig := initBreedingConfig(cfg)
+	lineageService := service.NewLineageService(lineageRepository, animalRepository, validator, breedingConfig)
+	moodHistoryService := service.NewMoodHistoryService(moodRepository, animalRepository, validator)
+	observationService := service.NewObservationService(observationRepository, animalRepository, validator)
+	userRepository := mainStorage.Users
+	userService := service.NewUserService(userRepository, validator)
+	keeperService := service.NewKeeperService(keeperRepository, animalRepository, enclosureRepository, validator)
+	feedingChecker := newFeedingChecker(cfg, feedingService, metricsMetrics)
+	serviceKeeper := newServiceKeeper(cfg, mainStorage, moodEngine, feedingChecker, metricsMetrics)
+	authenticator, err := initAuthenticator(cfg, userRepository)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	apiAPI, err := api.New(ctx, apiConfig, animalService, speciesService, enclosureService, feedingService, medicalService, measurementService, lineageService, moodHistoryService, observationService, userService, keeperService, serviceKeeper, authenticator, metricsMetrics)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	grpcConfig := initGrpcConfig(cfg)
+	server, err := grpc.New(ctx, grpcConfig, animalService, feedingService, authenticator, metricsMetrics)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	mainApplication := newApplication(cfg, apiAPI, server, serviceKeeper)
+	return mainApplication, func() {
 		cleanup()
 	}, nil
 }
@@ -3,19 +3,27 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/mi-raf/zooad/internal/errs"
+	"github.com/mi-raf/zooad/internal/metrics"
 	models "github.com/mi-raf/zooad/internal/models"
 	"github.com/mi-raf/zooad/internal/service"
+	"github.com/mi-raf/zooad/internal/tracing"
 	zl "github.com/rs/zerolog/log"
-)
-
-const (
-	MAX_LIMIT = 100
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type (
@@ -24,9 +32,21 @@ type (
 	}
 
 	API struct {
-		e    *echo.Echo
-		s    *service.AnimalService
-		addr string
+		e       *echo.Echo
+		s       *service.AnimalService
+		sp      *service.SpeciesService
+		enc     *service.EnclosureService
+		fd      *service.FeedingService
+		med     *service.MedicalService
+		meas    *service.MeasurementService
+		lin     *service.LineageService
+		moods   *service.MoodHistoryService
+		obs     *service.ObservationService
+		users   *service.UserService
+		keepers *service.KeeperService
+		health  Readiness
+		authn   auth.Authenticator
+		addr    string
 	}
 
 	Context struct {
@@ -35,31 +55,107 @@ type (
 	}
 )
 
-func New(ctx context.Context, cfg *Config, s *service.AnimalService) (*API, error) {
+func New(ctx context.Context, cfg *Config, s *service.AnimalService, sp *service.SpeciesService, enc *service.EnclosureService, fd *service.FeedingService, med *service.MedicalService, meas *service.MeasurementService, lin *service.LineageService, moods *service.MoodHistoryService, obs *service.ObservationService, users *service.UserService, keepers *service.KeeperService, health Readiness, authn auth.Authenticator, m *metrics.Metrics) (*API, error) {
 	e := echo.New()
+	e.HTTPErrorHandler = errorHandler
 	a := &API{
-		s:    s,
-		e:    e,
-		addr: cfg.Addr,
+		s:       s,
+		sp:      sp,
+		enc:     enc,
+		fd:      fd,
+		med:     med,
+		meas:    meas,
+		lin:     lin,
+		moods:   moods,
+		obs:     obs,
+		users:   users,
+		keepers: keepers,
+		health:  health,
+		authn:   authn,
+		e:       e,
+		addr:    cfg.Addr,
 	}
 
+	e.Use(traced())
+	// Ctx - контекст запроса: в нем спан из traced(), его же получает сервис и pgx
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			cc := &Context{
 				Context: c,
-				Ctx:     ctx,
+				Ctx:     c.Request().Context(),
 			}
 			return next(cc)
 		}
 	})
 	//TODO запроосы для рест
 	e.Use(logger())
+	e.Use(observe(m))
+	e.Use(a.authenticate("/health", "/livez", "/readyz", "/metrics"))
 	e.GET("/health", healthCheck)
+	e.GET("/livez", a.livez)
+	e.GET("/readyz", a.readyz)
+	e.GET("/metrics", echo.WrapHandler(m.Handler()))
 	e.GET("/animal/:id", a.getAnimal)
 	e.GET("/animal", a.getAllAnimal)
 	e.POST("/animal", a.addAnimal)
-	e.PUT("/animal", a.updateAnimal)
+	e.PUT("/animal/:id", a.updateAnimal)
+	e.PATCH("/animal/:id", a.patchAnimal)
 	e.DELETE("/animal/:id", a.deleteAnimal)
+	e.PUT("/animal/:id/enclosure", a.assignEnclosure)
+	e.DELETE("/animal/:id/enclosure", a.unassignEnclosure)
+	e.GET("/animal/:id/feeding", a.getAnimalFeedings)
+	e.GET("/animal/:id/medical", a.getMedicalHistory)
+	e.POST("/animal/:id/medical/record", a.addMedicalRecord)
+	e.POST("/animal/:id/medical/medication", a.addMedication)
+	e.POST("/animal/:id/medical/vaccination", a.addVaccination)
+	e.GET("/vaccination/due", a.getDueVaccinations)
+	e.GET("/animal/:id/measurement", a.getMeasurements)
+	e.POST("/animal/:id/measurement", a.addMeasurement)
+	e.GET("/animal/:id/measurement/series", a.getMeasurementSeries)
+	e.DELETE("/measurement/:id", a.deleteMeasurement)
+	e.GET("/measurement/alerts", a.getMeasurementAlerts)
+	e.PUT("/animal/:id/parents", a.setParents)
+	e.GET("/animal/:id/pedigree", a.getPedigree)
+	e.POST("/breeding/evaluate", a.evaluateBreeding)
+	e.POST("/animal/:id/mood", a.addMood)
+	e.GET("/animal/:id/mood", a.getMoodHistory)
+	e.GET("/mood/distribution", a.getMoodDistribution)
+	e.POST("/animal/:id/observation", a.addObservation)
+	e.GET("/animal/:id/observation", a.getAnimalObservations)
+	e.GET("/observation", a.searchObservations)
+	e.GET("/species", a.getAllSpecies)
+	e.GET("/species/:id", a.getSpecie)
+	e.POST("/species", a.addSpecie)
+	e.PUT("/species/:id", a.updateSpecie)
+	e.DELETE("/species/:id", a.deleteSpecie)
+	e.GET("/enclosure", a.getAllEnclosures)
+	e.GET("/enclosure/:id", a.getEnclosure)
+	e.POST("/enclosure", a.addEnclosure)
+	e.PUT("/enclosure/:id", a.updateEnclosure)
+	e.DELETE("/enclosure/:id", a.deleteEnclosure)
+	e.GET("/feeding/plan", a.getAllPlans)
+	e.GET("/feeding/plan/:id", a.getPlan)
+	e.POST("/feeding/plan", a.addPlan)
+	e.PUT("/feeding/plan/:id", a.updatePlan)
+	e.DELETE("/feeding/plan/:id", a.deletePlan)
+	e.POST("/feeding", a.addFeeding)
+	e.GET("/feeding/overdue", a.getOverdueFeedings)
+	e.GET("/keeper", a.getAllKeepers)
+	e.GET("/keeper/:id", a.getKeeper)
+	e.POST("/keeper", a.addKeeper)
+	e.PUT("/keeper/:id", a.updateKeeper)
+	e.DELETE("/keeper/:id", a.deleteKeeper)
+	e.GET("/keeper/:id/assignment", a.getKeeperAssignments)
+	e.POST("/keeper/:id/assignment", a.addKeeperAssignment)
+	e.DELETE("/assignment/:id", a.deleteKeeperAssignment)
+	e.GET("/keeper/:id/animal", a.getKeeperAnimals)
+	e.GET("/animal/:id/keeper", a.getAnimalKeepers)
+	e.GET("/me", a.getMe)
+	e.GET("/user", a.getAllUsers)
+	e.GET("/user/:id", a.getUser)
+	e.POST("/user", a.addUser)
+	e.PUT("/user/:id", a.updateUser)
+	e.DELETE("/user/:id", a.deleteUser)
 	return a, nil
 }
 
@@ -73,7 +169,7 @@ func logger() echo.MiddlewareFunc {
 			err := next(c)
 			stop := time.Now()
 
-			zl.Debug().
+			tracing.Log(req.Context(), zl.Debug()).
 				Str("remote", req.RemoteAddr).
 				Str("user_agent", req.UserAgent()).
 				Str("method", req.Method).
@@ -87,6 +183,85 @@ func logger() echo.MiddlewareFunc {
 	}
 }
 
+// traced продолжает трассу из заголовков traceparent или начинает новую
+// и кладет спан в контекст запроса
+func traced() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			route := c.Path()
+			ctx, span := tracing.Tracer().Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+			status := c.Response().Status
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return nil
+		}
+	}
+}
+
+// observe пишет в метрики каждый запрос; статус берется уже после errorHandler
+func observe(m *metrics.Metrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			m.ObserveHTTP(c.Request().Method, route, c.Response().Status, time.Since(start))
+			return nil
+		}
+	}
+}
+
+// authenticate кладет личность вызывающего в Ctx; маршруты из public доступны без нее
+func (a *API) authenticate(public ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if slices.Contains(public, c.Path()) {
+				return next(c)
+			}
+			cc, err := getParentContext(c)
+			if err != nil {
+				return err
+			}
+			h := c.Request().Header
+			cred, err := auth.ParseCredentials(h.Get(echo.HeaderAuthorization), h.Get("X-API-Key"))
+			if err != nil {
+				return err
+			}
+			id, err := a.authn.Authenticate(cc.Ctx, cred)
+			if err != nil {
+				return err
+			}
+			trace.SpanFromContext(cc.Ctx).SetAttributes(semconv.EnduserID(id.Subject))
+			cc.Ctx = auth.WithIdentity(cc.Ctx, id)
+			return next(c)
+		}
+	}
+}
+
 func healthCheck(e echo.Context) error {
 	return e.JSON(http.StatusOK, struct {
 		Message string
@@ -94,23 +269,63 @@ func healthCheck(e echo.Context) error {
 }
 
 type (
-	//todo
 	mineAnimalfull struct {
-		IdAnim  int64  `json:"id_anim"`
-		NameAn  string `json:"name_animal"`
-		Age     int    `json:"age"`
-		Gender  string `json:"gender"`
-		Title   string `json:"title"`
-		Descrip string `json:"description"`
-		Mood    string `json:"mood"`
+		mineAnimal
+		Mood        string           `json:"mood"`
+		MoodReasons []mineMoodReason `json:"mood_reasons"`
+		// только для ?include=health и ?include=measurements, можно оба через запятую
+		Health            *mineHealth      `json:"health,omitempty"`
+		LatestMeasurement *mineMeasurement `json:"latest_measurement,omitempty"`
+	}
+
+	// mineMoodReason - почему правило rule склоняет к настроению mood с весом weight
+	mineMoodReason struct {
+		Rule   string `json:"rule"`
+		Mood   string `json:"mood"`
+		Weight int    `json:"weight"`
+		Reason string `json:"reason"`
+	}
+
+	// mineAnimal - age вычисляется из birth_date на момент ответа
+	mineAnimal struct {
+		IdAnim         int64   `json:"id_anim"`
+		NameAn         string  `json:"name_animal"`
+		BirthDate      string  `json:"birth_date"`
+		BirthPrecision string  `json:"birth_precision"`
+		Age            mineAge `json:"age"`
+		Gender         string  `json:"gender"`
+		Title          string  `json:"title"`
+		Descrip        string  `json:"description"`
+		IdEncl         *int64  `json:"enclosure_id,omitempty"`
+	}
+
+	mineAge struct {
+		Years  int `json:"years"`
+		Months int `json:"months"`
+	}
+
+	// mineAnimalRequest - тело POST /animal, PUT /animal/:id и PATCH /animal/:id:
+	//
+	//	{"name_animal": "Klepa", "birth_date": "2011-05-01", "birth_precision": "month", "gender": "f", "title": "cat"}
+	//
+	// title - название существующего вида, birth_precision - exact (по умолчанию), month, year
+	// или estimated. Для POST и PUT обязательны все поля, кроме birth_precision,
+	// для PATCH передаются только те, что нужно изменить
+	mineAnimalRequest struct {
+		NameAn         *string `json:"name_animal"`
+		BirthDate      *string `json:"birth_date"`
+		BirthPrecision *string `json:"birth_precision"`
+		Gender         *string `json:"gender"`
+		Title          *string `json:"title"`
 	}
 
 	mineRes struct {
-		str string `json:"string for res"`
+		Str string `json:"result"`
 	}
 
-	mineError struct {
-		msg string `json:"msg"`
+	minePage struct {
+		Animals       []mineAnimal `json:"animals"`
+		NextPageToken string       `json:"next_page_token"`
 	}
 )
 
@@ -119,18 +334,46 @@ func (a *API) getAnimal(e echo.Context) error {
 	if err != nil {
 		return err
 	}
-	id, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	id, err := parseID(e)
 	if err != nil {
-		zl.Error().Msg("id is empty")
-		return e.NoContent(echo.ErrBadRequest.Code)
+		return err
 	}
 
 	animal, err := a.s.GetAnimal(cc.Ctx, id)
 	if err != nil {
-		zl.Error().Err(err).Int64("mine id animal", id).Msg("can't find animal")
 		return err
 	}
-	res := &mineAnimalfull{animal.IdAnim, animal.NameAn, animal.Age, animal.Gender, animal.Title, animal.Descrip, string(animal.Mood)}
+	res := &mineAnimalfull{
+		mineAnimal:  toMineAnimal(&animal.Animal),
+		Mood:        string(animal.Mood),
+		MoodReasons: make([]mineMoodReason, 0, len(animal.MoodReasons)),
+	}
+	for _, r := range animal.MoodReasons {
+		res.MoodReasons = append(res.MoodReasons, mineMoodReason{r.Rule, string(r.Mood), r.Weight, r.Reason})
+	}
+	if include := e.QueryParam("include"); include != "" {
+		for _, part := range strings.Split(include, ",") {
+			switch part {
+			case "health":
+				health, err := a.med.HealthSummary(cc.Ctx, id)
+				if err != nil {
+					return err
+				}
+				res.Health = toMineHealth(health)
+			case "measurements":
+				m, err := a.meas.Latest(cc.Ctx, id)
+				if err != nil {
+					return err
+				}
+				if m != nil {
+					latest := toMineMeasurement(m)
+					res.LatestMeasurement = &latest
+				}
+			default:
+				return errs.BadRequest("incorrect include %s, expected health or measurements", part)
+			}
+		}
+	}
 	return e.JSON(http.StatusOK, res)
 }
 
@@ -139,23 +382,143 @@ func (a *API) getAllAnimal(e echo.Context) error {
 	if err != nil {
 		return err
 	}
-	limit, err := strconv.Atoi(e.QueryParam("limit"))
-	if err != nil || limit <= 0 {
-		return e.JSON(echo.ErrBadRequest.Code, mineError{msg: "incorrect limit"})
+	var limit int
+	if l := e.QueryParam("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			return errs.BadRequest("incorrect limit")
+		}
 	}
 
-	offset, err := strconv.Atoi(e.QueryParam("offset"))
-	if err != nil || offset < 0 {
-		return e.JSON(echo.ErrBadRequest.Code, mineError{msg: "incorrect offset"})
+	filter, err := parseAnimalFilter(e)
+	if err != nil {
+		return err
+	}
+	order, err := service.ParseAnimalOrder(e.QueryParam("sort"))
+	if err != nil {
+		return err
 	}
-	limit = min(limit, MAX_LIMIT)
 
-	animals, err := a.s.GetAllAnimal(cc.Ctx, offset, limit)
-	if err != nil || offset < 0 {
-		zl.Error().Err(err).Str("error", "it is no OK").Msg("can't find animal")
+	page, err := a.s.GetAllAnimal(cc.Ctx, filter, order, e.QueryParam("page_token"), limit)
+	if err != nil {
 		return err
 	}
-	return e.JSON(http.StatusOK, animals)
+	res := minePage{Animals: make([]mineAnimal, 0, len(page.Animals)), NextPageToken: page.NextPageToken}
+	for i := range page.Animals {
+		res.Animals = append(res.Animals, toMineAnimal(&page.Animals[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// parseAnimalFilter собирает фильтр из ?species=&gender=&name_prefix=&min_age=&max_age=
+// &born_after=&born_before=&enclosure_id=, даты в формате 2006-01-02
+func parseAnimalFilter(e echo.Context) (models.AnimalFilter, error) {
+	f := models.AnimalFilter{
+		Species:    e.QueryParam("species"),
+		Gender:     e.QueryParam("gender"),
+		NamePrefix: e.QueryParam("name_prefix"),
+	}
+	for _, p := range []struct {
+		name string
+		dst  **int
+	}{{"min_age", &f.MinAge}, {"max_age", &f.MaxAge}} {
+		v := e.QueryParam(p.name)
+		if v == "" {
+			continue
+		}
+		age, err := strconv.Atoi(v)
+		if err != nil || age < 0 {
+			return f, errs.BadRequest("incorrect %s", p.name)
+		}
+		*p.dst = &age
+	}
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"born_after", &f.BornAfter}, {"born_before", &f.BornBefore}} {
+		v := e.QueryParam(p.name)
+		if v == "" {
+			continue
+		}
+		d, err := time.Parse(service.DateLayout, v)
+		if err != nil {
+			return f, errs.BadRequest("incorrect %s, expected YYYY-MM-DD", p.name)
+		}
+		*p.dst = &d
+	}
+	if v := e.QueryParam("enclosure_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return f, errs.BadRequest("incorrect enclosure_id")
+		}
+		f.Enclosure = &id
+	}
+	return f, nil
+}
+
+func toMineAnimal(an *models.Animal) mineAnimal {
+	age := service.AgeAt(an.BirthDate, time.Now())
+	return mineAnimal{
+		IdAnim:         an.IdAnim,
+		NameAn:         an.NameAn,
+		BirthDate:      an.BirthDate.Format(service.DateLayout),
+		BirthPrecision: an.BirthPrecision,
+		Age:            mineAge{age.Years, age.Months},
+		Gender:         an.Gender,
+		Title:          an.Title,
+		Descrip:        an.Descrip,
+		IdEncl:         an.IdEncl,
+	}
+}
+
+func bindAnimal(e echo.Context) (*mineAnimalRequest, error) {
+	var req mineAnimalRequest
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return nil, errs.BadRequest("incorrect animal: %s", bindMessage(err))
+	}
+	return &req, nil
+}
+
+// full проверяет, что заданы все поля, и собирает из них животное
+func (r *mineAnimalRequest) full() (*models.Animal, error) {
+	var missing []string
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"name_animal", r.NameAn != nil},
+		{"birth_date", r.BirthDate != nil},
+		{"gender", r.Gender != nil},
+		{"title", r.Title != nil},
+	} {
+		if !f.set {
+			missing = append(missing, f.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errs.BadRequest("missing fields: %s", strings.Join(missing, ", "))
+	}
+	birth, err := r.birthDate()
+	if err != nil {
+		return nil, err
+	}
+	an := &models.Animal{NameAn: *r.NameAn, BirthDate: *birth, Gender: *r.Gender, Title: *r.Title}
+	if r.BirthPrecision != nil {
+		an.BirthPrecision = *r.BirthPrecision
+	}
+	return an, nil
+}
+
+// birthDate - nil, если birth_date не передана
+func (r *mineAnimalRequest) birthDate() (*time.Time, error) {
+	if r.BirthDate == nil {
+		return nil, nil
+	}
+	d, err := time.Parse(service.DateLayout, *r.BirthDate)
+	if err != nil {
+		return nil, errs.BadRequest("incorrect birth_date, expected YYYY-MM-DD")
+	}
+	return &d, nil
 }
 
 func (a *API) addAnimal(e echo.Context) error {
@@ -163,22 +526,20 @@ func (a *API) addAnimal(e echo.Context) error {
 	if err != nil {
 		return err
 	}
-	age, err := strconv.Atoi(e.QueryParam("age"))
+	req, err := bindAnimal(e)
+	if err != nil {
+		return err
+	}
+	newAnimal, err := req.full()
 	if err != nil {
-		return e.JSON(echo.ErrBadRequest.Code, mineError{msg: "incorrect age of animal"})
+		return err
 	}
-
-	newAnimal := models.Animal{
-		NameAn:  e.QueryParam("name_animal"),
-		Age:     age,
-		Gender:  e.QueryParam("gender"),
-		Title:   e.QueryParam("title"),
-		Descrip: e.QueryParam("description")}
-	err = a.s.AddAnimal(cc.Ctx, &newAnimal)
+	animal, err := a.s.AddAnimal(cc.Ctx, newAnimal)
 	if err != nil {
-		return e.JSON(echo.ErrNotImplemented.Code, mineError{msg: "don't create animal"})
+		return err
 	}
-	return e.JSON(http.StatusCreated, mineRes{str: "correct create animal"})
+	e.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/animal/%d", animal.IdAnim))
+	return e.JSON(http.StatusCreated, toMineAnimal(animal))
 }
 
 func (a *API) updateAnimal(e echo.Context) error {
@@ -186,23 +547,54 @@ func (a *API) updateAnimal(e echo.Context) error {
 	if err != nil {
 		return err
 	}
-	age, err := strconv.Atoi(e.QueryParam("age"))
+	id, err := parseID(e)
 	if err != nil {
-		return e.JSON(echo.ErrBadRequest.Code, mineError{msg: "incorrect age of animal"})
+		return err
 	}
-
-	newAnimal := models.Animal{
-		NameAn:  e.QueryParam("name_animal"),
-		Age:     age,
-		Gender:  e.QueryParam("gender"),
-		Title:   e.QueryParam("title"),
-		Descrip: e.QueryParam("description")}
-	err = a.s.Update(cc.Ctx, &newAnimal)
+	req, err := bindAnimal(e)
 	if err != nil {
-		return e.JSON(echo.ErrNotImplemented.Code, mineError{msg: "don't update animal"})
+		return err
 	}
-	return e.JSON(http.StatusOK, mineRes{str: "correct update animal"})
+	newAnimal, err := req.full()
+	if err != nil {
+		return err
+	}
+	newAnimal.IdAnim = id
+	animal, err := a.s.Update(cc.Ctx, newAnimal)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineAnimal(animal))
+}
 
+func (a *API) patchAnimal(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	req, err := bindAnimal(e)
+	if err != nil {
+		return err
+	}
+	birth, err := req.birthDate()
+	if err != nil {
+		return err
+	}
+	animal, err := a.s.Patch(cc.Ctx, id, &models.AnimalPatch{
+		NameAn:         req.NameAn,
+		BirthDate:      birth,
+		BirthPrecision: req.BirthPrecision,
+		Gender:         req.Gender,
+		Title:          req.Title,
+	})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineAnimal(animal))
 }
 
 func (a *API) deleteAnimal(e echo.Context) error {
@@ -210,20 +602,26 @@ func (a *API) deleteAnimal(e echo.Context) error {
 	if err != nil {
 		return err
 	}
-	id, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	id, err := parseID(e)
 	if err != nil {
-		zl.Error().Msg("id is empty")
-		return errors.New("empty id")
+		return err
 	}
 	err = a.s.DeleteAnimal(cc.Ctx, id)
 	if err != nil {
-		zl.Error().Err(err).Int64("mine id anim", id).Msg("can't delete animal")
 		return err
 	}
-	res := &mineRes{str: "you kill that animal!!!!"}
+	res := &mineRes{Str: "you kill that animal!!!!"}
 	return e.JSON(http.StatusOK, res)
 }
 
+func parseID(e echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(e.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errs.BadRequest("incorrect id")
+	}
+	return id, nil
+}
+
 func getParentContext(e echo.Context) (*Context, error) {
 	cc, ok := e.(*Context)
 	if !ok {
@@ -246,9 +644,7 @@ func (a *API) Close() error {
 	return a.e.Close()
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
+// Shutdown дожидается обработки текущих запросов, но не дольше ctx
+func (a *API) Shutdown(ctx context.Context) error {
+	return a.e.Shutdown(ctx)
 }
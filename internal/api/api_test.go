@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/metrics"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPI(t *testing.T) *API {
+	return newTestAPIWith(t, auth.Anonymous{Role: models.RoleAdmin})
+}
+
+// newTestAPIWith - роли субъектов authn берутся из справочника, root - администратор
+func newTestAPIWith(t *testing.T, authn auth.Authenticator) *API {
+	ctx := context.Background()
+	st := database.NewMemStorage()
+	species := database.NewMemSpeciesRepository(st)
+	_, err := species.Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	tokens, err := service.NewPageTokenCodec("secret")
+	require.NoError(t, err)
+	v := service.NewValidator()
+	animals := database.NewMemAnimalRepository(st)
+	enclosures := database.NewMemEnclosureRepository(st)
+	keepers := database.NewMemKeeperRepository(st)
+	moods := database.NewMemMoodRepository(st)
+	observations := database.NewMemObservationRepository(st)
+	// ночь отключена, чтобы настроение не зависело от времени запуска
+	moodCfg := &service.MoodConfig{HungryAfter: 8 * time.Hour, FedRecently: time.Hour, MedicalWindow: 7 * 24 * time.Hour, ObservationWindow: 24 * time.Hour}
+	mood := service.NewMoodEngine(service.DefaultMoodRules(moodCfg), database.NewMemFeedingRepository(st),
+		database.NewMemMedicalRepository(st), enclosures, observations, moods, moodCfg)
+	s := service.NewAnimalService(animals, enclosures, database.NewMemLineageRepository(st), keepers, mood, tokens, v)
+	users := database.NewMemUserRepository(st)
+	a, err := New(ctx, &Config{}, s,
+		service.NewSpeciesService(species, v),
+		service.NewEnclosureService(enclosures, keepers, v),
+		service.NewFeedingService(database.NewMemFeedingRepository(st), animals, v, &service.FeedingConfig{Grace: 30 * time.Minute}),
+		service.NewMedicalService(database.NewMemMedicalRepository(st), animals, v),
+		service.NewMeasurementService(database.NewMemMeasurementRepository(st), animals, v,
+			&service.MeasurementConfig{Window: 30 * 24 * time.Hour, MaxLoss: 0.1, MaxGain: 0.2}),
+		service.NewLineageService(database.NewMemLineageRepository(st), animals, v, &service.BreedingConfig{MaxInbreeding: 0.0625}),
+		service.NewMoodHistoryService(moods, animals, v),
+		service.NewObservationService(observations, animals, v),
+		service.NewUserService(users, v),
+		service.NewKeeperService(keepers, animals, enclosures, v),
+		&fakeReadiness{}, service.NewRoleResolver(authn, users, []string{"root"}), metrics.New())
+	require.NoError(t, err)
+	return a
+}
+
+func (a *API) do(method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	a.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAnimalCreateAndPatch(t *testing.T) {
+	a := newTestAPI(t)
+
+	rec := a.do(http.MethodPost, "/animal", `{"name_animal":"Klepa","birth_date":"2011-03-14","gender":"f","title":"cat"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created mineAnimal
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "/animal/1", rec.Header().Get("Location"))
+	age := service.AgeAt(time.Date(2011, 3, 14, 0, 0, 0, 0, time.UTC), time.Now())
+	assert.Equal(t, mineAnimal{1, "Klepa", "2011-03-14", "exact", mineAge{age.Years, age.Months}, "f", "cat", "meow", nil}, created)
+
+	rec = a.do(http.MethodPatch, "/animal/1", `{"birth_date":"2011-03-20","birth_precision":"month"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var patched mineAnimal
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &patched))
+	assert.Equal(t, "2011-03-01", patched.BirthDate)
+	assert.Equal(t, "month", patched.BirthPrecision)
+}
+
+func TestAnimalFilterByBirthDate(t *testing.T) {
+	a := newTestAPI(t)
+	for _, body := range []string{
+		`{"name_animal":"Zu","birth_date":"2002-01-01","birth_precision":"year","gender":"f","title":"cat"}`,
+		`{"name_animal":"Zina","birth_date":"2024-04-20","birth_precision":"estimated","gender":"f","title":"cat"}`,
+	} {
+		rec := a.do(http.MethodPost, "/animal", body)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	rec := a.do(http.MethodGet, "/animal?born_after=2010-01-01", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var page minePage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	require.Len(t, page.Animals, 1)
+	assert.Equal(t, "Zina", page.Animals[0].NameAn)
+
+	rec = a.do(http.MethodGet, "/animal?born_after=1.1.2010", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAnimalErrors(t *testing.T) {
+	a := newTestAPI(t)
+
+	for _, tc := range []struct {
+		method, target, body string
+		status               int
+		code                 string
+	}{
+		{http.MethodGet, "/animal/7", "", http.StatusNotFound, "not_found"},
+		{http.MethodGet, "/animal/x", "", http.StatusBadRequest, "bad_request"},
+		{http.MethodPost, "/animal", `{"name_animal":"Rex"}`, http.StatusBadRequest, "bad_request"},
+		{http.MethodPost, "/animal", `{"name_animal":"Rex","birth_date":"2025-01-01","gender":"m","title":"dog"}`, http.StatusBadRequest, "unknown_species"},
+		{http.MethodPut, "/animal/7", `{"name_animal":"Rex","birth_date":"2025-01-01","gender":"m","title":"cat"}`, http.StatusNotFound, "not_found"},
+		{http.MethodGet, "/animal?page_token=forged", "", http.StatusBadRequest, "bad_request"},
+	} {
+		rec := a.do(tc.method, tc.target, tc.body)
+		assert.Equal(t, tc.status, rec.Code, tc.target)
+		var res mineError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, tc.code, res.Error.Code, tc.target)
+		assert.NotEmpty(t, res.Error.Message)
+	}
+}
+
+func TestAnimalValidationListsAllViolations(t *testing.T) {
+	a := newTestAPI(t)
+
+	rec := a.do(http.MethodPost, "/animal", `{"name_animal":"","birth_date":"2999-01-01","birth_precision":"roughly","gender":"x","title":"cat"}`)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	var res mineError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "validation", res.Error.Code)
+	var fields []string
+	for _, f := range res.Error.Fields {
+		fields = append(fields, f.Field+":"+f.Rule)
+	}
+	assert.Equal(t, []string{"name_animal:required", "birth_date:max", "birth_precision:one_of", "gender:one_of"}, fields)
+}
+
+func TestMetricsByRoute(t *testing.T) {
+	a := newTestAPI(t)
+
+	require.Equal(t, http.StatusNotFound, a.do(http.MethodGet, "/animal/42", "").Code)
+	require.Equal(t, http.StatusNotFound, a.do(http.MethodGet, "/animal/43", "").Code)
+
+	rec := a.do(http.MethodGet, "/metrics", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `zooad_http_requests_total{method="GET",route="/animal/:id",status="404"} 2`)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthentication(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	keys, err := auth.NewAPIKeys(map[string]string{"feeder": "feeder-key-0123456789"})
+	require.NoError(t, err)
+	j, err := auth.NewJWT(&auth.JWTConfig{HS256Secret: secret})
+	require.NoError(t, err)
+	a := newTestAPIWith(t, auth.Chain{keys, j})
+
+	var seen *auth.Identity
+	a.e.GET("/whoami", func(e echo.Context) error {
+		cc, err := getParentContext(e)
+		if err != nil {
+			return err
+		}
+		seen = auth.FromContext(cc.Ctx)
+		return e.NoContent(http.StatusNoContent)
+	})
+	call := func(method, target string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		a.e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := call(http.MethodDelete, "/animal/1")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, rec.Body.String())
+	assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+	assert.JSONEq(t, `{"error":{"code":"unauthenticated","message":"credentials required"}}`, rec.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/animal", "X-API-Key", "wrong-key-0123456789").Code)
+	assert.Equal(t, http.StatusOK, call(http.MethodGet, "/livez").Code, "probes stay public")
+
+	rec = call(http.MethodGet, "/whoami", "X-API-Key", "feeder-key-0123456789")
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.Equal(t, &auth.Identity{Subject: "feeder", Method: auth.MethodAPIKey}, seen)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject: "ivan", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}).SignedString(secret)
+	require.NoError(t, err)
+	rec = call(http.MethodGet, "/whoami", echo.HeaderAuthorization, "Bearer "+token)
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.Equal(t, &auth.Identity{Subject: "ivan", Method: auth.MethodJWT}, seen)
+
+	rec = call(http.MethodGet, "/animal", echo.HeaderAuthorization, "Basic dXNlcjpwYXNz")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+)
+
+type (
+	// mineEnclosure - вольер в ответах и тело POST/PUT /enclosure:
+	//
+	//	{"name": "Cat house", "zone": "north", "capacity": 10, "habitat": "forest", "species": ["cat"]}
+	//
+	// occupancy только для чтения
+	mineEnclosure struct {
+		IdEncl    int64    `json:"id"`
+		Name      string   `json:"name"`
+		Zone      string   `json:"zone"`
+		Capacity  int      `json:"capacity"`
+		Habitat   string   `json:"habitat"`
+		Species   []string `json:"species"`
+		Occupancy int      `json:"occupancy"`
+	}
+
+	// mineAssignment - тело PUT /animal/:id/enclosure
+	mineAssignment struct {
+		IdEncl *int64 `json:"enclosure_id"`
+	}
+)
+
+func toMineEnclosure(enc *models.Enclosure) mineEnclosure {
+	return mineEnclosure{
+		IdEncl:    enc.IdEncl,
+		Name:      enc.Name,
+		Zone:      enc.Zone,
+		Capacity:  enc.Capacity,
+		Habitat:   enc.Habitat,
+		Species:   enc.Species,
+		Occupancy: enc.Occupancy,
+	}
+}
+
+func bindEnclosure(e echo.Context) (*models.Enclosure, error) {
+	var req mineEnclosure
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return nil, errs.BadRequest("incorrect enclosure: %s", bindMessage(err))
+	}
+	return &models.Enclosure{
+		Name:     req.Name,
+		Zone:     req.Zone,
+		Capacity: req.Capacity,
+		Habitat:  req.Habitat,
+		Species:  req.Species,
+	}, nil
+}
+
+func (a *API) getAllEnclosures(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	enclosures, err := a.enc.ListEnclosures(cc.Ctx)
+	if err != nil {
+		return err
+	}
+	res := make([]mineEnclosure, 0, len(enclosures))
+	for i := range enclosures {
+		res = append(res, toMineEnclosure(&enclosures[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) getEnclosure(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	enc, err := a.enc.GetEnclosure(cc.Ctx, id)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineEnclosure(enc))
+}
+
+func (a *API) addEnclosure(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	req, err := bindEnclosure(e)
+	if err != nil {
+		return err
+	}
+	enc, err := a.enc.AddEnclosure(cc.Ctx, req)
+	if err != nil {
+		return err
+	}
+	e.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/enclosure/%d", enc.IdEncl))
+	return e.JSON(http.StatusCreated, toMineEnclosure(enc))
+}
+
+func (a *API) updateEnclosure(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	req, err := bindEnclosure(e)
+	if err != nil {
+		return err
+	}
+	req.IdEncl = id
+	enc, err := a.enc.UpdateEnclosure(cc.Ctx, req)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineEnclosure(enc))
+}
+
+func (a *API) deleteEnclosure(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	if err := a.enc.DeleteEnclosure(cc.Ctx, id); err != nil {
+		return err
+	}
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (a *API) assignEnclosure(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineAssignment
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect assignment: %s", bindMessage(err))
+	}
+	if req.IdEncl == nil || *req.IdEncl <= 0 {
+		return errs.Invalid([]errs.FieldError{{Field: "enclosure_id", Rule: service.RuleRequired, Message: "enclosure_id must be a positive id"}})
+	}
+	animal, err := a.s.AssignEnclosure(cc.Ctx, id, req.IdEncl)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineAnimal(animal))
+}
+
+func (a *API) unassignEnclosure(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	animal, err := a.s.AssignEnclosure(cc.Ctx, id, nil)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineAnimal(animal))
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnclosureHousing(t *testing.T) {
+	a := newTestAPI(t)
+
+	rec := a.do(http.MethodPost, "/enclosure", `{"name":"Cat house","zone":"north","capacity":1,"habitat":"forest","species":["cat"]}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var enc mineEnclosure
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &enc))
+	assert.Equal(t, mineEnclosure{1, "Cat house", "north", 1, "forest", []string{"cat"}, 0}, enc)
+
+	for _, name := range []string{"Klepa", "Tom"} {
+		rec = a.do(http.MethodPost, "/animal", `{"name_animal":"`+name+`","birth_date":"2023-05-01","gender":"f","title":"cat"}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	rec = a.do(http.MethodPut, "/animal/1/enclosure", `{"enclosure_id":1}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var housed mineAnimal
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &housed))
+	require.NotNil(t, housed.IdEncl)
+	assert.Equal(t, int64(1), *housed.IdEncl)
+
+	// мест больше нет
+	rec = a.do(http.MethodPut, "/animal/2/enclosure", `{"enclosure_id":1}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+
+	// занятый вольер не удалить и не уменьшить
+	assert.Equal(t, http.StatusConflict, a.do(http.MethodDelete, "/enclosure/1", "").Code)
+	rec = a.do(http.MethodPut, "/enclosure/1", `{"name":"Cat house","zone":"north","capacity":1,"habitat":"forest","species":["dog"]}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+
+	rec = a.do(http.MethodGet, "/animal?enclosure_id=1", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var page minePage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	require.Len(t, page.Animals, 1)
+	assert.Equal(t, "Klepa", page.Animals[0].NameAn)
+
+	require.Equal(t, http.StatusOK, a.do(http.MethodDelete, "/animal/1/enclosure", "").Code)
+	assert.Equal(t, http.StatusNoContent, a.do(http.MethodDelete, "/enclosure/1", "").Code)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	"github.com/mi-raf/zooad/internal/tracing"
+	zl "github.com/rs/zerolog/log"
+)
+
+type (
+	mineFieldError struct {
+		Field   string `json:"field"`
+		Rule    string `json:"rule"`
+		Message string `json:"message"`
+	}
+
+	mineErrorBody struct {
+		Code    string           `json:"code"`
+		Message string           `json:"message"`
+		Fields  []mineFieldError `json:"fields,omitempty"`
+	}
+
+	// mineError - единый формат ответа с ошибкой для всех ручек
+	mineError struct {
+		Error mineErrorBody `json:"error"`
+	}
+)
+
+var kindStatus = map[errs.Kind]int{
+	errs.KindNotFound:        http.StatusNotFound,
+	errs.KindUnknownSpecies:  http.StatusBadRequest,
+	errs.KindValidation:      http.StatusUnprocessableEntity,
+	errs.KindConflict:        http.StatusConflict,
+	errs.KindBadRequest:      http.StatusBadRequest,
+	errs.KindUnauthenticated: http.StatusUnauthorized,
+	errs.KindForbidden:       http.StatusForbidden,
+}
+
+// errorHandler переводит ошибки ручек в HTTP-ответы: доменные ошибки по Kind,
+// ошибки самого echo (404 на маршрут, 405 и т.п.) - по их коду
+func errorHandler(err error, e echo.Context) {
+	if e.Response().Committed {
+		return
+	}
+
+	status, body := http.StatusInternalServerError, mineError{}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		status = he.Code
+		body.Error = mineErrorBody{Code: "http_error", Message: http.StatusText(he.Code)}
+		if msg, ok := he.Message.(string); ok {
+			body.Error.Message = msg
+		}
+	} else {
+		kind := errs.KindOf(err)
+		if s, ok := kindStatus[kind]; ok {
+			status = s
+		}
+		body.Error = mineErrorBody{Code: kind.String(), Message: errs.Message(err)}
+		if kind == errs.KindUnauthenticated {
+			e.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+		}
+		for _, f := range errs.Fields(err) {
+			body.Error.Fields = append(body.Error.Fields, mineFieldError(f))
+		}
+	}
+
+	if status >= http.StatusInternalServerError {
+		tracing.Log(e.Request().Context(), zl.Error()).Err(err).Str("path", e.Path()).Msg("request failed")
+	} else {
+		zl.Debug().Err(err).Str("path", e.Path()).Msg("request rejected")
+	}
+
+	if e.Request().Method == http.MethodHead {
+		err = e.NoContent(status)
+	} else {
+		err = e.JSON(status, body)
+	}
+	if err != nil {
+		zl.Error().Err(err).Msg("can't write error response")
+	}
+}
+
+// bindMessage достает из ошибки биндера echo текст без HTTP-обвязки
+func bindMessage(err error) string {
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		if he.Internal != nil {
+			return he.Internal.Error()
+		}
+		if msg, ok := he.Message.(string); ok {
+			return msg
+		}
+	}
+	return err.Error()
+}
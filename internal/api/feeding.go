@@ -0,0 +1,286 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+type (
+	// minePlan - план кормления в ответах и тело POST/PUT /feeding/plan:
+	//
+	//	{"species": "cat", "food": "fish", "quantity": 200, "unit": "g", "times": ["08:00", "18:00"]}
+	//
+	// вместо species можно задать animal_id - тогда план только для этого животного
+	minePlan struct {
+		IdPlan   int64    `json:"id"`
+		Species  string   `json:"species,omitempty"`
+		IdAnim   *int64   `json:"animal_id,omitempty"`
+		Food     string   `json:"food"`
+		Quantity float64  `json:"quantity"`
+		Unit     string   `json:"unit"`
+		Times    []string `json:"times"`
+	}
+
+	// mineFeeding - запись журнала и тело POST /feeding:
+	//
+	//	{"animal_id": 1, "plan_id": 2, "keeper": "Ivan"}
+	//
+	// food, quantity и unit берутся из плана, если не заданы; без fed_at - кормление сейчас
+	mineFeeding struct {
+		IdFeed   int64     `json:"id"`
+		IdAnim   int64     `json:"animal_id"`
+		IdPlan   *int64    `json:"plan_id,omitempty"`
+		Food     string    `json:"food"`
+		Quantity float64   `json:"quantity"`
+		Unit     string    `json:"unit"`
+		Keeper   string    `json:"keeper"`
+		FedAt    time.Time `json:"fed_at"`
+		Notes    string    `json:"notes"`
+	}
+
+	mineOverdue struct {
+		IdAnim    int64      `json:"animal_id"`
+		NameAn    string     `json:"name_animal"`
+		Title     string     `json:"title"`
+		IdPlan    int64      `json:"plan_id"`
+		Food      string     `json:"food"`
+		DueAt     time.Time  `json:"due_at"`
+		LastFedAt *time.Time `json:"last_fed_at,omitempty"`
+	}
+)
+
+func toMinePlan(p *models.FeedingPlan) minePlan {
+	return minePlan{
+		IdPlan:   p.IdPlan,
+		Species:  p.Species,
+		IdAnim:   p.IdAnim,
+		Food:     p.Food,
+		Quantity: p.Quantity,
+		Unit:     p.Unit,
+		Times:    p.Times,
+	}
+}
+
+func toMineFeeding(f *models.Feeding) mineFeeding {
+	return mineFeeding{
+		IdFeed:   f.IdFeed,
+		IdAnim:   f.IdAnim,
+		IdPlan:   f.IdPlan,
+		Food:     f.Food,
+		Quantity: f.Quantity,
+		Unit:     f.Unit,
+		Keeper:   f.Keeper,
+		FedAt:    f.FedAt,
+		Notes:    f.Notes,
+	}
+}
+
+func bindPlan(e echo.Context) (*models.FeedingPlan, error) {
+	var req minePlan
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return nil, errs.BadRequest("incorrect feeding plan: %s", bindMessage(err))
+	}
+	return &models.FeedingPlan{
+		Species:  req.Species,
+		IdAnim:   req.IdAnim,
+		Food:     req.Food,
+		Quantity: req.Quantity,
+		Unit:     req.Unit,
+		Times:    req.Times,
+	}, nil
+}
+
+// parseTime разбирает необязательный параметр в формате RFC 3339
+func parseTime(e echo.Context, name string) (time.Time, error) {
+	v := e.QueryParam(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, errs.BadRequest("incorrect %s, expected RFC 3339", name)
+	}
+	return t, nil
+}
+
+func (a *API) getAllPlans(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	f := models.FeedingPlanFilter{Species: e.QueryParam("species")}
+	if v := e.QueryParam("animal_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return errs.BadRequest("incorrect animal_id")
+		}
+		f.IdAnim = &id
+	}
+	plans, err := a.fd.ListPlans(cc.Ctx, f)
+	if err != nil {
+		return err
+	}
+	res := make([]minePlan, 0, len(plans))
+	for i := range plans {
+		res = append(res, toMinePlan(&plans[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) getPlan(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	plan, err := a.fd.GetPlan(cc.Ctx, id)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMinePlan(plan))
+}
+
+func (a *API) addPlan(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	req, err := bindPlan(e)
+	if err != nil {
+		return err
+	}
+	plan, err := a.fd.AddPlan(cc.Ctx, req)
+	if err != nil {
+		return err
+	}
+	e.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/feeding/plan/%d", plan.IdPlan))
+	return e.JSON(http.StatusCreated, toMinePlan(plan))
+}
+
+func (a *API) updatePlan(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	req, err := bindPlan(e)
+	if err != nil {
+		return err
+	}
+	req.IdPlan = id
+	plan, err := a.fd.UpdatePlan(cc.Ctx, req)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMinePlan(plan))
+}
+
+func (a *API) deletePlan(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	if err := a.fd.DeletePlan(cc.Ctx, id); err != nil {
+		return err
+	}
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (a *API) addFeeding(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	var req mineFeeding
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect feeding: %s", bindMessage(err))
+	}
+	feed, err := a.fd.RecordFeeding(cc.Ctx, &models.Feeding{
+		IdAnim:   req.IdAnim,
+		IdPlan:   req.IdPlan,
+		Food:     req.Food,
+		Quantity: req.Quantity,
+		Unit:     req.Unit,
+		Keeper:   req.Keeper,
+		FedAt:    req.FedAt,
+		Notes:    req.Notes,
+	})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineFeeding(feed))
+}
+
+// getAnimalFeedings - журнал кормлений животного, ?from=&to= в RFC 3339 и ?limit=
+func (a *API) getAnimalFeedings(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	f := models.FeedingFilter{IdAnim: id}
+	if f.From, err = parseTime(e, "from"); err != nil {
+		return err
+	}
+	if f.To, err = parseTime(e, "to"); err != nil {
+		return err
+	}
+	if l := e.QueryParam("limit"); l != "" {
+		f.Limit, err = strconv.Atoi(l)
+		if err != nil || f.Limit <= 0 {
+			return errs.BadRequest("incorrect limit")
+		}
+	}
+	feeds, err := a.fd.ListFeedings(cc.Ctx, f)
+	if err != nil {
+		return err
+	}
+	res := make([]mineFeeding, 0, len(feeds))
+	for i := range feeds {
+		res = append(res, toMineFeeding(&feeds[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) getOverdueFeedings(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	overdue, err := a.fd.Overdue(cc.Ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	res := make([]mineOverdue, 0, len(overdue))
+	for _, o := range overdue {
+		res = append(res, mineOverdue{
+			IdAnim:    o.IdAnim,
+			NameAn:    o.NameAn,
+			Title:     o.Title,
+			IdPlan:    o.IdPlan,
+			Food:      o.Food,
+			DueAt:     o.DueAt,
+			LastFedAt: o.LastFedAt,
+		})
+	}
+	return e.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedingFlow(t *testing.T) {
+	a := newTestAPI(t)
+
+	rec := a.do(http.MethodPost, "/animal", `{"name_animal":"Klepa","birth_date":"2023-05-01","gender":"f","title":"cat"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	rec = a.do(http.MethodPost, "/feeding/plan", `{"species":"cat","food":"fish","quantity":200,"unit":"g","times":["00:00"]}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var plan minePlan
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &plan))
+	assert.Equal(t, "/feeding/plan/1", rec.Header().Get("Location"))
+	assert.Equal(t, minePlan{1, "cat", nil, "fish", 200, "g", []string{"00:00"}}, plan)
+
+	rec = a.do(http.MethodPost, "/feeding/plan", `{"species":"cat","food":"fish","quantity":200,"unit":"g","times":["noon"]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+
+	// кормлений еще не было - Клепа в списке пропущенных
+	rec = a.do(http.MethodGet, "/feeding/overdue", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var overdue []mineOverdue
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &overdue))
+	require.Len(t, overdue, 1)
+	assert.Equal(t, "Klepa", overdue[0].NameAn)
+
+	rec = a.do(http.MethodPost, "/feeding", `{"animal_id":1,"plan_id":1,"keeper":"Ivan"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var feed mineFeeding
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &feed))
+	assert.Equal(t, "fish", feed.Food)
+	assert.Equal(t, 200.0, feed.Quantity)
+
+	rec = a.do(http.MethodGet, "/feeding/overdue", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[]`, rec.Body.String())
+
+	rec = a.do(http.MethodGet, "/animal/1/feeding?from=2000-01-01T00:00:00Z", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var feeds []mineFeeding
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &feeds))
+	require.Len(t, feeds, 1)
+	assert.Equal(t, feed.IdFeed, feeds[0].IdFeed)
+
+	assert.Equal(t, http.StatusBadRequest, a.do(http.MethodGet, "/animal/1/feeding?from=yesterday", "").Code)
+	assert.Equal(t, http.StatusNotFound, a.do(http.MethodGet, "/animal/2/feeding", "").Code)
+	assert.Equal(t, http.StatusNoContent, a.do(http.MethodDelete, "/feeding/plan/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, a.do(http.MethodGet, "/feeding/plan/1", "").Code)
+}
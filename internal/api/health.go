@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal"
+)
+
+// Readiness - состояние ресурсов по последним проверкам ServiceKeeper
+type Readiness interface {
+	Ready() bool
+	Status() []internal.ServiceStatus
+}
+
+type (
+	mineServiceStatus struct {
+		Name      string     `json:"name"`
+		Status    string     `json:"status"`
+		Error     string     `json:"error,omitempty"`
+		CheckedAt *time.Time `json:"last_check,omitempty"`
+		LatencyMs float64    `json:"latency_ms"`
+	}
+
+	mineReadiness struct {
+		Status   string              `json:"status"`
+		Services []mineServiceStatus `json:"services"`
+	}
+)
+
+// livez отвечает, пока процесс жив и обслуживает запросы; ресурсы не проверяет,
+// иначе kubernetes перезапустит под из-за недоступной базы
+func (a *API) livez(e echo.Context) error {
+	return e.JSON(http.StatusOK, struct {
+		Status string `json:"status"`
+	}{Status: "ok"})
+}
+
+// readyz не пингует ресурсы сам, а отдает результат последней проверки ServiceKeeper
+func (a *API) readyz(e echo.Context) error {
+	statuses := a.health.Status()
+	res := mineReadiness{Status: "ok", Services: make([]mineServiceStatus, 0, len(statuses))}
+	for _, st := range statuses {
+		ms := mineServiceStatus{
+			Name:      st.Name,
+			Status:    st.Status,
+			Error:     st.Error,
+			LatencyMs: float64(st.Latency.Microseconds()) / 1000,
+		}
+		if !st.CheckedAt.IsZero() {
+			checked := st.CheckedAt.UTC()
+			ms.CheckedAt = &checked
+		}
+		res.Services = append(res.Services, ms)
+	}
+	code := http.StatusOK
+	if !a.health.Ready() {
+		res.Status = "unavailable"
+		code = http.StatusServiceUnavailable
+	}
+	return e.JSON(code, res)
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReadiness struct {
+	ready  bool
+	status []internal.ServiceStatus
+}
+
+func (f *fakeReadiness) Ready() bool                      { return f.ready }
+func (f *fakeReadiness) Status() []internal.ServiceStatus { return f.status }
+
+func TestReadyz(t *testing.T) {
+	a := newTestAPI(t)
+	health := a.health.(*fakeReadiness)
+	checked := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	health.ready = true
+	health.status = []internal.ServiceStatus{
+		{Name: "postgres", Status: internal.StatusOK, CheckedAt: checked, Latency: 1500 * time.Microsecond},
+	}
+	rec := a.do(http.MethodGet, "/readyz", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	health.ready = false
+	health.status[0].Status = internal.StatusFailing
+	health.status[0].Error = "connection refused"
+	rec = a.do(http.MethodGet, "/readyz", "")
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code, rec.Body.String())
+	var body mineReadiness
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "unavailable", body.Status)
+	require.Len(t, body.Services, 1)
+	assert.Equal(t, "postgres", body.Services[0].Name)
+	assert.Equal(t, internal.StatusFailing, body.Services[0].Status)
+	assert.Equal(t, "connection refused", body.Services[0].Error)
+	assert.Equal(t, 1.5, body.Services[0].LatencyMs)
+	assert.True(t, checked.Equal(*body.Services[0].CheckedAt))
+
+	// liveness не зависит от ресурсов
+	rec = a.do(http.MethodGet, "/livez", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+type (
+	// mineKeeper - смотритель в ответах и тело POST/PUT /keeper:
+	//
+	//	{"name": "Ivan", "role": "keeper", "contact": "+7 900 000-00-00", "species": ["cat"]}
+	mineKeeper struct {
+		IdKeeper int64    `json:"id"`
+		Name     string   `json:"name"`
+		Role     string   `json:"role"`
+		Contact  string   `json:"contact"`
+		Species  []string `json:"species"`
+	}
+
+	// mineKeeperAssignment - закрепление и тело POST /keeper/:id/assignment,
+	// задается animal_id или enclosure_id
+	mineKeeperAssignment struct {
+		IdAssign   int64     `json:"id"`
+		IdKeeper   int64     `json:"keeper_id"`
+		IdAnim     *int64    `json:"animal_id,omitempty"`
+		IdEncl     *int64    `json:"enclosure_id,omitempty"`
+		AssignedAt time.Time `json:"assigned_at"`
+	}
+
+	// mineResponsible - смотритель животного; enclosure_id - если через вольер
+	mineResponsible struct {
+		mineKeeper
+		IdAssign int64  `json:"assignment_id"`
+		IdEncl   *int64 `json:"enclosure_id,omitempty"`
+	}
+)
+
+func toMineKeeper(k *models.Keeper) mineKeeper {
+	return mineKeeper{IdKeeper: k.IdKeeper, Name: k.Name, Role: k.Role, Contact: k.Contact, Species: k.Species}
+}
+
+func toMineKeeperAssignment(a *models.KeeperAssignment) mineKeeperAssignment {
+	return mineKeeperAssignment{IdAssign: a.IdAssign, IdKeeper: a.IdKeeper, IdAnim: a.IdAnim, IdEncl: a.IdEncl, AssignedAt: a.AssignedAt}
+}
+
+func bindKeeper(e echo.Context) (*models.Keeper, error) {
+	var req mineKeeper
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return nil, errs.BadRequest("incorrect keeper: %s", bindMessage(err))
+	}
+	return &models.Keeper{Name: req.Name, Role: req.Role, Contact: req.Contact, Species: req.Species}, nil
+}
+
+func (a *API) getAllKeepers(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	keepers, err := a.keepers.ListKeepers(cc.Ctx)
+	if err != nil {
+		return err
+	}
+	res := make([]mineKeeper, 0, len(keepers))
+	for i := range keepers {
+		res = append(res, toMineKeeper(&keepers[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) getKeeper(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	k, err := a.keepers.GetKeeper(cc.Ctx, id)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineKeeper(k))
+}
+
+func (a *API) addKeeper(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	req, err := bindKeeper(e)
+	if err != nil {
+		return err
+	}
+	k, err := a.keepers.AddKeeper(cc.Ctx, req)
+	if err != nil {
+		return err
+	}
+	e.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/keeper/%d", k.IdKeeper))
+	return e.JSON(http.StatusCreated, toMineKeeper(k))
+}
+
+func (a *API) updateKeeper(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	req, err := bindKeeper(e)
+	if err != nil {
+		return err
+	}
+	req.IdKeeper = id
+	k, err := a.keepers.UpdateKeeper(cc.Ctx, req)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineKeeper(k))
+}
+
+func (a *API) deleteKeeper(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	if err := a.keepers.DeleteKeeper(cc.Ctx, id); err != nil {
+		return err
+	}
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (a *API) getKeeperAssignments(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	assigns, err := a.keepers.Assignments(cc.Ctx, id)
+	if err != nil {
+		return err
+	}
+	res := make([]mineKeeperAssignment, 0, len(assigns))
+	for i := range assigns {
+		res = append(res, toMineKeeperAssignment(&assigns[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) addKeeperAssignment(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineKeeperAssignment
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect assignment: %s", bindMessage(err))
+	}
+	assign, err := a.keepers.Assign(cc.Ctx, &models.KeeperAssignment{IdKeeper: id, IdAnim: req.IdAnim, IdEncl: req.IdEncl})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineKeeperAssignment(assign))
+}
+
+func (a *API) deleteKeeperAssignment(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	if err := a.keepers.Unassign(cc.Ctx, id); err != nil {
+		return err
+	}
+	return e.NoContent(http.StatusNoContent)
+}
+
+// getKeeperAnimals - животные, за которые отвечает смотритель
+func (a *API) getKeeperAnimals(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	animals, err := a.keepers.CoveredAnimals(cc.Ctx, id)
+	if err != nil {
+		return err
+	}
+	res := make([]mineAnimal, 0, len(animals))
+	for i := range animals {
+		res = append(res, toMineAnimal(&animals[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// getAnimalKeepers - кто отвечает за животное
+func (a *API) getAnimalKeepers(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	responsible, err := a.keepers.Responsible(cc.Ctx, id)
+	if err != nil {
+		return err
+	}
+	res := make([]mineResponsible, 0, len(responsible))
+	for i := range responsible {
+		r := &responsible[i]
+		res = append(res, mineResponsible{mineKeeper: toMineKeeper(&r.Keeper), IdAssign: r.IdAssign, IdEncl: r.IdEncl})
+	}
+	return e.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeeperDirectory(t *testing.T) {
+	a := newTestAPI(t)
+
+	rec := a.do(http.MethodPost, "/keeper", `{"name":"Ivan","role":"keeper","contact":"ivan@zoo","species":["cat"]}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "/keeper/1", rec.Header().Get("Location"))
+	var ivan mineKeeper
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &ivan))
+	assert.Equal(t, mineKeeper{1, "Ivan", "keeper", "ivan@zoo", []string{"cat"}}, ivan)
+	assert.Equal(t, http.StatusUnprocessableEntity, a.do(http.MethodPost, "/keeper", `{"name":"Olga","role":"boss"}`).Code)
+
+	require.Equal(t, http.StatusCreated, a.do(http.MethodPost, "/enclosure", `{"name":"Cat house","zone":"north","capacity":2,"habitat":"forest","species":["cat"]}`).Code)
+	rec = a.do(http.MethodPost, "/animal", `{"name_animal":"Klepa","birth_date":"2023-05-01","gender":"f","title":"cat"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	require.Equal(t, http.StatusOK, a.do(http.MethodPut, "/animal/1/enclosure", `{"enclosure_id":1}`).Code)
+
+	rec = a.do(http.MethodPost, "/keeper/1/assignment", `{"enclosure_id":1}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var assign mineKeeperAssignment
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &assign))
+	assert.Equal(t, int64(1), assign.IdKeeper)
+	assert.Nil(t, assign.IdAnim)
+	assert.Equal(t, http.StatusConflict, a.do(http.MethodPost, "/keeper/1/assignment", `{"enclosure_id":1}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, a.do(http.MethodPost, "/keeper/1/assignment", `{"animal_id":1,"enclosure_id":1}`).Code)
+	assert.Equal(t, http.StatusNotFound, a.do(http.MethodPost, "/keeper/9/assignment", `{"animal_id":1}`).Code)
+
+	rec = a.do(http.MethodGet, "/animal/1/keeper", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[{"id":1,"name":"Ivan","role":"keeper","contact":"ivan@zoo","species":["cat"],
+		"assignment_id":1,"enclosure_id":1}]`, rec.Body.String())
+
+	rec = a.do(http.MethodGet, "/keeper/1/animal", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var covered []mineAnimal
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &covered))
+	require.Len(t, covered, 1)
+	assert.Equal(t, "Klepa", covered[0].NameAn)
+
+	// без кошек в квалификации вольер с кошками не удержать
+	rec = a.do(http.MethodPut, "/keeper/1", `{"name":"Ivan","role":"keeper","species":[]}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, a.do(http.MethodDelete, "/assignment/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, a.do(http.MethodDelete, "/assignment/1", "").Code)
+	assert.JSONEq(t, `[]`, a.do(http.MethodGet, "/animal/1/keeper", "").Body.String())
+	assert.Equal(t, http.StatusNoContent, a.do(http.MethodDelete, "/keeper/1", "").Code)
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+)
+
+type (
+	// mineParent - животное зоопарка {"id_anim": 3} или внешнее {"external": "EEP 1234, Berlin"};
+	// null или {} - родитель неизвестен
+	mineParent struct {
+		IdAnim   *int64 `json:"id_anim,omitempty"`
+		External string `json:"external,omitempty"`
+	}
+
+	// mineParents - тело и ответ PUT /animal/:id/parents:
+	//
+	//	{"mother": {"id_anim": 3}, "father": {"external": "EEP 1234, Berlin"}}
+	mineParents struct {
+		IdAnim *int64      `json:"id_anim,omitempty"`
+		NameAn string      `json:"name_animal,omitempty"`
+		Mother *mineParent `json:"mother"`
+		Father *mineParent `json:"father"`
+	}
+
+	mineRelative struct {
+		IdAnim   *int64 `json:"id_anim,omitempty"`
+		External string `json:"external,omitempty"`
+		NameAn   string `json:"name_animal"`
+	}
+
+	minePedigreeNode struct {
+		mineRelative
+		Mother *minePedigreeNode `json:"mother"`
+		Father *minePedigreeNode `json:"father"`
+	}
+
+	mineDescendant struct {
+		IdAnim     int64  `json:"id_anim"`
+		NameAn     string `json:"name_animal"`
+		Generation int    `json:"generation"`
+	}
+
+	minePedigree struct {
+		minePedigreeNode
+		Descendants []mineDescendant `json:"descendants"`
+	}
+
+	// mineBreedingRequest - тело POST /breeding/evaluate:
+	//
+	//	{"sire_id": 5, "dam_id": 1}
+	mineBreedingRequest struct {
+		SireID int64 `json:"sire_id"`
+		DamID  int64 `json:"dam_id"`
+	}
+
+	mineBreeding struct {
+		Sire            mineRelative   `json:"sire"`
+		Dam             mineRelative   `json:"dam"`
+		Inbreeding      float64        `json:"inbreeding"`
+		MaxInbreeding   float64        `json:"max_inbreeding"`
+		Acceptable      bool           `json:"acceptable"`
+		CommonAncestors []mineRelative `json:"common_ancestors"`
+	}
+)
+
+func (p *mineParent) toParent() models.Parent {
+	if p == nil {
+		return models.Parent{}
+	}
+	return models.Parent{IdAnim: p.IdAnim, External: p.External}
+}
+
+func toMineParent(p models.Parent) *mineParent {
+	if p.IdAnim == nil && p.External == "" {
+		return nil
+	}
+	return &mineParent{p.IdAnim, p.External}
+}
+
+func toMineRelative(r *models.Relative) mineRelative {
+	return mineRelative{r.IdAnim, r.External, r.NameAn}
+}
+
+func toMinePedigreeNode(n *models.PedigreeNode) *minePedigreeNode {
+	if n == nil {
+		return nil
+	}
+	return &minePedigreeNode{
+		mineRelative: toMineRelative(&n.Relative),
+		Mother:       toMinePedigreeNode(n.Mother),
+		Father:       toMinePedigreeNode(n.Father),
+	}
+}
+
+func (a *API) setParents(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineParents
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect parents: %s", bindMessage(err))
+	}
+	kin, err := a.lin.SetParents(cc.Ctx, id, req.Mother.toParent(), req.Father.toParent())
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, mineParents{&kin.IdAnim, kin.NameAn, toMineParent(kin.Mother), toMineParent(kin.Father)})
+}
+
+// getPedigree - предки и потомки на ?generations= поколений (по умолчанию 3)
+func (a *API) getPedigree(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	generations := service.DefaultGenerations
+	if v := e.QueryParam("generations"); v != "" {
+		if generations, err = strconv.Atoi(v); err != nil {
+			return errs.BadRequest("incorrect generations")
+		}
+	}
+	p, err := a.lin.Pedigree(cc.Ctx, id, generations)
+	if err != nil {
+		return err
+	}
+	res := minePedigree{
+		minePedigreeNode: *toMinePedigreeNode(&p.PedigreeNode),
+		Descendants:      make([]mineDescendant, 0, len(p.Descendants)),
+	}
+	for _, d := range p.Descendants {
+		res.Descendants = append(res.Descendants, mineDescendant{d.IdAnim, d.NameAn, d.Generation})
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) evaluateBreeding(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	var req mineBreedingRequest
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect pairing: %s", bindMessage(err))
+	}
+	ev, err := a.lin.Evaluate(cc.Ctx, req.SireID, req.DamID)
+	if err != nil {
+		return err
+	}
+	res := mineBreeding{
+		Sire:            toMineRelative(&ev.Sire),
+		Dam:             toMineRelative(&ev.Dam),
+		Inbreeding:      ev.Inbreeding,
+		MaxInbreeding:   ev.MaxInbreeding,
+		Acceptable:      ev.Acceptable,
+		CommonAncestors: make([]mineRelative, 0, len(ev.CommonAncestors)),
+	}
+	for i := range ev.CommonAncestors {
+		res.CommonAncestors = append(res.CommonAncestors, toMineRelative(&ev.CommonAncestors[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
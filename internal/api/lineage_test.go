@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPedigreeAndBreeding(t *testing.T) {
+	a := newTestAPI(t)
+	for _, body := range []string{
+		`{"name_animal":"Tom","birth_date":"2010-01-01","gender":"m","title":"cat"}`,
+		`{"name_animal":"Klepa","birth_date":"2010-01-01","gender":"f","title":"cat"}`,
+		`{"name_animal":"Vaska","birth_date":"2015-01-01","gender":"m","title":"cat"}`,
+		`{"name_animal":"Muska","birth_date":"2015-01-01","gender":"f","title":"cat"}`,
+	} {
+		rec := a.do(http.MethodPost, "/animal", body)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	for _, id := range []string{"3", "4"} {
+		rec := a.do(http.MethodPut, "/animal/"+id+"/parents", `{"mother":{"id_anim":2},"father":{"id_anim":1}}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	rec := a.do(http.MethodPut, "/animal/1/parents", `{"mother":{"external":"EEP 7, Berlin"},"father":null}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var parents mineParents
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &parents))
+	assert.Equal(t, &mineParent{External: "EEP 7, Berlin"}, parents.Mother)
+	assert.Nil(t, parents.Father)
+
+	rec = a.do(http.MethodGet, "/animal/3/pedigree?generations=2", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var p minePedigree
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	require.NotNil(t, p.Father)
+	assert.Equal(t, "Tom", p.Father.NameAn)
+	require.NotNil(t, p.Father.Mother)
+	assert.Equal(t, "EEP 7, Berlin", p.Father.Mother.External)
+	assert.Empty(t, p.Descendants)
+
+	rec = a.do(http.MethodPost, "/breeding/evaluate", `{"sire_id":3,"dam_id":4}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var ev mineBreeding
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &ev))
+	assert.InDelta(t, 0.25, ev.Inbreeding, 1e-9)
+	assert.False(t, ev.Acceptable)
+	assert.Len(t, ev.CommonAncestors, 3)
+
+	assert.Equal(t, http.StatusConflict, a.do(http.MethodPost, "/breeding/evaluate", `{"sire_id":4,"dam_id":3}`).Code)
+	assert.Equal(t, http.StatusBadRequest, a.do(http.MethodGet, "/animal/3/pedigree?generations=x", "").Code)
+	assert.Equal(t, http.StatusConflict, a.do(http.MethodDelete, "/animal/1", "").Code)
+}
+
+func TestBirthDateKeepsPedigreeAcyclic(t *testing.T) {
+	a := newTestAPI(t)
+	for _, body := range []string{
+		`{"name_animal":"A","birth_date":"2010-01-01","gender":"f","title":"cat"}`,
+		`{"name_animal":"B","birth_date":"2015-01-01","gender":"f","title":"cat"}`,
+		`{"name_animal":"C","birth_date":"2010-01-01","gender":"m","title":"cat"}`,
+	} {
+		require.Equal(t, http.StatusCreated, a.do(http.MethodPost, "/animal", body).Code)
+	}
+	require.Equal(t, http.StatusOK, a.do(http.MethodPut, "/animal/2/parents", `{"mother":{"id_anim":1}}`).Code)
+
+	rec := a.do(http.MethodPatch, "/animal/2", `{"birth_date":"2005-01-01"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	rec = a.do(http.MethodPatch, "/animal/1", `{"birth_date":"2016-01-01"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, "mother can not become younger than her daughter")
+	rec = a.do(http.MethodPut, "/animal/2", `{"name_animal":"B","birth_date":"2009-01-01","gender":"f","title":"cat"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "parent A is not born before B")
+	assert.Equal(t, http.StatusOK, a.do(http.MethodPatch, "/animal/2", `{"birth_date":"2014-01-01"}`).Code)
+
+	assert.Equal(t, http.StatusConflict, a.do(http.MethodPut, "/animal/1/parents", `{"mother":{"id_anim":2}}`).Code)
+	assert.Equal(t, http.StatusOK, a.do(http.MethodPost, "/breeding/evaluate", `{"sire_id":3,"dam_id":1}`).Code)
+}
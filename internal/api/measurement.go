@@ -0,0 +1,177 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+)
+
+type (
+	// mineMeasurement - замер в ответах и тело POST /animal/:id/measurement:
+	//
+	//	{"weight_kg": 4.2, "length_cm": 46, "body_condition": 5}
+	//
+	// задается хотя бы одно значение; без measured_at - сейчас
+	mineMeasurement struct {
+		IdMeas        int64     `json:"id"`
+		IdAnim        int64     `json:"animal_id"`
+		MeasuredAt    time.Time `json:"measured_at"`
+		WeightKg      *float64  `json:"weight_kg,omitempty"`
+		LengthCm      *float64  `json:"length_cm,omitempty"`
+		BodyCondition *int      `json:"body_condition,omitempty"`
+		Notes         string    `json:"notes"`
+	}
+
+	mineRecorded struct {
+		mineMeasurement
+		Alerts []mineAlert `json:"alerts"`
+	}
+
+	minePoint struct {
+		At            time.Time `json:"at"`
+		Count         int       `json:"count"`
+		WeightKg      *float64  `json:"weight_kg,omitempty"`
+		LengthCm      *float64  `json:"length_cm,omitempty"`
+		BodyCondition *float64  `json:"body_condition,omitempty"`
+	}
+
+	// mineAlert - change в долях: -0.12 - потеря 12% веса
+	mineAlert struct {
+		IdAnim int64           `json:"animal_id"`
+		NameAn string          `json:"name_animal"`
+		Kind   string          `json:"kind"`
+		From   mineMeasurement `json:"from"`
+		To     mineMeasurement `json:"to"`
+		Change float64         `json:"change"`
+	}
+)
+
+func toMineMeasurement(m *models.Measurement) mineMeasurement {
+	return mineMeasurement{m.IdMeas, m.IdAnim, m.MeasuredAt, m.WeightKg, m.LengthCm, m.BodyCondition, m.Notes}
+}
+
+func toMineAlerts(alerts []models.MeasurementAlert) []mineAlert {
+	res := make([]mineAlert, 0, len(alerts))
+	for i := range alerts {
+		a := &alerts[i]
+		res = append(res, mineAlert{a.IdAnim, a.NameAn, a.Kind, toMineMeasurement(&a.From), toMineMeasurement(&a.To), a.Change})
+	}
+	return res
+}
+
+// parseMeasurementFilter - ?from=&to= в RFC 3339
+func parseMeasurementFilter(e echo.Context) (models.MeasurementFilter, error) {
+	id, err := parseID(e)
+	if err != nil {
+		return models.MeasurementFilter{}, err
+	}
+	f := models.MeasurementFilter{IdAnim: id}
+	if f.From, err = parseTime(e, "from"); err != nil {
+		return f, err
+	}
+	f.To, err = parseTime(e, "to")
+	return f, err
+}
+
+func (a *API) addMeasurement(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineMeasurement
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect measurement: %s", bindMessage(err))
+	}
+	m, alerts, err := a.meas.Record(cc.Ctx, &models.Measurement{
+		IdAnim:        id,
+		MeasuredAt:    req.MeasuredAt,
+		WeightKg:      req.WeightKg,
+		LengthCm:      req.LengthCm,
+		BodyCondition: req.BodyCondition,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, mineRecorded{toMineMeasurement(m), toMineAlerts(alerts)})
+}
+
+func (a *API) getMeasurements(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	f, err := parseMeasurementFilter(e)
+	if err != nil {
+		return err
+	}
+	ms, err := a.meas.List(cc.Ctx, f)
+	if err != nil {
+		return err
+	}
+	res := make([]mineMeasurement, 0, len(ms))
+	for i := range ms {
+		res = append(res, toMineMeasurement(&ms[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// getMeasurementSeries - средние по ?bucket=day (по умолчанию) или week
+func (a *API) getMeasurementSeries(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	f, err := parseMeasurementFilter(e)
+	if err != nil {
+		return err
+	}
+	bucket := e.QueryParam("bucket")
+	if bucket == "" {
+		bucket = service.BucketDay
+	}
+	points, err := a.meas.Series(cc.Ctx, f, bucket)
+	if err != nil {
+		return err
+	}
+	res := make([]minePoint, 0, len(points))
+	for _, p := range points {
+		res = append(res, minePoint{p.At, p.Count, p.WeightKg, p.LengthCm, p.BodyCondition})
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) deleteMeasurement(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	if err := a.meas.Delete(cc.Ctx, id); err != nil {
+		return err
+	}
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (a *API) getMeasurementAlerts(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	alerts, err := a.meas.Alerts(cc.Ctx)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineAlerts(alerts))
+}
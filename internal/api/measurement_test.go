@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasurements(t *testing.T) {
+	a := newTestAPI(t)
+
+	rec := a.do(http.MethodPost, "/animal", `{"name_animal":"Klepa","birth_date":"2023-05-01","gender":"f","title":"cat"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	rec = a.do(http.MethodPost, "/animal/1/measurement", `{"measured_at":"2024-05-01T09:00:00Z","weight_kg":5,"body_condition":5}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	rec = a.do(http.MethodPost, "/animal/1/measurement", `{"measured_at":"2024-05-01T18:00:00Z","weight_kg":4.4}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var recorded mineRecorded
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &recorded))
+	require.Len(t, recorded.Alerts, 1)
+	assert.Equal(t, "weight_loss", recorded.Alerts[0].Kind)
+
+	rec = a.do(http.MethodPost, "/animal/1/measurement", `{"notes":"nothing measured"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+
+	rec = a.do(http.MethodGet, "/animal/1/measurement/series?bucket=day", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var points []minePoint
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &points))
+	require.Len(t, points, 1)
+	assert.Equal(t, 2, points[0].Count)
+	assert.InDelta(t, 4.7, *points[0].WeightKg, 1e-9)
+	assert.Equal(t, http.StatusBadRequest, a.do(http.MethodGet, "/animal/1/measurement/series?bucket=year", "").Code)
+
+	rec = a.do(http.MethodGet, "/animal/1?include=measurements,health", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var full mineAnimalfull
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &full))
+	require.NotNil(t, full.LatestMeasurement)
+	assert.Equal(t, int64(2), full.LatestMeasurement.IdMeas)
+	assert.NotNil(t, full.Health)
+
+	assert.Equal(t, http.StatusNoContent, a.do(http.MethodDelete, "/measurement/2", "").Code)
+	rec = a.do(http.MethodGet, "/animal/1/measurement", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var ms []mineMeasurement
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &ms))
+	assert.Len(t, ms, 1)
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+)
+
+type (
+	// mineRecord - тело POST /animal/:id/medical/record:
+	//
+	//	{"kind": "diagnosis", "title": "otitis", "details": "left ear", "vet": "Dr. Aibolit"}
+	//
+	// kind - examination, diagnosis или treatment; без recorded_at - сейчас
+	mineRecord struct {
+		IdRec      int64     `json:"id"`
+		IdAnim     int64     `json:"animal_id"`
+		Kind       string    `json:"kind"`
+		Title      string    `json:"title"`
+		Details    string    `json:"details"`
+		Vet        string    `json:"vet"`
+		RecordedAt time.Time `json:"recorded_at"`
+	}
+
+	// mineMedication - тело POST /animal/:id/medical/medication:
+	//
+	//	{"record_id": 3, "drug": "amoxicillin", "dose": 50, "dose_unit": "mg", "frequency": "twice a day", "days": 7, "vet": "Dr. Aibolit"}
+	mineMedication struct {
+		IdMed     int64     `json:"id"`
+		IdAnim    int64     `json:"animal_id"`
+		IdRec     *int64    `json:"record_id,omitempty"`
+		Drug      string    `json:"drug"`
+		Dose      float64   `json:"dose"`
+		DoseUnit  string    `json:"dose_unit"`
+		Frequency string    `json:"frequency"`
+		StartedAt time.Time `json:"started_at"`
+		Days      int       `json:"days"`
+		Vet       string    `json:"vet"`
+	}
+
+	// mineVaccination - тело POST /animal/:id/medical/vaccination:
+	//
+	//	{"vaccine": "rabies", "due_at": "2025-05-01T00:00:00Z", "vet": "Dr. Aibolit"}
+	mineVaccination struct {
+		IdVac   int64      `json:"id"`
+		IdAnim  int64      `json:"animal_id"`
+		Vaccine string     `json:"vaccine"`
+		GivenAt time.Time  `json:"given_at"`
+		DueAt   *time.Time `json:"due_at,omitempty"`
+		Vet     string     `json:"vet"`
+	}
+
+	mineDueVaccination struct {
+		mineVaccination
+		NameAn string `json:"name_animal"`
+		Title  string `json:"title"`
+	}
+
+	mineHistory struct {
+		Records      []mineRecord      `json:"records"`
+		Medications  []mineMedication  `json:"medications"`
+		Vaccinations []mineVaccination `json:"vaccinations"`
+	}
+
+	// mineHealth - сводка в GET /animal/:id?include=health
+	mineHealth struct {
+		LastExamination   *mineRecord       `json:"last_examination"`
+		LastDiagnosis     *mineRecord       `json:"last_diagnosis"`
+		ActiveMedications []mineMedication  `json:"active_medications"`
+		DueVaccinations   []mineVaccination `json:"due_vaccinations"`
+	}
+)
+
+func toMineRecord(r *models.MedicalRecord) mineRecord {
+	return mineRecord{r.IdRec, r.IdAnim, r.Kind, r.Title, r.Details, r.Vet, r.RecordedAt}
+}
+
+func toMineMedication(m *models.Medication) mineMedication {
+	return mineMedication{m.IdMed, m.IdAnim, m.IdRec, m.Drug, m.Dose, m.DoseUnit, m.Frequency, m.StartedAt, m.Days, m.Vet}
+}
+
+func toMineVaccination(v *models.Vaccination) mineVaccination {
+	return mineVaccination{v.IdVac, v.IdAnim, v.Vaccine, v.GivenAt, v.DueAt, v.Vet}
+}
+
+func toMineHealth(h *models.HealthSummary) *mineHealth {
+	res := &mineHealth{
+		ActiveMedications: make([]mineMedication, 0, len(h.ActiveMedications)),
+		DueVaccinations:   make([]mineVaccination, 0, len(h.DueVaccinations)),
+	}
+	if h.LastExamination != nil {
+		rec := toMineRecord(h.LastExamination)
+		res.LastExamination = &rec
+	}
+	if h.LastDiagnosis != nil {
+		rec := toMineRecord(h.LastDiagnosis)
+		res.LastDiagnosis = &rec
+	}
+	for i := range h.ActiveMedications {
+		res.ActiveMedications = append(res.ActiveMedications, toMineMedication(&h.ActiveMedications[i]))
+	}
+	for i := range h.DueVaccinations {
+		res.DueVaccinations = append(res.DueVaccinations, toMineVaccination(&h.DueVaccinations[i]))
+	}
+	return res
+}
+
+// getMedicalHistory - история животного, ?from=&to= в RFC 3339
+func (a *API) getMedicalHistory(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	f := models.MedicalFilter{IdAnim: id}
+	if f.From, err = parseTime(e, "from"); err != nil {
+		return err
+	}
+	if f.To, err = parseTime(e, "to"); err != nil {
+		return err
+	}
+	h, err := a.med.History(cc.Ctx, f)
+	if err != nil {
+		return err
+	}
+	res := mineHistory{
+		Records:      make([]mineRecord, 0, len(h.Records)),
+		Medications:  make([]mineMedication, 0, len(h.Medications)),
+		Vaccinations: make([]mineVaccination, 0, len(h.Vaccinations)),
+	}
+	for i := range h.Records {
+		res.Records = append(res.Records, toMineRecord(&h.Records[i]))
+	}
+	for i := range h.Medications {
+		res.Medications = append(res.Medications, toMineMedication(&h.Medications[i]))
+	}
+	for i := range h.Vaccinations {
+		res.Vaccinations = append(res.Vaccinations, toMineVaccination(&h.Vaccinations[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) addMedicalRecord(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineRecord
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect medical record: %s", bindMessage(err))
+	}
+	rec, err := a.med.AddRecord(cc.Ctx, &models.MedicalRecord{
+		IdAnim:     id,
+		Kind:       req.Kind,
+		Title:      req.Title,
+		Details:    req.Details,
+		Vet:        req.Vet,
+		RecordedAt: req.RecordedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineRecord(rec))
+}
+
+func (a *API) addMedication(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineMedication
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect medication: %s", bindMessage(err))
+	}
+	med, err := a.med.AddMedication(cc.Ctx, &models.Medication{
+		IdAnim:    id,
+		IdRec:     req.IdRec,
+		Drug:      req.Drug,
+		Dose:      req.Dose,
+		DoseUnit:  req.DoseUnit,
+		Frequency: req.Frequency,
+		StartedAt: req.StartedAt,
+		Days:      req.Days,
+		Vet:       req.Vet,
+	})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineMedication(med))
+}
+
+func (a *API) addVaccination(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineVaccination
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect vaccination: %s", bindMessage(err))
+	}
+	vac, err := a.med.AddVaccination(cc.Ctx, &models.Vaccination{
+		IdAnim:  id,
+		Vaccine: req.Vaccine,
+		GivenAt: req.GivenAt,
+		DueAt:   req.DueAt,
+		Vet:     req.Vet,
+	})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineVaccination(vac))
+}
+
+// getDueVaccinations - прививки, повтор которых нужен в ближайшие ?days= дней (по умолчанию 30) или просрочен
+func (a *API) getDueVaccinations(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	within := service.DueWindow
+	if v := e.QueryParam("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return errs.BadRequest("incorrect days")
+		}
+		within = time.Duration(days) * 24 * time.Hour
+	}
+	due, err := a.med.DueVaccinations(cc.Ctx, within)
+	if err != nil {
+		return err
+	}
+	res := make([]mineDueVaccination, 0, len(due))
+	for i := range due {
+		res = append(res, mineDueVaccination{toMineVaccination(&due[i].Vaccination), due[i].NameAn, due[i].Title})
+	}
+	return e.JSON(http.StatusOK, res)
+}
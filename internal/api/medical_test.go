@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMedicalHistory(t *testing.T) {
+	a := newTestAPI(t)
+
+	rec := a.do(http.MethodPost, "/animal", `{"name_animal":"Klepa","birth_date":"2023-05-01","gender":"f","title":"cat"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	rec = a.do(http.MethodPost, "/animal/1/medical/record", `{"kind":"examination","title":"routine","vet":"Dr. Aibolit"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	rec = a.do(http.MethodPost, "/animal/1/medical/record", `{"kind":"surgery","title":"routine","vet":"Dr. Aibolit"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	rec = a.do(http.MethodPost, "/animal/1/medical/medication", `{"drug":"meloxicam","dose":0.5,"dose_unit":"mg","days":7,"vet":"Dr. Aibolit"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	rec = a.do(http.MethodPost, "/animal/1/medical/vaccination", `{"vaccine":"rabies","given_at":"2024-01-10T00:00:00Z","due_at":"2025-01-10T00:00:00Z","vet":"Dr. Aibolit"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, http.StatusNotFound, a.do(http.MethodPost, "/animal/2/medical/vaccination", `{"vaccine":"rabies","vet":"Dr. Aibolit"}`).Code)
+
+	rec = a.do(http.MethodGet, "/animal/1/medical", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var h mineHistory
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &h))
+	assert.Len(t, h.Records, 1)
+	assert.Len(t, h.Medications, 1)
+	assert.Len(t, h.Vaccinations, 1)
+
+	rec = a.do(http.MethodGet, "/vaccination/due", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var due []mineDueVaccination
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &due))
+	require.Len(t, due, 1)
+	assert.Equal(t, "Klepa", due[0].NameAn)
+
+	// без include сводки нет
+	rec = a.do(http.MethodGet, "/animal/1", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), `"health"`)
+
+	rec = a.do(http.MethodGet, "/animal/1?include=health", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var full mineAnimalfull
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &full))
+	require.NotNil(t, full.Health)
+	require.NotNil(t, full.Health.LastExamination)
+	assert.Equal(t, "routine", full.Health.LastExamination.Title)
+	assert.Nil(t, full.Health.LastDiagnosis)
+	assert.Len(t, full.Health.ActiveMedications, 1)
+	assert.Len(t, full.Health.DueVaccinations, 1)
+
+	assert.Equal(t, http.StatusBadRequest, a.do(http.MethodGet, "/animal/1?include=everything", "").Code)
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+type (
+	// mineMoodEntry - запись истории настроений и тело POST /animal/:id/mood:
+	//
+	//	{"mood": "playful", "keeper": "Ivan", "notes": "chasing a ball"}
+	//
+	// без observed_at - сейчас; source у отметок смотрителя всегда keeper
+	mineMoodEntry struct {
+		IdMood     int64     `json:"id"`
+		IdAnim     int64     `json:"animal_id"`
+		Mood       string    `json:"mood"`
+		Source     string    `json:"source"`
+		Keeper     string    `json:"keeper"`
+		Notes      string    `json:"notes"`
+		IdEncl     *int64    `json:"enclosure_id"`
+		ObservedAt time.Time `json:"observed_at"`
+	}
+
+	mineMoodShare struct {
+		Mood  string  `json:"mood"`
+		Count int     `json:"count"`
+		Share float64 `json:"share"`
+	}
+
+	mineMoodDistribution struct {
+		Group string          `json:"group"`
+		Total int             `json:"total"`
+		Moods []mineMoodShare `json:"moods"`
+	}
+)
+
+func toMineMoodEntry(m *models.MoodEntry) mineMoodEntry {
+	return mineMoodEntry{m.IdMood, m.IdAnim, string(m.Mood), string(m.Source), m.Keeper, m.Notes, m.IdEncl, m.ObservedAt}
+}
+
+func (a *API) addMood(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineMoodEntry
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect mood: %s", bindMessage(err))
+	}
+	m, err := a.moods.Record(cc.Ctx, &models.MoodEntry{
+		IdAnim:     id,
+		Mood:       models.Mood(req.Mood),
+		Keeper:     req.Keeper,
+		Notes:      req.Notes,
+		ObservedAt: req.ObservedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineMoodEntry(m))
+}
+
+// getMoodHistory - ?from=&to= в RFC 3339, ?source=keeper или computed
+func (a *API) getMoodHistory(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	f := models.MoodFilter{IdAnim: id, Source: models.MoodSource(e.QueryParam("source"))}
+	if f.From, err = parseTime(e, "from"); err != nil {
+		return err
+	}
+	if f.To, err = parseTime(e, "to"); err != nil {
+		return err
+	}
+	moods, err := a.moods.History(cc.Ctx, f)
+	if err != nil {
+		return err
+	}
+	res := make([]mineMoodEntry, 0, len(moods))
+	for i := range moods {
+		res = append(res, toMineMoodEntry(&moods[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+// getMoodDistribution - ?by=enclosure или species (по умолчанию), те же from, to и source
+func (a *API) getMoodDistribution(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	f := models.MoodDistributionFilter{
+		GroupBy: models.MoodGrouping(e.QueryParam("by")),
+		Source:  models.MoodSource(e.QueryParam("source")),
+	}
+	if f.GroupBy == "" {
+		f.GroupBy = models.MoodBySpecies
+	}
+	if f.From, err = parseTime(e, "from"); err != nil {
+		return err
+	}
+	if f.To, err = parseTime(e, "to"); err != nil {
+		return err
+	}
+	groups, err := a.moods.Distribution(cc.Ctx, f)
+	if err != nil {
+		return err
+	}
+	res := make([]mineMoodDistribution, 0, len(groups))
+	for _, g := range groups {
+		d := mineMoodDistribution{Group: g.Group, Total: g.Total, Moods: make([]mineMoodShare, 0, len(g.Moods))}
+		for _, m := range g.Moods {
+			d.Moods = append(d.Moods, mineMoodShare{string(m.Mood), m.Count, m.Share})
+		}
+		res = append(res, d)
+	}
+	return e.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoodHistoryAndDistribution(t *testing.T) {
+	a := newTestAPI(t)
+	rec := a.do(http.MethodPost, "/animal", `{"name_animal":"Klepa","birth_date":"2011-03-14","gender":"f","title":"cat"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	rec = a.do(http.MethodPost, "/animal/1/mood", `{"mood":"playful","keeper":"Ivan","notes":"chasing a ball","observed_at":"2026-01-10T10:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var entry mineMoodEntry
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entry))
+	assert.Equal(t, "keeper", entry.Source)
+
+	rec = a.do(http.MethodPost, "/animal/1/mood", `{"mood":"","keeper":"Ivan"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+
+	// карточка животного записывает вычисленное настроение
+	rec = a.do(http.MethodGet, "/animal/1", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = a.do(http.MethodGet, "/animal/1/mood?from=2026-01-01T00:00:00Z", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var history []mineMoodEntry
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &history))
+	require.Len(t, history, 2)
+	assert.Equal(t, "computed", history[0].Source)
+	assert.Equal(t, "playful", history[1].Mood)
+
+	rec = a.do(http.MethodGet, "/animal/1/mood?source=keeper&to=2026-01-10T10:00:00Z", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[]`, rec.Body.String())
+
+	rec = a.do(http.MethodGet, "/mood/distribution?source=keeper", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `[{"group":"cat","total":1,"moods":[{"mood":"playful","count":1,"share":1}]}]`, rec.Body.String())
+
+	rec = a.do(http.MethodGet, "/mood/distribution?by=zone", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+// mineObservation - наблюдение в ответах и тело POST /animal/:id/observation:
+//
+//	{"keeper": "Ivan", "activity": "low", "appetite": "poor", "social": "withdrawn",
+//	 "stereotypic": true, "severity": "medium", "notes": "pacing along the fence", "tags": ["pacing"]}
+//
+// без observed_at - сейчас, без severity - info
+type mineObservation struct {
+	IdObs       int64     `json:"id"`
+	IdAnim      int64     `json:"animal_id"`
+	Keeper      string    `json:"keeper"`
+	ObservedAt  time.Time `json:"observed_at"`
+	Activity    string    `json:"activity"`
+	Appetite    string    `json:"appetite"`
+	Social      string    `json:"social"`
+	Stereotypic bool      `json:"stereotypic"`
+	Severity    string    `json:"severity"`
+	Notes       string    `json:"notes"`
+	Tags        []string  `json:"tags"`
+}
+
+func toMineObservation(o *models.Observation) mineObservation {
+	tags := o.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return mineObservation{o.IdObs, o.IdAnim, o.Keeper, o.ObservedAt, o.Activity, o.Appetite, o.Social, o.Stereotypic, o.Severity, o.Notes, tags}
+}
+
+// parseObservationFilter - ?keeper=&tag=&from=&to=, время в RFC 3339
+func parseObservationFilter(e echo.Context) (models.ObservationFilter, error) {
+	f := models.ObservationFilter{Keeper: e.QueryParam("keeper"), Tag: e.QueryParam("tag")}
+	var err error
+	if f.From, err = parseTime(e, "from"); err != nil {
+		return f, err
+	}
+	f.To, err = parseTime(e, "to")
+	return f, err
+}
+
+func (a *API) addObservation(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineObservation
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect observation: %s", bindMessage(err))
+	}
+	o, err := a.obs.Record(cc.Ctx, &models.Observation{
+		IdAnim:      id,
+		Keeper:      req.Keeper,
+		ObservedAt:  req.ObservedAt,
+		Activity:    req.Activity,
+		Appetite:    req.Appetite,
+		Social:      req.Social,
+		Stereotypic: req.Stereotypic,
+		Severity:    req.Severity,
+		Notes:       req.Notes,
+		Tags:        req.Tags,
+	})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineObservation(o))
+}
+
+func (a *API) getAnimalObservations(e echo.Context) error {
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	f, err := parseObservationFilter(e)
+	if err != nil {
+		return err
+	}
+	f.IdAnim = &id
+	return a.observations(e, f)
+}
+
+// searchObservations - по всем животным или ?animal_id=
+func (a *API) searchObservations(e echo.Context) error {
+	f, err := parseObservationFilter(e)
+	if err != nil {
+		return err
+	}
+	if v := e.QueryParam("animal_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return errs.BadRequest("incorrect animal_id")
+		}
+		f.IdAnim = &id
+	}
+	return a.observations(e, f)
+}
+
+func (a *API) observations(e echo.Context, f models.ObservationFilter) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	obs, err := a.obs.Search(cc.Ctx, f)
+	if err != nil {
+		return err
+	}
+	res := make([]mineObservation, 0, len(obs))
+	for i := range obs {
+		res = append(res, toMineObservation(&obs[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservationSearch(t *testing.T) {
+	a := newTestAPI(t)
+	rec := a.do(http.MethodPost, "/animal", `{"name_animal":"Klepa","birth_date":"2011-03-14","gender":"f","title":"cat"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	rec = a.do(http.MethodPost, "/animal/1/observation", `{"keeper":"Ivan","social":"aggressive","severity":"high","tags":["Hissing"]}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created mineObservation
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, []string{"hissing"}, created.Tags)
+
+	rec = a.do(http.MethodPost, "/animal/1/observation", `{"keeper":"Olga","notes":"quiet evening","observed_at":"2026-01-10T18:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	rec = a.do(http.MethodPost, "/animal/1/observation", `{"keeper":"Olga","severity":"urgent","notes":"?"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+
+	// наблюдение сразу сказывается на настроении в карточке
+	rec = a.do(http.MethodGet, "/animal/1", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var full mineAnimalfull
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &full))
+	assert.Equal(t, "stressed", full.Mood)
+
+	for target, want := range map[string]int{
+		"/observation?tag=hissing":                         1,
+		"/observation?keeper=Olga":                         1,
+		"/observation?animal_id=1&to=2026-02-01T00:00:00Z": 1,
+		"/animal/1/observation":                            2,
+		"/observation?keeper=Petr":                         0,
+	} {
+		rec = a.do(http.MethodGet, target, "")
+		require.Equal(t, http.StatusOK, rec.Code, target)
+		var res []mineObservation
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Len(t, res, want, target)
+	}
+	rec = a.do(http.MethodGet, "/animal/2/observation", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+type mineSpecie struct {
+	IdSp    int64  `json:"id"`
+	Title   string `json:"title"`
+	Descrip string `json:"description"`
+}
+
+func toMineSpecie(sp *models.Specie) mineSpecie {
+	return mineSpecie{IdSp: sp.IdSp, Title: sp.Title, Descrip: sp.Descrip}
+}
+
+func (a *API) getAllSpecies(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	species, err := a.sp.ListSpecies(cc.Ctx)
+	if err != nil {
+		return err
+	}
+	res := make([]mineSpecie, 0, len(species))
+	for i := range species {
+		res = append(res, toMineSpecie(&species[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) getSpecie(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	sp, err := a.sp.GetSpecie(cc.Ctx, id)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineSpecie(sp))
+}
+
+func (a *API) addSpecie(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	var req mineSpecie
+	if err := e.Bind(&req); err != nil {
+		return errs.BadRequest("incorrect species")
+	}
+	sp := models.Specie{Title: req.Title, Descrip: req.Descrip}
+	if _, err := a.sp.AddSpecie(cc.Ctx, &sp); err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineSpecie(&sp))
+}
+
+func (a *API) updateSpecie(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineSpecie
+	if err := e.Bind(&req); err != nil {
+		return errs.BadRequest("incorrect species")
+	}
+	sp := models.Specie{IdSp: id, Title: req.Title, Descrip: req.Descrip}
+	if err := a.sp.UpdateSpecie(cc.Ctx, &sp); err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineSpecie(&sp))
+}
+
+func (a *API) deleteSpecie(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	if err := a.sp.DeleteSpecie(cc.Ctx, id); err != nil {
+		return err
+	}
+	return e.NoContent(http.StatusNoContent)
+}
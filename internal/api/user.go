@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+)
+
+type (
+	mineUser struct {
+		IdUser    int64     `json:"id"`
+		Name      string    `json:"name"`
+		Role      string    `json:"role"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// mineMe - кто выполняет запрос и что ему разрешено
+	mineMe struct {
+		Subject     string   `json:"subject"`
+		Method      string   `json:"method"`
+		Role        string   `json:"role"`
+		Permissions []string `json:"permissions"`
+	}
+)
+
+func toMineUser(u *models.User) mineUser {
+	return mineUser{IdUser: u.IdUser, Name: u.Name, Role: string(u.Role), CreatedAt: u.CreatedAt}
+}
+
+func (a *API) getMe(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id := auth.FromContext(cc.Ctx)
+	if id == nil {
+		return errs.ErrUnauthenticated
+	}
+	res := mineMe{Subject: id.Subject, Method: id.Method, Role: string(id.Role), Permissions: make([]string, 0)}
+	for _, p := range service.Permissions(id.Role) {
+		res.Permissions = append(res.Permissions, string(p))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) getAllUsers(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	users, err := a.users.ListUsers(cc.Ctx)
+	if err != nil {
+		return err
+	}
+	res := make([]mineUser, 0, len(users))
+	for i := range users {
+		res = append(res, toMineUser(&users[i]))
+	}
+	return e.JSON(http.StatusOK, res)
+}
+
+func (a *API) getUser(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	u, err := a.users.GetUser(cc.Ctx, id)
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineUser(u))
+}
+
+func (a *API) addUser(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	var req mineUser
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect user: %s", bindMessage(err))
+	}
+	u, err := a.users.AddUser(cc.Ctx, &models.User{Name: req.Name, Role: models.Role(req.Role)})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusCreated, toMineUser(u))
+}
+
+func (a *API) updateUser(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	var req mineUser
+	if err := (&echo.DefaultBinder{}).BindBody(e, &req); err != nil {
+		return errs.BadRequest("incorrect user: %s", bindMessage(err))
+	}
+	u, err := a.users.UpdateUser(cc.Ctx, &models.User{IdUser: id, Name: req.Name, Role: models.Role(req.Role)})
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, toMineUser(u))
+}
+
+func (a *API) deleteUser(e echo.Context) error {
+	cc, err := getParentContext(e)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(e)
+	if err != nil {
+		return err
+	}
+	if err := a.users.DeleteUser(cc.Ctx, id); err != nil {
+		return err
+	}
+	return e.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleBasedAccess(t *testing.T) {
+	keys, err := auth.NewAPIKeys(map[string]string{
+		"root": "root-key-0123456789", "ivan": "ivan-key-0123456789",
+		"aibolit": "aibolit-key-0123456789", "stranger": "stranger-key-0123456789",
+	})
+	require.NoError(t, err)
+	a := newTestAPIWith(t, keys)
+	as := func(key, method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("X-API-Key", key)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		a.e.ServeHTTP(rec, req)
+		return rec
+	}
+	const root, keeper, vet, stranger = "root-key-0123456789", "ivan-key-0123456789", "aibolit-key-0123456789", "stranger-key-0123456789"
+
+	rec := as(root, http.MethodPost, "/user", `{"name":"ivan","role":"keeper"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var ivan mineUser
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &ivan))
+	assert.Equal(t, "keeper", ivan.Role)
+	require.Equal(t, http.StatusCreated, as(root, http.MethodPost, "/user", `{"name":"aibolit","role":"vet"}`).Code)
+	assert.Equal(t, http.StatusConflict, as(root, http.MethodPost, "/user", `{"name":"ivan","role":"vet"}`).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, as(root, http.MethodPost, "/user", `{"name":"olga","role":"director"}`).Code)
+	require.Equal(t, http.StatusCreated, as(root, http.MethodPost, "/animal", `{"name_animal":"Klepa","birth_date":"2011-03-14","gender":"f","title":"cat"}`).Code)
+
+	rec = as(keeper, http.MethodDelete, "/animal/1", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"error":{"code":"forbidden","message":"permission animals:write required"}}`, rec.Body.String())
+	assert.Equal(t, http.StatusOK, as(keeper, http.MethodGet, "/animal/1", "").Code)
+	assert.Equal(t, http.StatusCreated, as(keeper, http.MethodPost, "/animal/1/observation", `{"keeper":"Ivan","notes":"sleepy"}`).Code)
+	assert.Equal(t, http.StatusForbidden, as(keeper, http.MethodPost, "/animal/1/medical/record", `{"kind":"examination","title":"routine","vet":"Dr. Aibolit"}`).Code)
+	assert.Equal(t, http.StatusCreated, as(vet, http.MethodPost, "/animal/1/medical/record", `{"kind":"examination","title":"routine","vet":"Dr. Aibolit"}`).Code)
+	assert.Equal(t, http.StatusForbidden, as(keeper, http.MethodGet, "/user", "").Code)
+	assert.Equal(t, http.StatusForbidden, as(stranger, http.MethodGet, "/animal", "").Code, "no user record, no role")
+
+	rec = as(keeper, http.MethodGet, "/me", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"subject":"ivan","method":"api_key","role":"keeper",
+		"permissions":["zoo:read","feedings:write","measurements:write","behaviour:write"]}`, rec.Body.String())
+
+	// роль меняется сразу, без перевыпуска ключа
+	rec = as(root, http.MethodPut, "/user/1", `{"name":"ivan","role":"curator"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = as(keeper, http.MethodDelete, "/animal/1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"result":"you kill that animal!!!!"}`, rec.Body.String())
+	require.Equal(t, http.StatusNoContent, as(root, http.MethodDelete, "/user/1", "").Code)
+	assert.Equal(t, http.StatusForbidden, as(keeper, http.MethodGet, "/animal", "").Code)
+}
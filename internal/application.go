@@ -186,7 +186,16 @@ func (a *Application) run(sig <-chan os.Signal) error {
 		}
 		// это жесткий путь - кто-то вызвал процедуру Shutdown()
 	case <-a.done:
-		// shutdown
+	}
+	// например, ресурс не ответил на Ping. Shutdown уже закрыл holdOn,
+	// даем основному потоку корректно завершиться
+	select {
+	case err, ok := <-errRun:
+		if ok && err != nil {
+			return err
+		}
+	case <-time.After(a.TerminationTimeout):
+		return ErrTermTimeout
 	}
 	return nil
 }
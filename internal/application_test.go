@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeService struct {
+	pingErr error
+	closed  atomic.Bool
+}
+
+func (f *fakeService) Init(ctx context.Context) error { return nil }
+func (f *fakeService) Ping(ctx context.Context) error { return f.pingErr }
+func (f *fakeService) Close() error {
+	f.closed.Store(true)
+	return nil
+}
+
+func TestApplicationStopsOnFailedPing(t *testing.T) {
+	errPing := errors.New("db is gone")
+	srv := &fakeService{pingErr: errPing}
+	var stopped atomic.Bool
+	app := Application{
+		MainFunc: func(ctx context.Context, holdOn <-chan struct{}) error {
+			<-holdOn
+			// основной поток успевает корректно остановиться
+			time.Sleep(10 * time.Millisecond)
+			stopped.Store(true)
+			return nil
+		},
+		Resources: &ServiceKeeper{
+			Services:   []service.Service{srv},
+			PingPeriod: 10 * time.Millisecond,
+		},
+		TerminationTimeout: time.Second,
+	}
+
+	err := app.Run()
+	require.ErrorIs(t, err, errPing)
+	require.True(t, stopped.Load(), "main func must finish before resources are released")
+	require.True(t, srv.closed.Load())
+}
+
+func TestApplicationMainError(t *testing.T) {
+	errMain := errors.New("listen failed")
+	srv := &fakeService{}
+	app := Application{
+		MainFunc: func(ctx context.Context, holdOn <-chan struct{}) error {
+			return errMain
+		},
+		Resources: &ServiceKeeper{
+			Services:   []service.Service{srv},
+			PingPeriod: time.Hour,
+		},
+	}
+
+	require.ErrorIs(t, app.Run(), errMain)
+	require.True(t, srv.closed.Load())
+}
+
+func TestServiceKeeperStatus(t *testing.T) {
+	ok := &fakeService{}
+	broken := &fakeService{pingErr: errors.New("timeout")}
+	k := &ServiceKeeper{Services: []service.Service{ok, broken}}
+	require.NoError(t, k.Init(context.Background()))
+	// после Init ресурсы проверены, но Watch еще не запущен
+	require.False(t, k.Ready())
+
+	require.Error(t, k.testServises(context.Background()))
+	st := k.Status()
+	require.Len(t, st, 2)
+	require.Equal(t, StatusOK, st[0].Status)
+	require.Equal(t, StatusFailing, st[1].Status)
+	require.Equal(t, "timeout", st[1].Error)
+	require.False(t, st[1].CheckedAt.IsZero())
+}
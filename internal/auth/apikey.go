@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/mi-raf/zooad/internal/errs"
+)
+
+// MinAPIKeyLength - короче ключ легко подобрать
+const MinAPIKeyLength = 16
+
+var errInvalidAPIKey = errs.Unauthenticated("invalid api key")
+
+// APIKeys - статические ключи из конфига. Хранятся только хеши, поэтому поиск
+// по карте не дает подбирать ключ по времени ответа
+type APIKeys struct {
+	subjects map[[sha256.Size]byte]string
+}
+
+// NewAPIKeys принимает ключи в виде субъект -> ключ
+func NewAPIKeys(keys map[string]string) (*APIKeys, error) {
+	a := &APIKeys{subjects: make(map[[sha256.Size]byte]string, len(keys))}
+	for subject, key := range keys {
+		if subject == "" {
+			return nil, fmt.Errorf("api key without subject")
+		}
+		if len(key) < MinAPIKeyLength {
+			return nil, fmt.Errorf("api key of %q is shorter than %d characters", subject, MinAPIKeyLength)
+		}
+		sum := sha256.Sum256([]byte(key))
+		if other, ok := a.subjects[sum]; ok {
+			return nil, fmt.Errorf("%q and %q have the same api key", other, subject)
+		}
+		a.subjects[sum] = subject
+	}
+	return a, nil
+}
+
+func (a *APIKeys) Authenticate(_ context.Context, c Credentials) (*Identity, error) {
+	if c.APIKey == "" {
+		return nil, ErrNoCredentials
+	}
+	subject, ok := a.subjects[sha256.Sum256([]byte(c.APIKey))]
+	if !ok {
+		return nil, errInvalidAPIKey
+	}
+	return &Identity{Subject: subject, Method: MethodAPIKey}, nil
+}
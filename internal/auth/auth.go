@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+// способы, которыми вызывающий подтвердил личность
+const (
+	MethodAPIKey    = "api_key"
+	MethodJWT       = "jwt"
+	MethodAnonymous = "anonymous"
+	MethodInternal  = "internal"
+)
+
+type (
+	// Identity - кто делает запрос. Транспорт кладет ее в контекст,
+	// сервисы достают через FromContext. Пустая Role - ни одной операции
+	Identity struct {
+		Subject string
+		Method  string
+		Role    models.Role
+	}
+
+	// Credentials - учетные данные из заголовков X-API-Key и Authorization: Bearer
+	// (в gRPC - из метаданных x-api-key и authorization)
+	Credentials struct {
+		APIKey string
+		Bearer string
+	}
+
+	// Authenticator проверяет учетные данные своего вида; если их в запросе нет,
+	// возвращает ErrNoCredentials, и Chain пробует следующий
+	Authenticator interface {
+		Authenticate(ctx context.Context, c Credentials) (*Identity, error)
+	}
+
+	// Chain - аутентификаторы по порядку; первый, кто узнал учетные данные, решает
+	Chain []Authenticator
+
+	// Anonymous пускает запросы без учетных данных с ролью Role. Стоит последним в Chain,
+	// поэтому неверный ключ или токен все равно отклоняются
+	Anonymous struct {
+		Role models.Role
+	}
+
+	identityKey struct{}
+)
+
+// System - личность фоновых задач самого сервиса
+var System = &Identity{Subject: "system", Method: MethodInternal, Role: models.RoleAdmin}
+
+var (
+	ErrNoCredentials        = errs.Unauthenticated("credentials required")
+	ErrUnsupportedScheme    = errs.Unauthenticated("unsupported authorization scheme, expected Bearer")
+	errAmbiguousCredentials = errs.Unauthenticated("both api key and bearer token given")
+	errNotAccepted          = errs.Unauthenticated("this kind of credentials is not accepted")
+)
+
+// ParseCredentials разбирает значения заголовков Authorization и X-API-Key
+func ParseCredentials(authorization, apiKey string) (Credentials, error) {
+	c := Credentials{APIKey: strings.TrimSpace(apiKey)}
+	if authorization = strings.TrimSpace(authorization); authorization != "" {
+		scheme, token, _ := strings.Cut(authorization, " ")
+		if !strings.EqualFold(scheme, "Bearer") {
+			return c, ErrUnsupportedScheme
+		}
+		c.Bearer = strings.TrimSpace(token)
+		if c.Bearer == "" {
+			return c, ErrNoCredentials
+		}
+	}
+	if c.APIKey != "" && c.Bearer != "" {
+		return c, errAmbiguousCredentials
+	}
+	return c, nil
+}
+
+func (ch Chain) Authenticate(ctx context.Context, c Credentials) (*Identity, error) {
+	for _, a := range ch {
+		id, err := a.Authenticate(ctx, c)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return id, err
+	}
+	if c.APIKey != "" || c.Bearer != "" {
+		return nil, errNotAccepted
+	}
+	return nil, ErrNoCredentials
+}
+
+func (a Anonymous) Authenticate(_ context.Context, c Credentials) (*Identity, error) {
+	if c.APIKey != "" || c.Bearer != "" {
+		return nil, ErrNoCredentials
+	}
+	return &Identity{Subject: "anonymous", Method: MethodAnonymous, Role: a.Role}, nil
+}
+
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext возвращает nil, если запрос пришел не через транспорт с аутентификацией
+func FromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityKey{}).(*Identity)
+	return id
+}
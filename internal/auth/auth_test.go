@@ -0,0 +1,143 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/mi-raf/zooad/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.Claims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestParseCredentials(t *testing.T) {
+	c, err := auth.ParseCredentials("bearer abc", "")
+	require.NoError(t, err)
+	assert.Equal(t, auth.Credentials{Bearer: "abc"}, c)
+
+	c, err = auth.ParseCredentials("", " key ")
+	require.NoError(t, err)
+	assert.Equal(t, auth.Credentials{APIKey: "key"}, c)
+
+	_, err = auth.ParseCredentials("Basic dXNlcjpwYXNz", "")
+	assert.ErrorIs(t, err, auth.ErrUnsupportedScheme)
+	_, err = auth.ParseCredentials("Bearer abc", "key")
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated)
+}
+
+func TestAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	_, err := auth.NewAPIKeys(map[string]string{"feeder": "short"})
+	assert.Error(t, err)
+	_, err = auth.NewAPIKeys(map[string]string{"a": "the-same-long-key", "b": "the-same-long-key"})
+	assert.Error(t, err)
+
+	keys, err := auth.NewAPIKeys(map[string]string{"feeder": "feeder-key-0123456789"})
+	require.NoError(t, err)
+	id, err := keys.Authenticate(ctx, auth.Credentials{APIKey: "feeder-key-0123456789"})
+	require.NoError(t, err)
+	assert.Equal(t, &auth.Identity{Subject: "feeder", Method: auth.MethodAPIKey}, id)
+
+	_, err = keys.Authenticate(ctx, auth.Credentials{APIKey: "feeder-key-000000000"})
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated)
+	_, err = keys.Authenticate(ctx, auth.Credentials{Bearer: "token"})
+	assert.ErrorIs(t, err, auth.ErrNoCredentials)
+}
+
+func TestJWT(t *testing.T) {
+	ctx := context.Background()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	pubFile, secretFile := filepath.Join(dir, "jwt.pub"), filepath.Join(dir, "jwt.secret")
+	require.NoError(t, os.WriteFile(pubFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(secretFile, []byte(secret+"\n"), 0o600))
+
+	hs, err := auth.ReadHS256Secret(secretFile)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(secret), hs)
+	pub, err := auth.ReadRS256PublicKey(pubFile)
+	require.NoError(t, err)
+
+	_, err = auth.NewJWT(&auth.JWTConfig{})
+	assert.Error(t, err, "no keys")
+	_, err = auth.NewJWT(&auth.JWTConfig{HS256Secret: []byte("short")})
+	assert.Error(t, err)
+	j, err := auth.NewJWT(&auth.JWTConfig{HS256Secret: hs, RS256Key: pub, Issuer: "zoo-sso"})
+	require.NoError(t, err)
+
+	exp := jwt.NewNumericDate(time.Now().Add(time.Hour))
+	valid := jwt.RegisteredClaims{Subject: "ivan", Issuer: "zoo-sso", ExpiresAt: exp}
+	for name, token := range map[string]string{
+		"hs256": sign(t, jwt.SigningMethodHS256, []byte(secret), valid),
+		"rs256": sign(t, jwt.SigningMethodRS256, rsaKey, valid),
+	} {
+		id, err := j.Authenticate(ctx, auth.Credentials{Bearer: token})
+		require.NoError(t, err, name)
+		assert.Equal(t, &auth.Identity{Subject: "ivan", Method: auth.MethodJWT}, id, name)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	for name, token := range map[string]string{
+		"expired":      sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.RegisteredClaims{Subject: "ivan", Issuer: "zoo-sso", ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour))}),
+		"no exp":       sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.RegisteredClaims{Subject: "ivan", Issuer: "zoo-sso"}),
+		"other issuer": sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.RegisteredClaims{Subject: "ivan", Issuer: "evil", ExpiresAt: exp}),
+		"no subject":   sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.RegisteredClaims{Issuer: "zoo-sso", ExpiresAt: exp}),
+		"wrong secret": sign(t, jwt.SigningMethodHS256, []byte(secret+"!"), valid),
+		"foreign rsa":  sign(t, jwt.SigningMethodRS256, otherKey, valid),
+		"unlisted alg": sign(t, jwt.SigningMethodHS512, []byte(secret), valid),
+		"unsigned":     sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid),
+		"not a jwt":    "abc",
+	} {
+		_, err := j.Authenticate(ctx, auth.Credentials{Bearer: token})
+		assert.ErrorIs(t, err, errs.ErrUnauthenticated, name)
+	}
+
+	// без rs256-ключа токены RS256 не принимаются вовсе
+	hsOnly, err := auth.NewJWT(&auth.JWTConfig{HS256Secret: hs})
+	require.NoError(t, err)
+	_, err = hsOnly.Authenticate(ctx, auth.Credentials{Bearer: sign(t, jwt.SigningMethodRS256, rsaKey, valid)})
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated)
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	keys, err := auth.NewAPIKeys(map[string]string{"feeder": "feeder-key-0123456789"})
+	require.NoError(t, err)
+
+	strict := auth.Chain{keys}
+	_, err = strict.Authenticate(ctx, auth.Credentials{})
+	assert.ErrorIs(t, err, auth.ErrNoCredentials)
+	_, err = strict.Authenticate(ctx, auth.Credentials{Bearer: "token"})
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated, "no jwt configured")
+
+	open := auth.Chain{keys, auth.Anonymous{}}
+	id, err := open.Authenticate(ctx, auth.Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, auth.MethodAnonymous, id.Method)
+	_, err = open.Authenticate(ctx, auth.Credentials{APIKey: "wrong-key-0123456789"})
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated, "bad key is not anonymous")
+
+	ctx = auth.WithIdentity(ctx, id)
+	assert.Equal(t, id, auth.FromContext(ctx))
+	assert.Nil(t, auth.FromContext(context.Background()))
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mi-raf/zooad/internal/errs"
+)
+
+// MinHS256SecretLength - секрет HS256 не короче длины подписи
+const MinHS256SecretLength = 32
+
+type (
+	// JWTConfig - хотя бы один из ключей обязателен; принимаются только алгоритмы,
+	// для которых задан ключ. Пустые Issuer и Audience не проверяются
+	JWTConfig struct {
+		HS256Secret []byte
+		RS256Key    *rsa.PublicKey
+		Issuer      string
+		Audience    string
+		// допустимое расхождение часов с выпускающим токены
+		Leeway time.Duration
+	}
+
+	// JWT проверяет bearer-токены; субъект - claim sub, срок exp обязателен
+	JWT struct {
+		parser *jwt.Parser
+		secret []byte
+		key    *rsa.PublicKey
+	}
+)
+
+func NewJWT(cfg *JWTConfig) (*JWT, error) {
+	var methods []string
+	if cfg.HS256Secret != nil {
+		if len(cfg.HS256Secret) < MinHS256SecretLength {
+			return nil, fmt.Errorf("hs256 secret is shorter than %d bytes", MinHS256SecretLength)
+		}
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.RS256Key != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("jwt needs hs256 secret or rs256 public key")
+	}
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired(), jwt.WithLeeway(cfg.Leeway)}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	return &JWT{parser: jwt.NewParser(opts...), secret: cfg.HS256Secret, key: cfg.RS256Key}, nil
+}
+
+// ReadHS256Secret читает секрет из файла; перевод строки в конце не считается
+func ReadHS256Secret(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(b, "\r\n"), nil
+}
+
+// ReadRS256PublicKey читает открытый ключ RSA в PEM
+func ReadRS256PublicKey(path string) (*rsa.PublicKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPublicKeyFromPEM(b)
+}
+
+// keyFunc выбирает ключ по alg; чужие алгоритмы отсекает WithValidMethods
+func (j *JWT) keyFunc(t *jwt.Token) (any, error) {
+	switch t.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return j.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		return j.key, nil
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+}
+
+func (j *JWT) Authenticate(_ context.Context, c Credentials) (*Identity, error) {
+	if c.Bearer == "" {
+		return nil, ErrNoCredentials
+	}
+	var claims jwt.RegisteredClaims
+	if _, err := j.parser.ParseWithClaims(c.Bearer, &claims, j.keyFunc); err != nil {
+		return nil, errs.Unauthenticated("invalid token: %v", err)
+	}
+	if claims.Subject == "" {
+		return nil, errs.Unauthenticated("token has no subject")
+	}
+	return &Identity{Subject: claims.Subject, Method: MethodJWT}, nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	selectEnclosures = `SELECT e.id_encl, e.name, e.zone, e.capacity, e.habitat,
+	COALESCE(array_agg(s.title ORDER BY s.title) FILTER (WHERE s.title IS NOT NULL), '{}'),
+	(SELECT count(*) FROM Animals a WHERE a.id_encl = e.id_encl)
+	FROM Enclosures e
+	LEFT JOIN Enclosure_species es ON es.id_encl = e.id_encl
+	LEFT JOIN Species s ON s.id_sp = es.id_sp`
+	selectAllEnclosures = selectEnclosures + " GROUP BY e.id_encl ORDER BY e.name"
+	selectEnclosure     = selectEnclosures + " WHERE e.id_encl = $1 GROUP BY e.id_encl"
+	insertEnclosure     = "INSERT INTO Enclosures (name, zone, capacity, habitat) VALUES($1, $2, $3, $4) RETURNING id_encl"
+	updateEnclosure     = "UPDATE Enclosures SET name = $1, zone = $2, capacity = $3, habitat = $4 WHERE id_encl = $5"
+	deleteEnclosure     = "DELETE FROM Enclosures WHERE id_encl = $1"
+	clearEnclosureSp    = "DELETE FROM Enclosure_species WHERE id_encl = $1"
+	insertEnclosureSp   = "INSERT INTO Enclosure_species (id_encl, id_sp) VALUES($1, $2) ON CONFLICT DO NOTHING"
+	strayResident       = `SELECT title, name_an FROM Animals JOIN Species ON Animals.id_sp = Species.id_sp
+	WHERE id_encl = $1 AND NOT title = ANY($2) ORDER BY id_anim LIMIT 1`
+)
+
+var (
+	ErrEnclosureNotFound error = errs.NotFound("enclosure not found")
+	ErrEnclosureExists   error = errs.Conflict("enclosure with this name already exists")
+	ErrEnclosureInUse    error = errs.Conflict("enclosure still houses animals")
+	ErrEnclosureFull     error = errs.Conflict("enclosure is full")
+)
+
+type EnclosureRepository interface {
+	List(ctx context.Context) ([]models.Enclosure, error)
+	Get(ctx context.Context, idEncl int64) (*models.Enclosure, error)
+	// Add и Update принимают виды по названию, неизвестное название - UnknownSpecies
+	Add(ctx context.Context, enc *models.Enclosure) (int64, error)
+	// Update не дает опустить вместимость ниже числа жильцов и убрать вид, который в вольере живет
+	Update(ctx context.Context, enc *models.Enclosure) error
+	Delete(ctx context.Context, idEncl int64) error
+}
+
+type PgEnclosureRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewEnclosureRepository(ctx context.Context, p *pgxpool.Pool) (*PgEnclosureRepository, error) {
+	return &PgEnclosureRepository{pool: p}, nil
+}
+
+func scanEnclosure(row pgx.Row) (*models.Enclosure, error) {
+	var enc models.Enclosure
+	err := row.Scan(&enc.IdEncl, &enc.Name, &enc.Zone, &enc.Capacity, &enc.Habitat, &enc.Species, &enc.Occupancy)
+	if err != nil {
+		return nil, err
+	}
+	return &enc, nil
+}
+
+func (r *PgEnclosureRepository) List(ctx context.Context) ([]models.Enclosure, error) {
+	rows, err := r.pool.Query(ctx, selectAllEnclosures)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	enclosures := make([]models.Enclosure, 0)
+	for rows.Next() {
+		enc, err := scanEnclosure(rows)
+		if err != nil {
+			return nil, err
+		}
+		enclosures = append(enclosures, *enc)
+	}
+	return enclosures, rows.Err()
+}
+
+func (r *PgEnclosureRepository) Get(ctx context.Context, idEncl int64) (*models.Enclosure, error) {
+	enc, err := scanEnclosure(r.pool.QueryRow(ctx, selectEnclosure, idEncl))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEnclosureNotFound
+	}
+	return enc, err
+}
+
+func (r *PgEnclosureRepository) Add(ctx context.Context, enc *models.Enclosure) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int64
+	err = tx.QueryRow(ctx, insertEnclosure, enc.Name, enc.Zone, enc.Capacity, enc.Habitat).Scan(&id)
+	if err != nil {
+		return -1, enclosureError(err)
+	}
+	if err := setEnclosureSpecies(ctx, tx, id, enc.Species); err != nil {
+		return -1, err
+	}
+	return id, tx.Commit(ctx)
+}
+
+func (r *PgEnclosureRepository) Update(ctx context.Context, enc *models.Enclosure) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// блокируем вольер, как при заселении, чтобы жильцы не поменялись до коммита
+	var capacity, occupied int
+	err = tx.QueryRow(ctx, lockEnclosure, enc.IdEncl).Scan(&capacity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrEnclosureNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.QueryRow(ctx, countOccupants, enc.IdEncl, 0).Scan(&occupied); err != nil {
+		return err
+	}
+	if occupied > enc.Capacity {
+		return errs.Conflict("enclosure houses %d animals, capacity %d is too small", occupied, enc.Capacity)
+	}
+	var title, name string
+	err = tx.QueryRow(ctx, strayResident, enc.IdEncl, enc.Species).Scan(&title, &name)
+	if err == nil {
+		return errs.Conflict("enclosure houses %s %s, species must stay allowed", title, name)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if _, err := tx.Exec(ctx, updateEnclosure, enc.Name, enc.Zone, enc.Capacity, enc.Habitat, enc.IdEncl); err != nil {
+		return enclosureError(err)
+	}
+	if _, err := tx.Exec(ctx, clearEnclosureSp, enc.IdEncl); err != nil {
+		return err
+	}
+	if err := setEnclosureSpecies(ctx, tx, enc.IdEncl, enc.Species); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Delete не дает удалить вольер, пока в нем живут животные - это проверяет внешний ключ Animals.id_encl
+func (r *PgEnclosureRepository) Delete(ctx context.Context, idEncl int64) error {
+	tag, err := r.pool.Exec(ctx, deleteEnclosure, idEncl)
+	if err != nil {
+		return enclosureError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEnclosureNotFound
+	}
+	return nil
+}
+
+func setEnclosureSpecies(ctx context.Context, tx pgx.Tx, idEncl int64, titles []string) error {
+	for _, title := range titles {
+		var idSp int64
+		err := tx.QueryRow(ctx, searchIdSp, title).Scan(&idSp)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errs.UnknownSpecies(title)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, insertEnclosureSp, idEncl, idSp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func enclosureError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return ErrEnclosureExists
+		case pgForeignKeyViolation:
+			return ErrEnclosureInUse
+		}
+	}
+	return err
+}
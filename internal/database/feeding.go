@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	selectPlans = `SELECT p.id_plan, COALESCE(s.title, ''), p.id_anim, p.food, p.quantity, p.unit, p.times
+	FROM Feeding_plans p LEFT JOIN Species s ON s.id_sp = p.id_sp`
+	selectPlan  = selectPlans + " WHERE p.id_plan = $1"
+	insertPlan  = "INSERT INTO Feeding_plans (id_sp, id_anim, food, quantity, unit, times) VALUES($1, $2, $3, $4, $5, $6) RETURNING id_plan"
+	updatePlan  = "UPDATE Feeding_plans SET id_sp = $1, id_anim = $2, food = $3, quantity = $4, unit = $5, times = $6 WHERE id_plan = $7"
+	deletePlan  = "DELETE FROM Feeding_plans WHERE id_plan = $1"
+	insertFeed  = "INSERT INTO Feedings (id_anim, id_plan, food, quantity, unit, keeper, fed_at, notes) VALUES($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id_feed"
+	selectFeeds = "SELECT id_feed, id_anim, id_plan, food, quantity, unit, keeper, fed_at, notes FROM Feedings"
+	// последнее кормление животного по каждому плану и каждому корму
+	selectLatestFeeds = `SELECT DISTINCT ON (id_anim, id_plan, food) id_feed, id_anim, id_plan, food, quantity, unit, keeper, fed_at, notes
+	FROM Feedings ORDER BY id_anim, id_plan, food, fed_at DESC`
+)
+
+var ErrFeedingPlanNotFound error = errs.NotFound("feeding plan not found")
+
+type FeedingRepository interface {
+	ListPlans(ctx context.Context, f models.FeedingPlanFilter) ([]models.FeedingPlan, error)
+	GetPlan(ctx context.Context, idPlan int64) (*models.FeedingPlan, error)
+	// AddPlan и UpdatePlan принимают вид по названию, неизвестное название - UnknownSpecies
+	AddPlan(ctx context.Context, plan *models.FeedingPlan) (int64, error)
+	UpdatePlan(ctx context.Context, plan *models.FeedingPlan) error
+	DeletePlan(ctx context.Context, idPlan int64) error
+	AddFeeding(ctx context.Context, feed *models.Feeding) (int64, error)
+	// ListFeedings отдает кормления от новых к старым
+	ListFeedings(ctx context.Context, f models.FeedingFilter) ([]models.Feeding, error)
+	// LatestFeedings - последнее кормление каждого животного по каждому плану и корму
+	LatestFeedings(ctx context.Context) ([]models.Feeding, error)
+}
+
+type PgFeedingRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewFeedingRepository(ctx context.Context, p *pgxpool.Pool) (*PgFeedingRepository, error) {
+	return &PgFeedingRepository{pool: p}, nil
+}
+
+func scanPlan(row pgx.Row) (*models.FeedingPlan, error) {
+	var plan models.FeedingPlan
+	err := row.Scan(&plan.IdPlan, &plan.Species, &plan.IdAnim, &plan.Food, &plan.Quantity, &plan.Unit, &plan.Times)
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func scanFeeding(row pgx.Row) (*models.Feeding, error) {
+	var feed models.Feeding
+	err := row.Scan(&feed.IdFeed, &feed.IdAnim, &feed.IdPlan, &feed.Food, &feed.Quantity, &feed.Unit, &feed.Keeper, &feed.FedAt, &feed.Notes)
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *PgFeedingRepository) ListPlans(ctx context.Context, f models.FeedingPlanFilter) ([]models.FeedingPlan, error) {
+	var b sqlBuilder
+	if f.Species != "" {
+		b.cond("s.title = %s", b.arg(f.Species))
+	}
+	if f.IdAnim != nil {
+		b.cond("p.id_anim = %s", b.arg(*f.IdAnim))
+	}
+	query := selectPlans
+	if len(b.where) > 0 {
+		query += "\n\tWHERE " + strings.Join(b.where, " AND ")
+	}
+	query += "\n\tORDER BY p.id_plan"
+
+	rows, err := r.pool.Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := make([]models.FeedingPlan, 0)
+	for rows.Next() {
+		plan, err := scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, *plan)
+	}
+	return plans, rows.Err()
+}
+
+func (r *PgFeedingRepository) GetPlan(ctx context.Context, idPlan int64) (*models.FeedingPlan, error) {
+	plan, err := scanPlan(r.pool.QueryRow(ctx, selectPlan, idPlan))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFeedingPlanNotFound
+	}
+	return plan, err
+}
+
+// planSpecies находит id вида плана, для плана животного - nil
+func (r *PgFeedingRepository) planSpecies(ctx context.Context, plan *models.FeedingPlan) (*int64, error) {
+	if plan.Species == "" {
+		return nil, nil
+	}
+	var idSp int64
+	err := r.pool.QueryRow(ctx, searchIdSp, plan.Species).Scan(&idSp)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.UnknownSpecies(plan.Species)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &idSp, nil
+}
+
+func (r *PgFeedingRepository) AddPlan(ctx context.Context, plan *models.FeedingPlan) (int64, error) {
+	idSp, err := r.planSpecies(ctx, plan)
+	if err != nil {
+		return -1, err
+	}
+	var id int64
+	err = r.pool.QueryRow(ctx, insertPlan, idSp, plan.IdAnim, plan.Food, plan.Quantity, plan.Unit, plan.Times).Scan(&id)
+	if err != nil {
+		return -1, feedingError(err)
+	}
+	return id, nil
+}
+
+func (r *PgFeedingRepository) UpdatePlan(ctx context.Context, plan *models.FeedingPlan) error {
+	idSp, err := r.planSpecies(ctx, plan)
+	if err != nil {
+		return err
+	}
+	tag, err := r.pool.Exec(ctx, updatePlan, idSp, plan.IdAnim, plan.Food, plan.Quantity, plan.Unit, plan.Times, plan.IdPlan)
+	if err != nil {
+		return feedingError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrFeedingPlanNotFound
+	}
+	return nil
+}
+
+func (r *PgFeedingRepository) DeletePlan(ctx context.Context, idPlan int64) error {
+	tag, err := r.pool.Exec(ctx, deletePlan, idPlan)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrFeedingPlanNotFound
+	}
+	return nil
+}
+
+func (r *PgFeedingRepository) AddFeeding(ctx context.Context, feed *models.Feeding) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, insertFeed, feed.IdAnim, feed.IdPlan, feed.Food, feed.Quantity, feed.Unit, feed.Keeper, feed.FedAt, feed.Notes).Scan(&id)
+	if err != nil {
+		return -1, feedingError(err)
+	}
+	return id, nil
+}
+
+func (r *PgFeedingRepository) ListFeedings(ctx context.Context, f models.FeedingFilter) ([]models.Feeding, error) {
+	var b sqlBuilder
+	b.cond("id_anim = %s", b.arg(f.IdAnim))
+	if !f.From.IsZero() {
+		b.cond("fed_at >= %s", b.arg(f.From))
+	}
+	if !f.To.IsZero() {
+		b.cond("fed_at < %s", b.arg(f.To))
+	}
+	query := fmt.Sprintf("%s\n\tWHERE %s\n\tORDER BY fed_at DESC, id_feed DESC\n\tLIMIT %s",
+		selectFeeds, strings.Join(b.where, " AND "), b.arg(f.Limit))
+	return r.feedings(ctx, query, b.args...)
+}
+
+func (r *PgFeedingRepository) LatestFeedings(ctx context.Context) ([]models.Feeding, error) {
+	return r.feedings(ctx, selectLatestFeeds)
+}
+
+func (r *PgFeedingRepository) feedings(ctx context.Context, query string, args ...any) ([]models.Feeding, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	feeds := make([]models.Feeding, 0)
+	for rows.Next() {
+		feed, err := scanFeeding(rows)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, *feed)
+	}
+	return feeds, rows.Err()
+}
+
+// feedingError - ссылка на удаленное животное или план
+func feedingError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		if strings.Contains(pgErr.ConstraintName, "id_plan") {
+			return ErrFeedingPlanNotFound
+		}
+		return ErrNotFound
+	}
+	return err
+}
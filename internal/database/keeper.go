@@ -0,0 +1,200 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	selectKeepers = `SELECT k.id_keeper, k.name, k.role, k.contact,
+	COALESCE(array_agg(s.title ORDER BY s.title) FILTER (WHERE s.title IS NOT NULL), '{}')
+	FROM Keepers k
+	LEFT JOIN Keeper_species ks ON ks.id_keeper = k.id_keeper
+	LEFT JOIN Species s ON s.id_sp = ks.id_sp`
+	selectAllKeepers  = selectKeepers + " GROUP BY k.id_keeper ORDER BY k.name"
+	selectKeeper      = selectKeepers + " WHERE k.id_keeper = $1 GROUP BY k.id_keeper"
+	insertKeeper      = "INSERT INTO Keepers (name, role, contact) VALUES($1, $2, $3) RETURNING id_keeper"
+	updateKeeper      = "UPDATE Keepers SET name = $1, role = $2, contact = $3 WHERE id_keeper = $4"
+	deleteKeeper      = "DELETE FROM Keepers WHERE id_keeper = $1"
+	clearKeeperSp     = "DELETE FROM Keeper_species WHERE id_keeper = $1"
+	insertKeeperSp    = "INSERT INTO Keeper_species (id_keeper, id_sp) VALUES($1, $2) ON CONFLICT DO NOTHING"
+	insertAssignment  = "INSERT INTO Keeper_assignments (id_keeper, id_anim, id_encl) VALUES($1, $2, $3) RETURNING id_assign, assigned_at"
+	deleteAssignment  = "DELETE FROM Keeper_assignments WHERE id_assign = $1"
+	selectAssignments = "SELECT id_assign, id_keeper, id_anim, id_encl, assigned_at FROM Keeper_assignments"
+)
+
+var (
+	ErrKeeperNotFound     error = errs.NotFound("keeper not found")
+	ErrKeeperExists       error = errs.Conflict("keeper with this name already exists")
+	ErrAssignmentNotFound error = errs.NotFound("assignment not found")
+	ErrAssignmentExists   error = errs.Conflict("keeper is already assigned there")
+)
+
+type KeeperRepository interface {
+	List(ctx context.Context) ([]models.Keeper, error)
+	Get(ctx context.Context, idKeeper int64) (*models.Keeper, error)
+	// Add и Update принимают виды по названию, неизвестное название - UnknownSpecies
+	Add(ctx context.Context, k *models.Keeper) (int64, error)
+	Update(ctx context.Context, k *models.Keeper) error
+	// Delete снимает и все закрепления смотрителя
+	Delete(ctx context.Context, idKeeper int64) error
+	// Assign заполняет IdAssign и AssignedAt
+	Assign(ctx context.Context, a *models.KeeperAssignment) error
+	Unassign(ctx context.Context, idAssign int64) error
+	// Assignments отдает закрепления в порядке их создания
+	Assignments(ctx context.Context, f models.AssignmentFilter) ([]models.KeeperAssignment, error)
+}
+
+type PgKeeperRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewKeeperRepository(ctx context.Context, p *pgxpool.Pool) (*PgKeeperRepository, error) {
+	return &PgKeeperRepository{pool: p}, nil
+}
+
+func scanKeeper(row pgx.Row, k *models.Keeper) error {
+	return row.Scan(&k.IdKeeper, &k.Name, &k.Role, &k.Contact, &k.Species)
+}
+
+func scanAssignment(row pgx.Row, a *models.KeeperAssignment) error {
+	return row.Scan(&a.IdAssign, &a.IdKeeper, &a.IdAnim, &a.IdEncl, &a.AssignedAt)
+}
+
+func (r *PgKeeperRepository) List(ctx context.Context) ([]models.Keeper, error) {
+	return collect(ctx, r.pool, selectAllKeepers, nil, scanKeeper)
+}
+
+func (r *PgKeeperRepository) Get(ctx context.Context, idKeeper int64) (*models.Keeper, error) {
+	var k models.Keeper
+	err := scanKeeper(r.pool.QueryRow(ctx, selectKeeper, idKeeper), &k)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrKeeperNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *PgKeeperRepository) Add(ctx context.Context, k *models.Keeper) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int64
+	if err := tx.QueryRow(ctx, insertKeeper, k.Name, k.Role, k.Contact).Scan(&id); err != nil {
+		return -1, keeperError(err)
+	}
+	if err := setKeeperSpecies(ctx, tx, id, k.Species); err != nil {
+		return -1, err
+	}
+	return id, tx.Commit(ctx)
+}
+
+func (r *PgKeeperRepository) Update(ctx context.Context, k *models.Keeper) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, updateKeeper, k.Name, k.Role, k.Contact, k.IdKeeper)
+	if err != nil {
+		return keeperError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrKeeperNotFound
+	}
+	if _, err := tx.Exec(ctx, clearKeeperSp, k.IdKeeper); err != nil {
+		return err
+	}
+	if err := setKeeperSpecies(ctx, tx, k.IdKeeper, k.Species); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PgKeeperRepository) Delete(ctx context.Context, idKeeper int64) error {
+	tag, err := r.pool.Exec(ctx, deleteKeeper, idKeeper)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrKeeperNotFound
+	}
+	return nil
+}
+
+func (r *PgKeeperRepository) Assign(ctx context.Context, a *models.KeeperAssignment) error {
+	err := r.pool.QueryRow(ctx, insertAssignment, a.IdKeeper, a.IdAnim, a.IdEncl).Scan(&a.IdAssign, &a.AssignedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return ErrAssignmentExists
+	}
+	return err
+}
+
+func (r *PgKeeperRepository) Unassign(ctx context.Context, idAssign int64) error {
+	tag, err := r.pool.Exec(ctx, deleteAssignment, idAssign)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAssignmentNotFound
+	}
+	return nil
+}
+
+func (r *PgKeeperRepository) Assignments(ctx context.Context, f models.AssignmentFilter) ([]models.KeeperAssignment, error) {
+	var b sqlBuilder
+	if f.IdKeeper != nil {
+		b.cond("id_keeper = %s", b.arg(*f.IdKeeper))
+	}
+	if f.IdAnim != nil {
+		b.cond("id_anim = %s", b.arg(*f.IdAnim))
+	}
+	if f.IdEncl != nil {
+		b.cond("id_encl = %s", b.arg(*f.IdEncl))
+	}
+	query := selectAssignments
+	if len(b.where) > 0 {
+		query += "\n\tWHERE " + strings.Join(b.where, " AND ")
+	}
+	query += "\n\tORDER BY id_assign"
+	return collect(ctx, r.pool, query, b.args, scanAssignment)
+}
+
+func setKeeperSpecies(ctx context.Context, tx pgx.Tx, idKeeper int64, titles []string) error {
+	for _, title := range titles {
+		var idSp int64
+		err := tx.QueryRow(ctx, searchIdSp, title).Scan(&idSp)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errs.UnknownSpecies(title)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, insertKeeperSp, idKeeper, idSp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func keeperError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return ErrKeeperExists
+	}
+	return err
+}
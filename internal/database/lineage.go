@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	setParents = "UPDATE Animals SET mother_id = $2, mother_ext = $3, father_id = $4, father_ext = $5 WHERE id_anim = $1"
+	selectKin  = `SELECT id_anim, name_an, gender, title, birth_date, mother_id, mother_ext, father_id, father_ext FROM
+	Animals JOIN Species ON Animals.id_sp = Species.id_sp`
+)
+
+var (
+	ErrParentNotFound     error = errs.NotFound("parent not found")
+	ErrAnimalHasOffspring error = errs.Conflict("animal has registered offspring")
+)
+
+type LineageRepository interface {
+	// SetParents заменяет обоих родителей животного
+	SetParents(ctx context.Context, idAnim int64, mother, father models.Parent) error
+	// Kin - животные ids с их родителями, несуществующие пропускаются
+	Kin(ctx context.Context, ids []int64) ([]models.Kin, error)
+	// Children - животные, у которых мать или отец среди ids
+	Children(ctx context.Context, ids []int64) ([]models.Kin, error)
+}
+
+type PgLineageRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLineageRepository(ctx context.Context, p *pgxpool.Pool) (*PgLineageRepository, error) {
+	return &PgLineageRepository{pool: p}, nil
+}
+
+func scanKin(row pgx.Row, k *models.Kin) error {
+	return row.Scan(&k.IdAnim, &k.NameAn, &k.Gender, &k.Title, &k.BirthDate, &k.Mother.IdAnim, &k.Mother.External, &k.Father.IdAnim, &k.Father.External)
+}
+
+func (r *PgLineageRepository) SetParents(ctx context.Context, idAnim int64, mother, father models.Parent) error {
+	tag, err := r.pool.Exec(ctx, setParents, idAnim, mother.IdAnim, mother.External, father.IdAnim, father.External)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return ErrParentNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PgLineageRepository) Kin(ctx context.Context, ids []int64) ([]models.Kin, error) {
+	return collect(ctx, r.pool, selectKin+"\n\tWHERE id_anim = ANY($1)\n\tORDER BY id_anim", []any{ids}, scanKin)
+}
+
+func (r *PgLineageRepository) Children(ctx context.Context, ids []int64) ([]models.Kin, error) {
+	return collect(ctx, r.pool, selectKin+"\n\tWHERE mother_id = ANY($1) OR father_id = ANY($1)\n\tORDER BY id_anim", []any{ids}, scanKin)
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	insertMeasurement  = "INSERT INTO Measurements (id_anim, measured_at, weight_kg, length_cm, body_condition, notes) VALUES($1, $2, $3, $4, $5, $6) RETURNING id_meas"
+	selectMeasurements = "SELECT id_meas, id_anim, measured_at, weight_kg, length_cm, body_condition, notes FROM Measurements"
+	deleteMeasurement  = "DELETE FROM Measurements WHERE id_meas = $1"
+	latestMeasurement  = selectMeasurements + " WHERE id_anim = $1 ORDER BY measured_at DESC, id_meas DESC LIMIT 1"
+)
+
+var ErrMeasurementNotFound error = errs.NotFound("measurement not found")
+
+type MeasurementRepository interface {
+	Add(ctx context.Context, m *models.Measurement) (int64, error)
+	Delete(ctx context.Context, idMeas int64) error
+	// List отдает замеры животного от старых к новым
+	List(ctx context.Context, f models.MeasurementFilter) ([]models.Measurement, error)
+	// Latest - последний замер животного, nil - замеров нет
+	Latest(ctx context.Context, idAnim int64) (*models.Measurement, error)
+	// Since - замеры всех животных не раньше from, по животным и от старых к новым
+	Since(ctx context.Context, from time.Time) ([]models.Measurement, error)
+}
+
+type PgMeasurementRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMeasurementRepository(ctx context.Context, p *pgxpool.Pool) (*PgMeasurementRepository, error) {
+	return &PgMeasurementRepository{pool: p}, nil
+}
+
+func scanMeasurement(row pgx.Row, m *models.Measurement) error {
+	return row.Scan(&m.IdMeas, &m.IdAnim, &m.MeasuredAt, &m.WeightKg, &m.LengthCm, &m.BodyCondition, &m.Notes)
+}
+
+func (r *PgMeasurementRepository) Add(ctx context.Context, m *models.Measurement) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, insertMeasurement, m.IdAnim, m.MeasuredAt, m.WeightKg, m.LengthCm, m.BodyCondition, m.Notes).Scan(&id)
+	if err != nil {
+		return -1, measurementError(err)
+	}
+	return id, nil
+}
+
+func (r *PgMeasurementRepository) Delete(ctx context.Context, idMeas int64) error {
+	tag, err := r.pool.Exec(ctx, deleteMeasurement, idMeas)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMeasurementNotFound
+	}
+	return nil
+}
+
+func (r *PgMeasurementRepository) List(ctx context.Context, f models.MeasurementFilter) ([]models.Measurement, error) {
+	var b sqlBuilder
+	b.cond("id_anim = %s", b.arg(f.IdAnim))
+	if !f.From.IsZero() {
+		b.cond("measured_at >= %s", b.arg(f.From))
+	}
+	if !f.To.IsZero() {
+		b.cond("measured_at < %s", b.arg(f.To))
+	}
+	query := fmt.Sprintf("%s\n\tWHERE %s\n\tORDER BY measured_at, id_meas", selectMeasurements, strings.Join(b.where, " AND "))
+	return collect(ctx, r.pool, query, b.args, scanMeasurement)
+}
+
+func (r *PgMeasurementRepository) Latest(ctx context.Context, idAnim int64) (*models.Measurement, error) {
+	var m models.Measurement
+	err := scanMeasurement(r.pool.QueryRow(ctx, latestMeasurement, idAnim), &m)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *PgMeasurementRepository) Since(ctx context.Context, from time.Time) ([]models.Measurement, error) {
+	query := selectMeasurements + "\n\tWHERE measured_at >= $1\n\tORDER BY id_anim, measured_at, id_meas"
+	return collect(ctx, r.pool, query, []any{from}, scanMeasurement)
+}
+
+// measurementError - замер ссылается только на животное
+func measurementError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return ErrNotFound
+	}
+	return err
+}
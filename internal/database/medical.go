@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	insertRecord      = "INSERT INTO Medical_records (id_anim, kind, title, details, vet, recorded_at) VALUES($1, $2, $3, $4, $5, $6) RETURNING id_rec"
+	selectRecords     = "SELECT id_rec, id_anim, kind, title, details, vet, recorded_at FROM Medical_records"
+	insertMedication  = "INSERT INTO Medications (id_anim, id_rec, drug, dose, dose_unit, frequency, started_at, days, vet) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id_med"
+	selectMedications = "SELECT id_med, id_anim, id_rec, drug, dose, dose_unit, frequency, started_at, days, vet FROM Medications"
+	insertVaccination = "INSERT INTO Vaccinations (id_anim, vaccine, given_at, due_at, vet) VALUES($1, $2, $3, $4, $5) RETURNING id_vac"
+	selectVacc        = "SELECT id_vac, id_anim, vaccine, given_at, due_at, vet FROM Vaccinations"
+	// последняя прививка каждой вакциной у каждого животного, если по ней подходит срок
+	selectDueVacc = `SELECT v.id_vac, v.id_anim, v.vaccine, v.given_at, v.due_at, v.vet, a.name_an, s.title
+	FROM (SELECT DISTINCT ON (id_anim, vaccine) * FROM Vaccinations ORDER BY id_anim, vaccine, given_at DESC, id_vac DESC) v
+	JOIN Animals a ON a.id_anim = v.id_anim
+	JOIN Species s ON s.id_sp = a.id_sp
+	WHERE v.due_at <= $1
+	ORDER BY v.due_at, v.id_vac`
+)
+
+var ErrMedicalRecordNotFound error = errs.NotFound("medical record not found")
+
+// MedicalRepository - медицинская история животных. История только дополняется,
+// записи удаляются вместе с животным
+type MedicalRepository interface {
+	AddRecord(ctx context.Context, rec *models.MedicalRecord) (int64, error)
+	GetRecord(ctx context.Context, idRec int64) (*models.MedicalRecord, error)
+	AddMedication(ctx context.Context, med *models.Medication) (int64, error)
+	AddVaccination(ctx context.Context, vac *models.Vaccination) (int64, error)
+	History(ctx context.Context, f models.MedicalFilter) (*models.MedicalHistory, error)
+	// DueVaccinations - последние прививки каждой вакциной, повтор которых нужен не позже until
+	DueVaccinations(ctx context.Context, until time.Time) ([]models.DueVaccination, error)
+}
+
+type PgMedicalRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMedicalRepository(ctx context.Context, p *pgxpool.Pool) (*PgMedicalRepository, error) {
+	return &PgMedicalRepository{pool: p}, nil
+}
+
+func (r *PgMedicalRepository) AddRecord(ctx context.Context, rec *models.MedicalRecord) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, insertRecord, rec.IdAnim, rec.Kind, rec.Title, rec.Details, rec.Vet, rec.RecordedAt).Scan(&id)
+	if err != nil {
+		return -1, medicalError(err)
+	}
+	return id, nil
+}
+
+func (r *PgMedicalRepository) GetRecord(ctx context.Context, idRec int64) (*models.MedicalRecord, error) {
+	var rec models.MedicalRecord
+	err := r.pool.QueryRow(ctx, selectRecords+" WHERE id_rec = $1", idRec).
+		Scan(&rec.IdRec, &rec.IdAnim, &rec.Kind, &rec.Title, &rec.Details, &rec.Vet, &rec.RecordedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrMedicalRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *PgMedicalRepository) AddMedication(ctx context.Context, med *models.Medication) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, insertMedication, med.IdAnim, med.IdRec, med.Drug, med.Dose, med.DoseUnit,
+		med.Frequency, med.StartedAt, med.Days, med.Vet).Scan(&id)
+	if err != nil {
+		return -1, medicalError(err)
+	}
+	return id, nil
+}
+
+func (r *PgMedicalRepository) AddVaccination(ctx context.Context, vac *models.Vaccination) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, insertVaccination, vac.IdAnim, vac.Vaccine, vac.GivenAt, vac.DueAt, vac.Vet).Scan(&id)
+	if err != nil {
+		return -1, medicalError(err)
+	}
+	return id, nil
+}
+
+// historyQuery - выборка записей животного за период по столбцу времени column
+func historyQuery(base, column string, f models.MedicalFilter) (string, []any) {
+	var b sqlBuilder
+	b.cond("id_anim = %s", b.arg(f.IdAnim))
+	if !f.From.IsZero() {
+		b.cond(column+" >= %s", b.arg(f.From))
+	}
+	if !f.To.IsZero() {
+		b.cond(column+" < %s", b.arg(f.To))
+	}
+	return fmt.Sprintf("%s\n\tWHERE %s\n\tORDER BY %s DESC", base, strings.Join(b.where, " AND "), column), b.args
+}
+
+func (r *PgMedicalRepository) History(ctx context.Context, f models.MedicalFilter) (*models.MedicalHistory, error) {
+	var (
+		h   models.MedicalHistory
+		err error
+	)
+	query, args := historyQuery(selectRecords, "recorded_at", f)
+	h.Records, err = collect(ctx, r.pool, query, args, func(row pgx.Row, rec *models.MedicalRecord) error {
+		return row.Scan(&rec.IdRec, &rec.IdAnim, &rec.Kind, &rec.Title, &rec.Details, &rec.Vet, &rec.RecordedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	query, args = historyQuery(selectMedications, "started_at", f)
+	h.Medications, err = collect(ctx, r.pool, query, args, func(row pgx.Row, med *models.Medication) error {
+		return row.Scan(&med.IdMed, &med.IdAnim, &med.IdRec, &med.Drug, &med.Dose, &med.DoseUnit,
+			&med.Frequency, &med.StartedAt, &med.Days, &med.Vet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	query, args = historyQuery(selectVacc, "given_at", f)
+	h.Vaccinations, err = collect(ctx, r.pool, query, args, func(row pgx.Row, vac *models.Vaccination) error {
+		return row.Scan(&vac.IdVac, &vac.IdAnim, &vac.Vaccine, &vac.GivenAt, &vac.DueAt, &vac.Vet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *PgMedicalRepository) DueVaccinations(ctx context.Context, until time.Time) ([]models.DueVaccination, error) {
+	return collect(ctx, r.pool, selectDueVacc, []any{until}, func(row pgx.Row, v *models.DueVaccination) error {
+		return row.Scan(&v.IdVac, &v.IdAnim, &v.Vaccine, &v.GivenAt, &v.DueAt, &v.Vet, &v.NameAn, &v.Title)
+	})
+}
+
+// collect читает все строки запроса через scan
+func collect[T any](ctx context.Context, pool *pgxpool.Pool, query string, args []any, scan func(pgx.Row, *T) error) ([]T, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]T, 0)
+	for rows.Next() {
+		var v T
+		if err := scan(rows, &v); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}
+
+// medicalError - ссылка на удаленное животное или запись
+func medicalError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		if strings.Contains(pgErr.ConstraintName, "id_rec") {
+			return ErrMedicalRecordNotFound
+		}
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,1399 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+// MemStorage - хранилище в памяти для тестов и демо-режима (STORAGE=memory).
+// Репозитории поверх него повторяют семантику Pg-реализаций: поиск вида по названию,
+// последовательные id, те же ошибки
+type MemStorage struct {
+	mux        sync.RWMutex
+	species    map[int64]models.Specie
+	animals    map[int64]models.AnimalSmall
+	enclosures map[int64]memEnclosure
+	plans      map[int64]memPlan
+	feedings   map[int64]models.Feeding
+	records    map[int64]models.MedicalRecord
+	meds       map[int64]models.Medication
+	vaccs      map[int64]models.Vaccination
+	measures   map[int64]models.Measurement
+	parents    map[int64]memParents
+	moods      map[int64]models.MoodEntry
+	obs        map[int64]models.Observation
+	users      map[int64]models.User
+	keepers    map[int64]memKeeper
+	assigns    map[int64]models.KeeperAssignment
+	lastSpId   int64
+	lastAnId   int64
+	lastEncId  int64
+	lastPlanId int64
+	lastFeedId int64
+	lastRecId  int64
+	lastMedId  int64
+	lastVacId  int64
+	lastMeasId int64
+	lastMoodId int64
+	lastObsId  int64
+	lastUserId int64
+	lastKeepId int64
+	lastAsgnId int64
+}
+
+// memPlan хранит вид по id, как Feeding_plans
+type memPlan struct {
+	models.FeedingPlan
+	idSp int64
+}
+
+// memParents - mother_id, mother_ext, father_id и father_ext из Animals
+type memParents struct {
+	mother, father models.Parent
+}
+
+// memKeeper хранит виды по id, как Keeper_species
+type memKeeper struct {
+	models.Keeper
+	species []int64
+}
+
+// memEnclosure хранит допустимые виды по id, как Enclosure_species
+type memEnclosure struct {
+	models.Enclosure
+	species []int64
+}
+
+func NewMemStorage() *MemStorage {
+	return &MemStorage{
+		species:    make(map[int64]models.Specie),
+		animals:    make(map[int64]models.AnimalSmall),
+		enclosures: make(map[int64]memEnclosure),
+		plans:      make(map[int64]memPlan),
+		feedings:   make(map[int64]models.Feeding),
+		records:    make(map[int64]models.MedicalRecord),
+		meds:       make(map[int64]models.Medication),
+		vaccs:      make(map[int64]models.Vaccination),
+		measures:   make(map[int64]models.Measurement),
+		parents:    make(map[int64]memParents),
+		moods:      make(map[int64]models.MoodEntry),
+		obs:        make(map[int64]models.Observation),
+		users:      make(map[int64]models.User),
+		keepers:    make(map[int64]memKeeper),
+		assigns:    make(map[int64]models.KeeperAssignment),
+	}
+}
+
+// MemStorage нечего проверять и освобождать, но он регистрируется
+// в ServiceKeeper наравне с Pg-репозиторием
+func (st *MemStorage) Init(ctx context.Context) error {
+	return nil
+}
+
+func (st *MemStorage) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (st *MemStorage) Name() string {
+	return "memory"
+}
+
+func (st *MemStorage) Close() error {
+	return nil
+}
+
+// speciesByTitle вызывается под блокировкой
+func (st *MemStorage) speciesByTitle(title string) (models.Specie, bool) {
+	for _, sp := range st.species {
+		if sp.Title == title {
+			return sp, true
+		}
+	}
+	return models.Specie{}, false
+}
+
+// speciesIDs вызывается под блокировкой
+func (st *MemStorage) speciesIDs(titles []string) ([]int64, error) {
+	ids := make([]int64, 0, len(titles))
+	for _, title := range titles {
+		sp, ok := st.speciesByTitle(title)
+		if !ok {
+			return nil, errs.UnknownSpecies(title)
+		}
+		if !slices.Contains(ids, sp.IdSp) {
+			ids = append(ids, sp.IdSp)
+		}
+	}
+	return ids, nil
+}
+
+// animal вызывается под блокировкой
+func (st *MemStorage) animal(an models.AnimalSmall) models.Animal {
+	sp := st.species[an.IdSp]
+	return models.Animal{
+		IdAnim:         an.IdAnim,
+		NameAn:         an.NameAn,
+		BirthDate:      an.BirthDate,
+		BirthPrecision: an.BirthPrecision,
+		Gender:         an.Gender,
+		Title:          sp.Title,
+		Descrip:        sp.Descrip,
+		IdEncl:         an.IdEncl,
+	}
+}
+
+// enclosure вызывается под блокировкой
+func (st *MemStorage) enclosure(enc memEnclosure) models.Enclosure {
+	res := enc.Enclosure
+	res.Species = make([]string, 0, len(enc.species))
+	for _, idSp := range enc.species {
+		res.Species = append(res.Species, st.species[idSp].Title)
+	}
+	sort.Strings(res.Species)
+	res.Occupancy = st.occupancy(enc.IdEncl, 0)
+	return res
+}
+
+// occupancy - число животных в вольере, кроме except; вызывается под блокировкой
+func (st *MemStorage) occupancy(idEncl, except int64) int {
+	n := 0
+	for _, an := range st.animals {
+		if an.IdEncl != nil && *an.IdEncl == idEncl && an.IdAnim != except {
+			n++
+		}
+	}
+	return n
+}
+
+type MemAnimalRepository struct {
+	st *MemStorage
+}
+
+func NewMemAnimalRepository(st *MemStorage) *MemAnimalRepository {
+	return &MemAnimalRepository{st: st}
+}
+
+func (r *MemAnimalRepository) Delete(ctx context.Context, idAnim int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[idAnim]; !ok {
+		return ErrNotFound
+	}
+	for _, p := range r.st.parents {
+		if p.mother.IdAnim != nil && *p.mother.IdAnim == idAnim || p.father.IdAnim != nil && *p.father.IdAnim == idAnim {
+			return ErrAnimalHasOffspring
+		}
+	}
+	delete(r.st.animals, idAnim)
+	delete(r.st.parents, idAnim)
+	// как ON DELETE CASCADE у Feeding_plans, Feedings, медицинской истории, замеров, настроений,
+	// наблюдений и Keeper_assignments
+	maps.DeleteFunc(r.st.plans, func(_ int64, plan memPlan) bool { return plan.IdAnim != nil && *plan.IdAnim == idAnim })
+	maps.DeleteFunc(r.st.feedings, func(_ int64, feed models.Feeding) bool { return feed.IdAnim == idAnim })
+	maps.DeleteFunc(r.st.records, func(_ int64, rec models.MedicalRecord) bool { return rec.IdAnim == idAnim })
+	maps.DeleteFunc(r.st.meds, func(_ int64, med models.Medication) bool { return med.IdAnim == idAnim })
+	maps.DeleteFunc(r.st.vaccs, func(_ int64, vac models.Vaccination) bool { return vac.IdAnim == idAnim })
+	maps.DeleteFunc(r.st.measures, func(_ int64, m models.Measurement) bool { return m.IdAnim == idAnim })
+	maps.DeleteFunc(r.st.moods, func(_ int64, m models.MoodEntry) bool { return m.IdAnim == idAnim })
+	maps.DeleteFunc(r.st.obs, func(_ int64, o models.Observation) bool { return o.IdAnim == idAnim })
+	maps.DeleteFunc(r.st.assigns, func(_ int64, a models.KeeperAssignment) bool { return a.IdAnim != nil && *a.IdAnim == idAnim })
+	return nil
+}
+
+func (r *MemAnimalRepository) Add(ctx context.Context, individual *models.Animal) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	sp, ok := r.st.speciesByTitle(individual.Title)
+	if !ok {
+		return -1, errs.UnknownSpecies(individual.Title)
+	}
+	r.st.lastAnId++
+	r.st.animals[r.st.lastAnId] = models.AnimalSmall{
+		IdAnim:         r.st.lastAnId,
+		NameAn:         individual.NameAn,
+		BirthDate:      individual.BirthDate,
+		BirthPrecision: individual.BirthPrecision,
+		Gender:         individual.Gender,
+		IdSp:           sp.IdSp,
+	}
+	return r.st.lastAnId, nil
+}
+
+func (r *MemAnimalRepository) Get(ctx context.Context, idAnim int64) (*models.Animal, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	an, ok := r.st.animals[idAnim]
+	if !ok {
+		return &models.Animal{}, ErrNotFound
+	}
+	animal := r.st.animal(an)
+	return &animal, nil
+}
+
+func (r *MemAnimalRepository) GetAll(ctx context.Context, q AnimalQuery) ([]models.Animal, error) {
+	less, err := animalLess(q.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	r.st.mux.RLock()
+	animals := make([]models.Animal, 0)
+	for _, an := range r.st.animals {
+		animal := r.st.animal(an)
+		if !matchAnimal(&animal, &q.Filter) {
+			continue
+		}
+		if q.After != nil && !less(animalCursor(q.After), &animal) {
+			continue
+		}
+		animals = append(animals, animal)
+	}
+	r.st.mux.RUnlock()
+
+	sort.Slice(animals, func(i, j int) bool { return less(&animals[i], &animals[j]) })
+	if len(animals) > q.Limit {
+		animals = animals[:q.Limit]
+	}
+	return animals, nil
+}
+
+func (r *MemAnimalRepository) Update(ctx context.Context, individual *models.Animal) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	sp, ok := r.st.speciesByTitle(individual.Title)
+	if !ok {
+		return errs.UnknownSpecies(individual.Title)
+	}
+	old, ok := r.st.animals[individual.IdAnim]
+	if !ok {
+		return ErrNotFound
+	}
+	r.st.animals[individual.IdAnim] = models.AnimalSmall{
+		IdAnim:         individual.IdAnim,
+		NameAn:         individual.NameAn,
+		BirthDate:      individual.BirthDate,
+		BirthPrecision: individual.BirthPrecision,
+		Gender:         individual.Gender,
+		IdSp:           sp.IdSp,
+		IdEncl:         old.IdEncl,
+	}
+	return nil
+}
+
+func (r *MemAnimalRepository) SetEnclosure(ctx context.Context, idAnim int64, idEncl *int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	an, ok := r.st.animals[idAnim]
+	if !ok {
+		return ErrNotFound
+	}
+	if idEncl != nil {
+		enc, ok := r.st.enclosures[*idEncl]
+		if !ok {
+			return ErrEnclosureNotFound
+		}
+		if r.st.occupancy(*idEncl, idAnim) >= enc.Capacity {
+			return ErrEnclosureFull
+		}
+		id := *idEncl
+		idEncl = &id
+	}
+	an.IdEncl = idEncl
+	r.st.animals[idAnim] = an
+	return nil
+}
+
+func matchAnimal(a *models.Animal, f *models.AnimalFilter) bool {
+	switch {
+	case f.Species != "" && a.Title != f.Species,
+		f.Gender != "" && a.Gender != f.Gender,
+		f.NamePrefix != "" && !strings.HasPrefix(a.NameAn, f.NamePrefix),
+		f.BornAfter != nil && !a.BirthDate.After(*f.BornAfter),
+		f.BornBefore != nil && !a.BirthDate.Before(*f.BornBefore),
+		f.Enclosure != nil && (a.IdEncl == nil || *a.IdEncl != *f.Enclosure):
+		return false
+	}
+	return true
+}
+
+func animalCursor(c *AnimalCursor) *models.Animal {
+	return &models.Animal{IdAnim: c.ID, NameAn: c.Name, BirthDate: c.BirthDate}
+}
+
+// animalLess - тот же порядок, что ORDER BY <поле>, id_anim в Pg-реализации
+func animalLess(o models.AnimalOrder) (func(a, b *models.Animal) bool, error) {
+	var byField func(a, b *models.Animal) int
+	switch o.Field {
+	case models.SortByID, "":
+		byField = func(a, b *models.Animal) int { return 0 }
+	case models.SortByName:
+		byField = func(a, b *models.Animal) int { return strings.Compare(a.NameAn, b.NameAn) }
+	case models.SortByAge:
+		byField = func(a, b *models.Animal) int { return a.BirthDate.Compare(b.BirthDate) }
+	default:
+		return nil, fmt.Errorf("unknown sort field %q", o.Field)
+	}
+	// возраст по возрастанию - дата рождения по убыванию, как и в AnimalQuery.sql
+	desc := o.Desc != (o.Field == models.SortByAge)
+	return func(a, b *models.Animal) bool {
+		c := byField(a, b)
+		if c == 0 {
+			c = cmp.Compare(a.IdAnim, b.IdAnim)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	}, nil
+}
+
+type MemSpeciesRepository struct {
+	st *MemStorage
+}
+
+func NewMemSpeciesRepository(st *MemStorage) *MemSpeciesRepository {
+	return &MemSpeciesRepository{st: st}
+}
+
+func (r *MemSpeciesRepository) List(ctx context.Context) ([]models.Specie, error) {
+	r.st.mux.RLock()
+	species := make([]models.Specie, 0, len(r.st.species))
+	for _, sp := range r.st.species {
+		species = append(species, sp)
+	}
+	r.st.mux.RUnlock()
+	sort.Slice(species, func(i, j int) bool { return species[i].Title < species[j].Title })
+	return species, nil
+}
+
+func (r *MemSpeciesRepository) Get(ctx context.Context, idSp int64) (*models.Specie, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	sp, ok := r.st.species[idSp]
+	if !ok {
+		return nil, ErrSpeciesNotFound
+	}
+	return &sp, nil
+}
+
+func (r *MemSpeciesRepository) Add(ctx context.Context, sp *models.Specie) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.speciesByTitle(sp.Title); ok {
+		return -1, ErrSpeciesExists
+	}
+	r.st.lastSpId++
+	r.st.species[r.st.lastSpId] = models.Specie{IdSp: r.st.lastSpId, Title: sp.Title, Descrip: sp.Descrip}
+	return r.st.lastSpId, nil
+}
+
+func (r *MemSpeciesRepository) Update(ctx context.Context, sp *models.Specie) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.species[sp.IdSp]; !ok {
+		return ErrSpeciesNotFound
+	}
+	if other, ok := r.st.speciesByTitle(sp.Title); ok && other.IdSp != sp.IdSp {
+		return ErrSpeciesExists
+	}
+	r.st.species[sp.IdSp] = *sp
+	return nil
+}
+
+func (r *MemSpeciesRepository) Delete(ctx context.Context, idSp int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.species[idSp]; !ok {
+		return ErrSpeciesNotFound
+	}
+	for _, an := range r.st.animals {
+		if an.IdSp == idSp {
+			return ErrSpeciesInUse
+		}
+	}
+	delete(r.st.species, idSp)
+	// как ON DELETE CASCADE у Enclosure_species, Keeper_species и Feeding_plans
+	for id, enc := range r.st.enclosures {
+		enc.species = slices.DeleteFunc(enc.species, func(v int64) bool { return v == idSp })
+		r.st.enclosures[id] = enc
+	}
+	for id, k := range r.st.keepers {
+		k.species = slices.DeleteFunc(k.species, func(v int64) bool { return v == idSp })
+		r.st.keepers[id] = k
+	}
+	for id, plan := range r.st.plans {
+		if plan.idSp == idSp {
+			delete(r.st.plans, id)
+		}
+	}
+	return nil
+}
+
+type MemEnclosureRepository struct {
+	st *MemStorage
+}
+
+func NewMemEnclosureRepository(st *MemStorage) *MemEnclosureRepository {
+	return &MemEnclosureRepository{st: st}
+}
+
+func (r *MemEnclosureRepository) List(ctx context.Context) ([]models.Enclosure, error) {
+	r.st.mux.RLock()
+	enclosures := make([]models.Enclosure, 0, len(r.st.enclosures))
+	for _, enc := range r.st.enclosures {
+		enclosures = append(enclosures, r.st.enclosure(enc))
+	}
+	r.st.mux.RUnlock()
+	sort.Slice(enclosures, func(i, j int) bool { return enclosures[i].Name < enclosures[j].Name })
+	return enclosures, nil
+}
+
+func (r *MemEnclosureRepository) Get(ctx context.Context, idEncl int64) (*models.Enclosure, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	enc, ok := r.st.enclosures[idEncl]
+	if !ok {
+		return nil, ErrEnclosureNotFound
+	}
+	res := r.st.enclosure(enc)
+	return &res, nil
+}
+
+func (r *MemEnclosureRepository) Add(ctx context.Context, enc *models.Enclosure) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if r.nameTaken(enc.Name, 0) {
+		return -1, ErrEnclosureExists
+	}
+	species, err := r.st.speciesIDs(enc.Species)
+	if err != nil {
+		return -1, err
+	}
+	r.st.lastEncId++
+	r.st.enclosures[r.st.lastEncId] = r.memEnclosure(r.st.lastEncId, enc, species)
+	return r.st.lastEncId, nil
+}
+
+func (r *MemEnclosureRepository) Update(ctx context.Context, enc *models.Enclosure) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.enclosures[enc.IdEncl]; !ok {
+		return ErrEnclosureNotFound
+	}
+	if occupied := r.st.occupancy(enc.IdEncl, 0); occupied > enc.Capacity {
+		return errs.Conflict("enclosure houses %d animals, capacity %d is too small", occupied, enc.Capacity)
+	}
+	for _, an := range r.st.animals {
+		title := r.st.species[an.IdSp].Title
+		if an.IdEncl != nil && *an.IdEncl == enc.IdEncl && !slices.Contains(enc.Species, title) {
+			return errs.Conflict("enclosure houses %s %s, species must stay allowed", title, an.NameAn)
+		}
+	}
+	if r.nameTaken(enc.Name, enc.IdEncl) {
+		return ErrEnclosureExists
+	}
+	species, err := r.st.speciesIDs(enc.Species)
+	if err != nil {
+		return err
+	}
+	r.st.enclosures[enc.IdEncl] = r.memEnclosure(enc.IdEncl, enc, species)
+	return nil
+}
+
+func (r *MemEnclosureRepository) Delete(ctx context.Context, idEncl int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.enclosures[idEncl]; !ok {
+		return ErrEnclosureNotFound
+	}
+	if r.st.occupancy(idEncl, 0) > 0 {
+		return ErrEnclosureInUse
+	}
+	delete(r.st.enclosures, idEncl)
+	// как ON DELETE CASCADE у Keeper_assignments
+	maps.DeleteFunc(r.st.assigns, func(_ int64, a models.KeeperAssignment) bool { return a.IdEncl != nil && *a.IdEncl == idEncl })
+	// как ON DELETE SET NULL у Moods
+	for id, m := range r.st.moods {
+		if m.IdEncl != nil && *m.IdEncl == idEncl {
+			m.IdEncl = nil
+			r.st.moods[id] = m
+		}
+	}
+	return nil
+}
+
+func (r *MemEnclosureRepository) memEnclosure(id int64, enc *models.Enclosure, species []int64) memEnclosure {
+	return memEnclosure{
+		Enclosure: models.Enclosure{
+			IdEncl:   id,
+			Name:     enc.Name,
+			Zone:     enc.Zone,
+			Capacity: enc.Capacity,
+			Habitat:  enc.Habitat,
+		},
+		species: species,
+	}
+}
+
+// nameTaken вызывается под блокировкой
+func (r *MemEnclosureRepository) nameTaken(name string, except int64) bool {
+	for _, enc := range r.st.enclosures {
+		if enc.Name == name && enc.IdEncl != except {
+			return true
+		}
+	}
+	return false
+}
+
+type MemFeedingRepository struct {
+	st *MemStorage
+}
+
+func NewMemFeedingRepository(st *MemStorage) *MemFeedingRepository {
+	return &MemFeedingRepository{st: st}
+}
+
+// plan вызывается под блокировкой
+func (st *MemStorage) plan(p memPlan) models.FeedingPlan {
+	res := p.FeedingPlan
+	res.Species = st.species[p.idSp].Title
+	res.Times = slices.Clone(p.Times)
+	return res
+}
+
+func (r *MemFeedingRepository) ListPlans(ctx context.Context, f models.FeedingPlanFilter) ([]models.FeedingPlan, error) {
+	r.st.mux.RLock()
+	plans := make([]models.FeedingPlan, 0)
+	for _, p := range r.st.plans {
+		plan := r.st.plan(p)
+		if f.Species != "" && plan.Species != f.Species {
+			continue
+		}
+		if f.IdAnim != nil && (plan.IdAnim == nil || *plan.IdAnim != *f.IdAnim) {
+			continue
+		}
+		plans = append(plans, plan)
+	}
+	r.st.mux.RUnlock()
+	sort.Slice(plans, func(i, j int) bool { return plans[i].IdPlan < plans[j].IdPlan })
+	return plans, nil
+}
+
+func (r *MemFeedingRepository) GetPlan(ctx context.Context, idPlan int64) (*models.FeedingPlan, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	p, ok := r.st.plans[idPlan]
+	if !ok {
+		return nil, ErrFeedingPlanNotFound
+	}
+	plan := r.st.plan(p)
+	return &plan, nil
+}
+
+// memPlan проверяет ссылки плана, вызывается под блокировкой
+func (r *MemFeedingRepository) memPlan(id int64, plan *models.FeedingPlan) (memPlan, error) {
+	res := memPlan{FeedingPlan: *plan}
+	res.IdPlan = id
+	res.Species = ""
+	res.Times = slices.Clone(plan.Times)
+	if plan.Species != "" {
+		sp, ok := r.st.speciesByTitle(plan.Species)
+		if !ok {
+			return res, errs.UnknownSpecies(plan.Species)
+		}
+		res.idSp = sp.IdSp
+	}
+	if plan.IdAnim != nil {
+		if _, ok := r.st.animals[*plan.IdAnim]; !ok {
+			return res, ErrNotFound
+		}
+		idAnim := *plan.IdAnim
+		res.IdAnim = &idAnim
+	}
+	return res, nil
+}
+
+func (r *MemFeedingRepository) AddPlan(ctx context.Context, plan *models.FeedingPlan) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	p, err := r.memPlan(r.st.lastPlanId+1, plan)
+	if err != nil {
+		return -1, err
+	}
+	r.st.lastPlanId++
+	r.st.plans[r.st.lastPlanId] = p
+	return r.st.lastPlanId, nil
+}
+
+func (r *MemFeedingRepository) UpdatePlan(ctx context.Context, plan *models.FeedingPlan) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.plans[plan.IdPlan]; !ok {
+		return ErrFeedingPlanNotFound
+	}
+	p, err := r.memPlan(plan.IdPlan, plan)
+	if err != nil {
+		return err
+	}
+	r.st.plans[plan.IdPlan] = p
+	return nil
+}
+
+func (r *MemFeedingRepository) DeletePlan(ctx context.Context, idPlan int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.plans[idPlan]; !ok {
+		return ErrFeedingPlanNotFound
+	}
+	delete(r.st.plans, idPlan)
+	// как ON DELETE SET NULL у Feedings.id_plan
+	for id, feed := range r.st.feedings {
+		if feed.IdPlan != nil && *feed.IdPlan == idPlan {
+			feed.IdPlan = nil
+			r.st.feedings[id] = feed
+		}
+	}
+	return nil
+}
+
+func (r *MemFeedingRepository) AddFeeding(ctx context.Context, feed *models.Feeding) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[feed.IdAnim]; !ok {
+		return -1, ErrNotFound
+	}
+	if feed.IdPlan != nil {
+		if _, ok := r.st.plans[*feed.IdPlan]; !ok {
+			return -1, ErrFeedingPlanNotFound
+		}
+	}
+	r.st.lastFeedId++
+	res := *feed
+	res.IdFeed = r.st.lastFeedId
+	r.st.feedings[res.IdFeed] = res
+	return res.IdFeed, nil
+}
+
+func (r *MemFeedingRepository) ListFeedings(ctx context.Context, f models.FeedingFilter) ([]models.Feeding, error) {
+	r.st.mux.RLock()
+	feeds := make([]models.Feeding, 0)
+	for _, feed := range r.st.feedings {
+		if feed.IdAnim != f.IdAnim ||
+			!f.From.IsZero() && feed.FedAt.Before(f.From) ||
+			!f.To.IsZero() && !feed.FedAt.Before(f.To) {
+			continue
+		}
+		feeds = append(feeds, feed)
+	}
+	r.st.mux.RUnlock()
+	sortFeedings(feeds)
+	if len(feeds) > f.Limit {
+		feeds = feeds[:f.Limit]
+	}
+	return feeds, nil
+}
+
+func (r *MemFeedingRepository) LatestFeedings(ctx context.Context) ([]models.Feeding, error) {
+	type key struct {
+		idAnim, idPlan int64
+		food           string
+	}
+	r.st.mux.RLock()
+	latest := make(map[key]models.Feeding)
+	for _, feed := range r.st.feedings {
+		k := key{idAnim: feed.IdAnim, food: feed.Food}
+		if feed.IdPlan != nil {
+			k.idPlan = *feed.IdPlan
+		}
+		if cur, ok := latest[k]; !ok || feed.FedAt.After(cur.FedAt) {
+			latest[k] = feed
+		}
+	}
+	r.st.mux.RUnlock()
+	feeds := make([]models.Feeding, 0, len(latest))
+	for _, feed := range latest {
+		feeds = append(feeds, feed)
+	}
+	sortFeedings(feeds)
+	return feeds, nil
+}
+
+// sortFeedings - тот же порядок, что ORDER BY fed_at DESC, id_feed DESC
+func sortFeedings(feeds []models.Feeding) {
+	sort.Slice(feeds, func(i, j int) bool {
+		if !feeds[i].FedAt.Equal(feeds[j].FedAt) {
+			return feeds[i].FedAt.After(feeds[j].FedAt)
+		}
+		return feeds[i].IdFeed > feeds[j].IdFeed
+	})
+}
+
+type MemMedicalRepository struct {
+	st *MemStorage
+}
+
+func NewMemMedicalRepository(st *MemStorage) *MemMedicalRepository {
+	return &MemMedicalRepository{st: st}
+}
+
+func (r *MemMedicalRepository) AddRecord(ctx context.Context, rec *models.MedicalRecord) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[rec.IdAnim]; !ok {
+		return -1, ErrNotFound
+	}
+	r.st.lastRecId++
+	res := *rec
+	res.IdRec = r.st.lastRecId
+	r.st.records[res.IdRec] = res
+	return res.IdRec, nil
+}
+
+func (r *MemMedicalRepository) GetRecord(ctx context.Context, idRec int64) (*models.MedicalRecord, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	rec, ok := r.st.records[idRec]
+	if !ok {
+		return nil, ErrMedicalRecordNotFound
+	}
+	return &rec, nil
+}
+
+func (r *MemMedicalRepository) AddMedication(ctx context.Context, med *models.Medication) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[med.IdAnim]; !ok {
+		return -1, ErrNotFound
+	}
+	if med.IdRec != nil {
+		if _, ok := r.st.records[*med.IdRec]; !ok {
+			return -1, ErrMedicalRecordNotFound
+		}
+	}
+	r.st.lastMedId++
+	res := *med
+	res.IdMed = r.st.lastMedId
+	r.st.meds[res.IdMed] = res
+	return res.IdMed, nil
+}
+
+func (r *MemMedicalRepository) AddVaccination(ctx context.Context, vac *models.Vaccination) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[vac.IdAnim]; !ok {
+		return -1, ErrNotFound
+	}
+	r.st.lastVacId++
+	res := *vac
+	res.IdVac = r.st.lastVacId
+	r.st.vaccs[res.IdVac] = res
+	return res.IdVac, nil
+}
+
+// inPeriod - t попадает в [From, To) фильтра
+func inPeriod(f models.MedicalFilter, t time.Time) bool {
+	return (f.From.IsZero() || !t.Before(f.From)) && (f.To.IsZero() || t.Before(f.To))
+}
+
+// newestFirst - тот же порядок, что ORDER BY <время> DESC
+func newestFirst[T any](items []T, at func(T) time.Time) {
+	slices.SortStableFunc(items, func(a, b T) int { return at(b).Compare(at(a)) })
+}
+
+func (r *MemMedicalRepository) History(ctx context.Context, f models.MedicalFilter) (*models.MedicalHistory, error) {
+	h := models.MedicalHistory{
+		Records:      make([]models.MedicalRecord, 0),
+		Medications:  make([]models.Medication, 0),
+		Vaccinations: make([]models.Vaccination, 0),
+	}
+	r.st.mux.RLock()
+	for _, rec := range r.st.records {
+		if rec.IdAnim == f.IdAnim && inPeriod(f, rec.RecordedAt) {
+			h.Records = append(h.Records, rec)
+		}
+	}
+	for _, med := range r.st.meds {
+		if med.IdAnim == f.IdAnim && inPeriod(f, med.StartedAt) {
+			h.Medications = append(h.Medications, med)
+		}
+	}
+	for _, vac := range r.st.vaccs {
+		if vac.IdAnim == f.IdAnim && inPeriod(f, vac.GivenAt) {
+			h.Vaccinations = append(h.Vaccinations, vac)
+		}
+	}
+	r.st.mux.RUnlock()
+	// map обходится в случайном порядке, id задают порядок при равном времени
+	slices.SortFunc(h.Records, func(a, b models.MedicalRecord) int { return cmp.Compare(a.IdRec, b.IdRec) })
+	slices.SortFunc(h.Medications, func(a, b models.Medication) int { return cmp.Compare(a.IdMed, b.IdMed) })
+	slices.SortFunc(h.Vaccinations, func(a, b models.Vaccination) int { return cmp.Compare(a.IdVac, b.IdVac) })
+	newestFirst(h.Records, func(rec models.MedicalRecord) time.Time { return rec.RecordedAt })
+	newestFirst(h.Medications, func(med models.Medication) time.Time { return med.StartedAt })
+	newestFirst(h.Vaccinations, func(vac models.Vaccination) time.Time { return vac.GivenAt })
+	return &h, nil
+}
+
+func (r *MemMedicalRepository) DueVaccinations(ctx context.Context, until time.Time) ([]models.DueVaccination, error) {
+	type key struct {
+		idAnim  int64
+		vaccine string
+	}
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	latest := make(map[key]models.Vaccination)
+	for _, vac := range r.st.vaccs {
+		k := key{vac.IdAnim, vac.Vaccine}
+		cur, ok := latest[k]
+		if !ok || vac.GivenAt.After(cur.GivenAt) || vac.GivenAt.Equal(cur.GivenAt) && vac.IdVac > cur.IdVac {
+			latest[k] = vac
+		}
+	}
+	due := make([]models.DueVaccination, 0)
+	for _, vac := range latest {
+		if vac.DueAt == nil || vac.DueAt.After(until) {
+			continue
+		}
+		an := r.st.animals[vac.IdAnim]
+		due = append(due, models.DueVaccination{Vaccination: vac, NameAn: an.NameAn, Title: r.st.species[an.IdSp].Title})
+	}
+	slices.SortFunc(due, func(a, b models.DueVaccination) int {
+		return cmp.Or(a.DueAt.Compare(*b.DueAt), cmp.Compare(a.IdVac, b.IdVac))
+	})
+	return due, nil
+}
+
+type MemMeasurementRepository struct {
+	st *MemStorage
+}
+
+func NewMemMeasurementRepository(st *MemStorage) *MemMeasurementRepository {
+	return &MemMeasurementRepository{st: st}
+}
+
+func (r *MemMeasurementRepository) Add(ctx context.Context, m *models.Measurement) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[m.IdAnim]; !ok {
+		return -1, ErrNotFound
+	}
+	r.st.lastMeasId++
+	res := *m
+	res.IdMeas = r.st.lastMeasId
+	r.st.measures[res.IdMeas] = res
+	return res.IdMeas, nil
+}
+
+func (r *MemMeasurementRepository) Delete(ctx context.Context, idMeas int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.measures[idMeas]; !ok {
+		return ErrMeasurementNotFound
+	}
+	delete(r.st.measures, idMeas)
+	return nil
+}
+
+func (r *MemMeasurementRepository) List(ctx context.Context, f models.MeasurementFilter) ([]models.Measurement, error) {
+	return r.filter(func(m models.Measurement) bool {
+		return m.IdAnim == f.IdAnim &&
+			(f.From.IsZero() || !m.MeasuredAt.Before(f.From)) &&
+			(f.To.IsZero() || m.MeasuredAt.Before(f.To))
+	}), nil
+}
+
+func (r *MemMeasurementRepository) Latest(ctx context.Context, idAnim int64) (*models.Measurement, error) {
+	ms := r.filter(func(m models.Measurement) bool { return m.IdAnim == idAnim })
+	if len(ms) == 0 {
+		return nil, nil
+	}
+	return &ms[len(ms)-1], nil
+}
+
+func (r *MemMeasurementRepository) Since(ctx context.Context, from time.Time) ([]models.Measurement, error) {
+	return r.filter(func(m models.Measurement) bool { return !m.MeasuredAt.Before(from) }), nil
+}
+
+// filter - тот же порядок, что ORDER BY id_anim, measured_at, id_meas
+func (r *MemMeasurementRepository) filter(match func(models.Measurement) bool) []models.Measurement {
+	r.st.mux.RLock()
+	res := make([]models.Measurement, 0)
+	for _, m := range r.st.measures {
+		if match(m) {
+			res = append(res, m)
+		}
+	}
+	r.st.mux.RUnlock()
+	slices.SortFunc(res, func(a, b models.Measurement) int {
+		return cmp.Or(cmp.Compare(a.IdAnim, b.IdAnim), a.MeasuredAt.Compare(b.MeasuredAt), cmp.Compare(a.IdMeas, b.IdMeas))
+	})
+	return res
+}
+
+type MemLineageRepository struct {
+	st *MemStorage
+}
+
+func NewMemLineageRepository(st *MemStorage) *MemLineageRepository {
+	return &MemLineageRepository{st: st}
+}
+
+func (r *MemLineageRepository) SetParents(ctx context.Context, idAnim int64, mother, father models.Parent) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[idAnim]; !ok {
+		return ErrNotFound
+	}
+	for _, p := range []*models.Parent{&mother, &father} {
+		if p.IdAnim == nil {
+			continue
+		}
+		if _, ok := r.st.animals[*p.IdAnim]; !ok {
+			return ErrParentNotFound
+		}
+		id := *p.IdAnim
+		p.IdAnim = &id
+	}
+	r.st.parents[idAnim] = memParents{mother: mother, father: father}
+	return nil
+}
+
+// kin вызывается под блокировкой
+func (st *MemStorage) kin(an models.AnimalSmall) models.Kin {
+	p := st.parents[an.IdAnim]
+	return models.Kin{
+		IdAnim:    an.IdAnim,
+		NameAn:    an.NameAn,
+		Gender:    an.Gender,
+		Title:     st.species[an.IdSp].Title,
+		BirthDate: an.BirthDate,
+		Mother:    p.mother,
+		Father:    p.father,
+	}
+}
+
+func (r *MemLineageRepository) Kin(ctx context.Context, ids []int64) ([]models.Kin, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	res := make([]models.Kin, 0, len(ids))
+	for _, id := range ids {
+		if an, ok := r.st.animals[id]; ok {
+			res = append(res, r.st.kin(an))
+		}
+	}
+	slices.SortFunc(res, func(a, b models.Kin) int { return cmp.Compare(a.IdAnim, b.IdAnim) })
+	return slices.CompactFunc(res, func(a, b models.Kin) bool { return a.IdAnim == b.IdAnim }), nil
+}
+
+func (r *MemLineageRepository) Children(ctx context.Context, ids []int64) ([]models.Kin, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	isParent := func(p models.Parent) bool { return p.IdAnim != nil && slices.Contains(ids, *p.IdAnim) }
+	res := make([]models.Kin, 0)
+	for id, p := range r.st.parents {
+		if isParent(p.mother) || isParent(p.father) {
+			res = append(res, r.st.kin(r.st.animals[id]))
+		}
+	}
+	slices.SortFunc(res, func(a, b models.Kin) int { return cmp.Compare(a.IdAnim, b.IdAnim) })
+	return res, nil
+}
+
+type MemMoodRepository struct {
+	st *MemStorage
+}
+
+func NewMemMoodRepository(st *MemStorage) *MemMoodRepository {
+	return &MemMoodRepository{st: st}
+}
+
+func (r *MemMoodRepository) Add(ctx context.Context, m *models.MoodEntry) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[m.IdAnim]; !ok {
+		return -1, ErrNotFound
+	}
+	if m.IdEncl != nil {
+		if _, ok := r.st.enclosures[*m.IdEncl]; !ok {
+			return -1, ErrEnclosureNotFound
+		}
+	}
+	r.st.lastMoodId++
+	res := *m
+	res.IdMood = r.st.lastMoodId
+	if m.IdEncl != nil {
+		id := *m.IdEncl
+		res.IdEncl = &id
+	}
+	r.st.moods[res.IdMood] = res
+	return res.IdMood, nil
+}
+
+func matchMood(m models.MoodEntry, source models.MoodSource, from, to time.Time) bool {
+	return (source == "" || m.Source == source) &&
+		(from.IsZero() || !m.ObservedAt.Before(from)) &&
+		(to.IsZero() || m.ObservedAt.Before(to))
+}
+
+func (r *MemMoodRepository) List(ctx context.Context, f models.MoodFilter) ([]models.MoodEntry, error) {
+	r.st.mux.RLock()
+	res := make([]models.MoodEntry, 0)
+	for _, m := range r.st.moods {
+		if m.IdAnim == f.IdAnim && matchMood(m, f.Source, f.From, f.To) {
+			res = append(res, m)
+		}
+	}
+	r.st.mux.RUnlock()
+	slices.SortFunc(res, func(a, b models.MoodEntry) int {
+		return cmp.Or(b.ObservedAt.Compare(a.ObservedAt), cmp.Compare(b.IdMood, a.IdMood))
+	})
+	if f.Limit > 0 && len(res) > f.Limit {
+		res = res[:f.Limit]
+	}
+	return res, nil
+}
+
+func (r *MemMoodRepository) Distribution(ctx context.Context, f models.MoodDistributionFilter) ([]models.MoodCount, error) {
+	if f.GroupBy != models.MoodByEnclosure && f.GroupBy != models.MoodBySpecies {
+		return nil, fmt.Errorf("unknown mood grouping %q", f.GroupBy)
+	}
+	r.st.mux.RLock()
+	type key struct {
+		group string
+		mood  models.Mood
+	}
+	counts := make(map[key]int)
+	for _, m := range r.st.moods {
+		if !matchMood(m, f.Source, f.From, f.To) {
+			continue
+		}
+		group := r.st.species[r.st.animals[m.IdAnim].IdSp].Title
+		if f.GroupBy == models.MoodByEnclosure {
+			if m.IdEncl == nil {
+				continue
+			}
+			group = r.st.enclosures[*m.IdEncl].Name
+		}
+		counts[key{group, m.Mood}]++
+	}
+	r.st.mux.RUnlock()
+	res := make([]models.MoodCount, 0, len(counts))
+	for k, n := range counts {
+		res = append(res, models.MoodCount{Group: k.group, Mood: k.mood, Count: n})
+	}
+	slices.SortFunc(res, func(a, b models.MoodCount) int {
+		return cmp.Or(cmp.Compare(a.Group, b.Group), cmp.Compare(a.Mood, b.Mood))
+	})
+	return res, nil
+}
+
+type MemObservationRepository struct {
+	st *MemStorage
+}
+
+func NewMemObservationRepository(st *MemStorage) *MemObservationRepository {
+	return &MemObservationRepository{st: st}
+}
+
+func (r *MemObservationRepository) Add(ctx context.Context, o *models.Observation) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.animals[o.IdAnim]; !ok {
+		return -1, ErrNotFound
+	}
+	r.st.lastObsId++
+	res := *o
+	res.IdObs = r.st.lastObsId
+	res.Tags = append([]string{}, o.Tags...)
+	r.st.obs[res.IdObs] = res
+	return res.IdObs, nil
+}
+
+func (r *MemObservationRepository) List(ctx context.Context, f models.ObservationFilter) ([]models.Observation, error) {
+	r.st.mux.RLock()
+	res := make([]models.Observation, 0)
+	for _, o := range r.st.obs {
+		if f.IdAnim != nil && o.IdAnim != *f.IdAnim ||
+			f.Keeper != "" && o.Keeper != f.Keeper ||
+			f.Tag != "" && !slices.Contains(o.Tags, f.Tag) ||
+			!f.From.IsZero() && o.ObservedAt.Before(f.From) ||
+			!f.To.IsZero() && !o.ObservedAt.Before(f.To) {
+			continue
+		}
+		o.Tags = slices.Clone(o.Tags)
+		res = append(res, o)
+	}
+	r.st.mux.RUnlock()
+	slices.SortFunc(res, func(a, b models.Observation) int {
+		return cmp.Or(b.ObservedAt.Compare(a.ObservedAt), cmp.Compare(b.IdObs, a.IdObs))
+	})
+	if f.Limit > 0 && len(res) > f.Limit {
+		res = res[:f.Limit]
+	}
+	return res, nil
+}
+
+type MemUserRepository struct {
+	st *MemStorage
+}
+
+func NewMemUserRepository(st *MemStorage) *MemUserRepository {
+	return &MemUserRepository{st: st}
+}
+
+// userByName вызывается под блокировкой
+func (st *MemStorage) userByName(name string) (models.User, bool) {
+	for _, u := range st.users {
+		if u.Name == name {
+			return u, true
+		}
+	}
+	return models.User{}, false
+}
+
+func (r *MemUserRepository) List(ctx context.Context) ([]models.User, error) {
+	r.st.mux.RLock()
+	users := make([]models.User, 0, len(r.st.users))
+	for _, u := range r.st.users {
+		users = append(users, u)
+	}
+	r.st.mux.RUnlock()
+	slices.SortFunc(users, func(a, b models.User) int { return cmp.Compare(a.Name, b.Name) })
+	return users, nil
+}
+
+func (r *MemUserRepository) Get(ctx context.Context, idUser int64) (*models.User, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	u, ok := r.st.users[idUser]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &u, nil
+}
+
+func (r *MemUserRepository) GetByName(ctx context.Context, name string) (*models.User, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	u, ok := r.st.userByName(name)
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &u, nil
+}
+
+func (r *MemUserRepository) Add(ctx context.Context, u *models.User) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.userByName(u.Name); ok {
+		return ErrUserExists
+	}
+	r.st.lastUserId++
+	u.IdUser, u.CreatedAt = r.st.lastUserId, time.Now()
+	r.st.users[u.IdUser] = *u
+	return nil
+}
+
+func (r *MemUserRepository) Update(ctx context.Context, u *models.User) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	old, ok := r.st.users[u.IdUser]
+	if !ok {
+		return ErrUserNotFound
+	}
+	if other, ok := r.st.userByName(u.Name); ok && other.IdUser != u.IdUser {
+		return ErrUserExists
+	}
+	old.Name, old.Role = u.Name, u.Role
+	r.st.users[u.IdUser] = old
+	return nil
+}
+
+func (r *MemUserRepository) Delete(ctx context.Context, idUser int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.users[idUser]; !ok {
+		return ErrUserNotFound
+	}
+	delete(r.st.users, idUser)
+	return nil
+}
+
+type MemKeeperRepository struct {
+	st *MemStorage
+}
+
+func NewMemKeeperRepository(st *MemStorage) *MemKeeperRepository {
+	return &MemKeeperRepository{st: st}
+}
+
+// keeper вызывается под блокировкой
+func (st *MemStorage) keeper(k memKeeper) models.Keeper {
+	res := k.Keeper
+	res.Species = make([]string, 0, len(k.species))
+	for _, idSp := range k.species {
+		res.Species = append(res.Species, st.species[idSp].Title)
+	}
+	sort.Strings(res.Species)
+	return res
+}
+
+// keeperNameTaken вызывается под блокировкой
+func (st *MemStorage) keeperNameTaken(name string, except int64) bool {
+	for _, k := range st.keepers {
+		if k.Name == name && k.IdKeeper != except {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemKeeperRepository) List(ctx context.Context) ([]models.Keeper, error) {
+	r.st.mux.RLock()
+	keepers := make([]models.Keeper, 0, len(r.st.keepers))
+	for _, k := range r.st.keepers {
+		keepers = append(keepers, r.st.keeper(k))
+	}
+	r.st.mux.RUnlock()
+	sort.Slice(keepers, func(i, j int) bool { return keepers[i].Name < keepers[j].Name })
+	return keepers, nil
+}
+
+func (r *MemKeeperRepository) Get(ctx context.Context, idKeeper int64) (*models.Keeper, error) {
+	r.st.mux.RLock()
+	defer r.st.mux.RUnlock()
+	k, ok := r.st.keepers[idKeeper]
+	if !ok {
+		return nil, ErrKeeperNotFound
+	}
+	res := r.st.keeper(k)
+	return &res, nil
+}
+
+func (r *MemKeeperRepository) Add(ctx context.Context, k *models.Keeper) (int64, error) {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if r.st.keeperNameTaken(k.Name, 0) {
+		return -1, ErrKeeperExists
+	}
+	species, err := r.st.speciesIDs(k.Species)
+	if err != nil {
+		return -1, err
+	}
+	r.st.lastKeepId++
+	r.st.keepers[r.st.lastKeepId] = memKeeper{
+		Keeper:  models.Keeper{IdKeeper: r.st.lastKeepId, Name: k.Name, Role: k.Role, Contact: k.Contact},
+		species: species,
+	}
+	return r.st.lastKeepId, nil
+}
+
+func (r *MemKeeperRepository) Update(ctx context.Context, k *models.Keeper) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.keepers[k.IdKeeper]; !ok {
+		return ErrKeeperNotFound
+	}
+	if r.st.keeperNameTaken(k.Name, k.IdKeeper) {
+		return ErrKeeperExists
+	}
+	species, err := r.st.speciesIDs(k.Species)
+	if err != nil {
+		return err
+	}
+	r.st.keepers[k.IdKeeper] = memKeeper{
+		Keeper:  models.Keeper{IdKeeper: k.IdKeeper, Name: k.Name, Role: k.Role, Contact: k.Contact},
+		species: species,
+	}
+	return nil
+}
+
+func (r *MemKeeperRepository) Delete(ctx context.Context, idKeeper int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.keepers[idKeeper]; !ok {
+		return ErrKeeperNotFound
+	}
+	delete(r.st.keepers, idKeeper)
+	// как ON DELETE CASCADE у Keeper_assignments
+	maps.DeleteFunc(r.st.assigns, func(_ int64, a models.KeeperAssignment) bool { return a.IdKeeper == idKeeper })
+	return nil
+}
+
+func (r *MemKeeperRepository) Assign(ctx context.Context, a *models.KeeperAssignment) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.keepers[a.IdKeeper]; !ok {
+		return ErrKeeperNotFound
+	}
+	if a.IdAnim != nil {
+		if _, ok := r.st.animals[*a.IdAnim]; !ok {
+			return ErrNotFound
+		}
+	}
+	if a.IdEncl != nil {
+		if _, ok := r.st.enclosures[*a.IdEncl]; !ok {
+			return ErrEnclosureNotFound
+		}
+	}
+	for _, other := range r.st.assigns {
+		if other.IdKeeper == a.IdKeeper && (sameID(other.IdAnim, a.IdAnim) || sameID(other.IdEncl, a.IdEncl)) {
+			return ErrAssignmentExists
+		}
+	}
+	r.st.lastAsgnId++
+	a.IdAssign, a.AssignedAt = r.st.lastAsgnId, time.Now()
+	res := *a
+	res.IdAnim, res.IdEncl = cloneID(a.IdAnim), cloneID(a.IdEncl)
+	r.st.assigns[a.IdAssign] = res
+	return nil
+}
+
+func (r *MemKeeperRepository) Unassign(ctx context.Context, idAssign int64) error {
+	r.st.mux.Lock()
+	defer r.st.mux.Unlock()
+	if _, ok := r.st.assigns[idAssign]; !ok {
+		return ErrAssignmentNotFound
+	}
+	delete(r.st.assigns, idAssign)
+	return nil
+}
+
+func (r *MemKeeperRepository) Assignments(ctx context.Context, f models.AssignmentFilter) ([]models.KeeperAssignment, error) {
+	r.st.mux.RLock()
+	res := make([]models.KeeperAssignment, 0)
+	for _, a := range r.st.assigns {
+		if f.IdKeeper != nil && a.IdKeeper != *f.IdKeeper ||
+			f.IdAnim != nil && !sameID(a.IdAnim, f.IdAnim) ||
+			f.IdEncl != nil && !sameID(a.IdEncl, f.IdEncl) {
+			continue
+		}
+		a.IdAnim, a.IdEncl = cloneID(a.IdAnim), cloneID(a.IdEncl)
+		res = append(res, a)
+	}
+	r.st.mux.RUnlock()
+	slices.SortFunc(res, func(a, b models.KeeperAssignment) int { return cmp.Compare(a.IdAssign, b.IdAssign) })
+	return res, nil
+}
+
+// sameID - оба id заданы и равны; NULL в UNIQUE не совпадает ни с чем
+func sameID(a, b *int64) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+func cloneID(id *int64) *int64 {
+	if id == nil {
+		return nil
+	}
+	v := *id
+	return &v
+}
@@ -0,0 +1,190 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMemRepositories(t *testing.T) (*database.MemAnimalRepository, *database.MemSpeciesRepository) {
+	st := database.NewMemStorage()
+	an, sp := database.NewMemAnimalRepository(st), database.NewMemSpeciesRepository(st)
+	_, err := sp.Add(context.Background(), &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	return an, sp
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMemAnimalRepository(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newMemRepositories(t)
+
+	id, err := r.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: date(2011, 3, 14), BirthPrecision: "exact", Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+
+	got, err := r.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, &models.Animal{IdAnim: id, NameAn: "Klepa", BirthDate: date(2011, 3, 14), BirthPrecision: "exact", Gender: "f", Title: "cat", Descrip: "meow"}, got)
+
+	_, err = r.Add(ctx, &models.Animal{NameAn: "Rex", Title: "dog"})
+	assert.ErrorIs(t, err, errs.ErrUnknownSpecies)
+
+	require.NoError(t, r.Delete(ctx, id))
+	got, err = r.Get(ctx, id)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	assert.Equal(t, &models.Animal{}, got)
+	assert.ErrorIs(t, r.Delete(ctx, id), database.ErrNotFound)
+	assert.ErrorIs(t, r.Update(ctx, &models.Animal{IdAnim: id, NameAn: "Klepa", Title: "cat"}), database.ErrNotFound)
+}
+
+func TestMemAnimalRepositoryGetAll(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newMemRepositories(t)
+	for _, an := range []models.Animal{
+		{NameAn: "Zu", BirthDate: date(2002, 1, 1), Gender: "f", Title: "cat"},
+		{NameAn: "Tom", BirthDate: date(1994, 9, 1), Gender: "m", Title: "cat"},
+		{NameAn: "Zina", BirthDate: date(2024, 4, 20), Gender: "f", Title: "cat"},
+	} {
+		_, err := r.Add(ctx, &an)
+		require.NoError(t, err)
+	}
+
+	q := database.AnimalQuery{
+		Filter: models.AnimalFilter{Gender: "f"},
+		Order:  models.AnimalOrder{Field: models.SortByName},
+		Limit:  1,
+	}
+	animals, err := r.GetAll(ctx, q)
+	require.NoError(t, err)
+	require.Len(t, animals, 1)
+	assert.Equal(t, "Zina", animals[0].NameAn)
+
+	q.After = &database.AnimalCursor{ID: animals[0].IdAnim, Name: animals[0].NameAn}
+	animals, err = r.GetAll(ctx, q)
+	require.NoError(t, err)
+	require.Len(t, animals, 1)
+	assert.Equal(t, "Zu", animals[0].NameAn)
+
+	// по возрасту - от младших к старшим, то есть от поздней даты рождения к ранней
+	bornAfter := date(2000, 1, 1)
+	q = database.AnimalQuery{
+		Filter: models.AnimalFilter{BornAfter: &bornAfter},
+		Order:  models.AnimalOrder{Field: models.SortByAge},
+		Limit:  1,
+	}
+	animals, err = r.GetAll(ctx, q)
+	require.NoError(t, err)
+	require.Len(t, animals, 1)
+	assert.Equal(t, "Zina", animals[0].NameAn)
+
+	q.After = &database.AnimalCursor{ID: animals[0].IdAnim, BirthDate: animals[0].BirthDate}
+	q.Limit = 10
+	animals, err = r.GetAll(ctx, q)
+	require.NoError(t, err)
+	require.Len(t, animals, 1)
+	assert.Equal(t, "Zu", animals[0].NameAn)
+}
+
+func TestMemSpeciesRepositoryDeleteInUse(t *testing.T) {
+	ctx := context.Background()
+	r, sp := newMemRepositories(t)
+	_, err := r.Add(ctx, &models.Animal{NameAn: "Klepa", Title: "cat"})
+	require.NoError(t, err)
+
+	species, err := sp.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, species, 1)
+	assert.ErrorIs(t, sp.Delete(ctx, species[0].IdSp), database.ErrSpeciesInUse)
+
+	_, err = sp.Add(ctx, &models.Specie{Title: "cat", Descrip: "again"})
+	assert.ErrorIs(t, err, database.ErrSpeciesExists)
+}
+
+func TestMemEnclosureRepository(t *testing.T) {
+	ctx := context.Background()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	enclosures := database.NewMemEnclosureRepository(st)
+
+	_, err = enclosures.Add(ctx, &models.Enclosure{Name: "Aviary", Capacity: 1, Species: []string{"parrot"}})
+	require.ErrorIs(t, err, errs.ErrUnknownSpecies)
+	id, err := enclosures.Add(ctx, &models.Enclosure{Name: "Cat house", Capacity: 1, Species: []string{"cat"}})
+	require.NoError(t, err)
+
+	klepa, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	tom, err := animals.Add(ctx, &models.Animal{NameAn: "Tom", Gender: "m", Title: "cat"})
+	require.NoError(t, err)
+	require.NoError(t, animals.SetEnclosure(ctx, klepa, &id))
+	require.ErrorIs(t, animals.SetEnclosure(ctx, tom, &id), database.ErrEnclosureFull)
+
+	// Update животного не выселяет его из вольера
+	require.NoError(t, animals.Update(ctx, &models.Animal{IdAnim: klepa, NameAn: "Klepa", BirthDate: date(2024, 1, 1), Gender: "f", Title: "cat"}))
+	got, err := animals.Get(ctx, klepa)
+	require.NoError(t, err)
+	require.Equal(t, &id, got.IdEncl)
+
+	require.ErrorIs(t, enclosures.Delete(ctx, id), database.ErrEnclosureInUse)
+}
+
+func TestMemFeedingRepository(t *testing.T) {
+	ctx := context.Background()
+	st := database.NewMemStorage()
+	species := database.NewMemSpeciesRepository(st)
+	idSp, err := species.Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	feedings := database.NewMemFeedingRepository(st)
+
+	klepa, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	_, err = feedings.AddPlan(ctx, &models.FeedingPlan{Species: "parrot", Food: "seeds"})
+	require.ErrorIs(t, err, errs.ErrUnknownSpecies)
+	catPlan, err := feedings.AddPlan(ctx, &models.FeedingPlan{Species: "cat", Food: "fish", Quantity: 1, Unit: "g", Times: []string{"08:00"}})
+	require.NoError(t, err)
+	ownPlan, err := feedings.AddPlan(ctx, &models.FeedingPlan{IdAnim: &klepa, Food: "milk", Quantity: 1, Unit: "ml", Times: []string{"09:00"}})
+	require.NoError(t, err)
+
+	plans, err := feedings.ListPlans(ctx, models.FeedingPlanFilter{IdAnim: &klepa})
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	assert.Equal(t, ownPlan, plans[0].IdPlan)
+
+	day := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		_, err := feedings.AddFeeding(ctx, &models.Feeding{IdAnim: klepa, IdPlan: &catPlan, Food: "fish", FedAt: day.AddDate(0, 0, i)})
+		require.NoError(t, err)
+	}
+	feeds, err := feedings.ListFeedings(ctx, models.FeedingFilter{IdAnim: klepa, From: day.AddDate(0, 0, 1), Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, feeds, 2)
+	assert.Equal(t, day.AddDate(0, 0, 2), feeds[0].FedAt)
+	latest, err := feedings.LatestFeedings(ctx)
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, day.AddDate(0, 0, 2), latest[0].FedAt)
+
+	// удаление плана оставляет журнал, удаление вида - убирает его планы
+	require.NoError(t, feedings.DeletePlan(ctx, catPlan))
+	feeds, err = feedings.ListFeedings(ctx, models.FeedingFilter{IdAnim: klepa, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, feeds, 3)
+	assert.Nil(t, feeds[0].IdPlan)
+
+	require.NoError(t, animals.Delete(ctx, klepa))
+	require.NoError(t, species.Delete(ctx, idSp))
+	plans, err = feedings.ListPlans(ctx, models.FeedingPlanFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, plans)
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+const (
+	// ключ pg_advisory_lock, общий для всех реплик zooad
+	migrationLockKey int64 = 0x7a6f6f6164
+
+	createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name varchar(200) NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now()
+)`
+	selectMigrations = "SELECT version, applied_at FROM schema_migrations"
+	insertMigration  = "INSERT INTO schema_migrations (version, name) VALUES($1, $2)"
+	deleteMigration  = "DELETE FROM schema_migrations WHERE version = $1"
+)
+
+var ErrNoMigrations = errors.New("no applied migrations")
+
+type (
+	// Migration - пара файлов NNNN_name.up.sql / NNNN_name.down.sql из migrations/
+	Migration struct {
+		Version int64
+		Name    string
+		Up      string
+		Down    string
+	}
+
+	MigrationStatus struct {
+		Version   int64
+		Name      string
+		AppliedAt *time.Time
+	}
+
+	// Migrator применяет миграции под advisory lock, так что несколько реплик,
+	// стартующих одновременно, выполняют их строго по очереди
+	Migrator struct {
+		pool       *pgxpool.Pool
+		migrations []Migration
+	}
+)
+
+func NewMigrator(p *pgxpool.Pool) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: p, migrations: migrations}, nil
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		file := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(file, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("migration %s: expected NNNN_name.up.sql or NNNN_name.down.sql", file)
+		}
+		num, name, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: bad version: %w", file, err)
+		}
+		body, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up применяет все еще не примененные миграции и возвращает их количество
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	var applied int
+	err := m.locked(ctx, func(conn *pgx.Conn, done map[int64]time.Time) error {
+		for _, mig := range m.migrations {
+			if _, ok := done[mig.Version]; ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, insertMigration, mig.Version, mig.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down откатывает последнюю примененную миграцию
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var rolledBack *Migration
+	err := m.locked(ctx, func(conn *pgx.Conn, done map[int64]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := done[mig.Version]; !ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if mig.Down != "" {
+					if _, err := tx.Exec(ctx, mig.Down); err != nil {
+						return err
+					}
+				}
+				_, err := tx.Exec(ctx, deleteMigration, mig.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+			}
+			rolledBack = &mig
+			return nil
+		}
+		return ErrNoMigrations
+	})
+	return rolledBack, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var res []MigrationStatus
+	err := m.locked(ctx, func(_ *pgx.Conn, done map[int64]time.Time) error {
+		for _, mig := range m.migrations {
+			st := MigrationStatus{Version: mig.Version, Name: mig.Name}
+			if at, ok := done[mig.Version]; ok {
+				st.AppliedAt = &at
+			}
+			res = append(res, st)
+		}
+		return nil
+	})
+	return res, err
+}
+
+// locked держит advisory lock на отдельном соединении, пока выполняется f
+func (m *Migrator) locked(ctx context.Context, f func(*pgx.Conn, map[int64]time.Time) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return err
+	}
+	defer func() {
+		// контекст мог уже закончиться, а лок нужно отпустить в любом случае
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			conn.Conn().Close(context.Background())
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, createMigrationsTable); err != nil {
+		return err
+	}
+	rows, err := conn.Query(ctx, selectMigrations)
+	if err != nil {
+		return err
+	}
+	done := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			rows.Close()
+			return err
+		}
+		done[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return f(conn.Conn(), done)
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrationsSortsAndPairs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0002_second.up.sql":  {Data: []byte("up2")},
+		"m/0001_first.up.sql":   {Data: []byte("up1")},
+		"m/0001_first.down.sql": {Data: []byte("down1")},
+	}
+	migrations, err := loadMigrations(fsys, "m")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, Migration{Version: 1, Name: "first", Up: "up1", Down: "down1"}, migrations[0])
+	assert.Equal(t, Migration{Version: 2, Name: "second", Up: "up2"}, migrations[1])
+}
+
+func TestLoadMigrationsRejectsBadNames(t *testing.T) {
+	for _, name := range []string{"m/first.up.sql", "m/0001_first.sql", "m/0001_first.down.sql"} {
+		_, err := loadMigrations(fstest.MapFS{name: {Data: []byte("x")}}, "m")
+		assert.Error(t, err, name)
+	}
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, int64(1), migrations[0].Version)
+}
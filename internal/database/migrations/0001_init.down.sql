@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Animals;
+DROP TABLE IF EXISTS Species;
@@ -1,3 +1,4 @@
+-- IF NOT EXISTS: базы, созданные старым init/init.sql, принимаются как есть
 CREATE TABLE IF NOT EXISTS Species (
     id_sp bigserial PRIMARY KEY,
     title varchar(40) NOT NULL UNIQUE CONSTRAINT non_empty_title CHECK(length(title)>0),
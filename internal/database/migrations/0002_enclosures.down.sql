@@ -0,0 +1,3 @@
+ALTER TABLE Animals DROP COLUMN IF EXISTS id_encl;
+DROP TABLE IF EXISTS Enclosure_species;
+DROP TABLE IF EXISTS Enclosures;
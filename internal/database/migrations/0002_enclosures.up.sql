@@ -0,0 +1,18 @@
+CREATE TABLE Enclosures (
+    id_encl bigserial PRIMARY KEY,
+    name varchar(40) NOT NULL UNIQUE CONSTRAINT non_empty_name CHECK(length(name)>0),
+    zone varchar(40) NOT NULL,
+    capacity integer NOT NULL CONSTRAINT positive_capacity CHECK(capacity>0),
+    habitat varchar(40) NOT NULL
+);
+
+-- виды, которые можно держать в вольере
+CREATE TABLE Enclosure_species (
+    id_encl bigint NOT NULL REFERENCES Enclosures(id_encl) ON DELETE CASCADE,
+    id_sp bigint NOT NULL REFERENCES Species(id_sp) ON DELETE CASCADE,
+    PRIMARY KEY (id_encl, id_sp)
+);
+
+-- без ON DELETE: занятый вольер удалить нельзя
+ALTER TABLE Animals ADD COLUMN id_encl bigint REFERENCES Enclosures(id_encl);
+CREATE INDEX animals_id_encl ON Animals (id_encl);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS Feedings;
+DROP TABLE IF EXISTS Feeding_plans;
@@ -0,0 +1,24 @@
+-- план задается либо для вида, либо для конкретного животного
+CREATE TABLE Feeding_plans (
+    id_plan bigserial PRIMARY KEY,
+    id_sp bigint REFERENCES Species(id_sp) ON DELETE CASCADE,
+    id_anim bigint REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    food varchar(40) NOT NULL,
+    quantity double precision NOT NULL CONSTRAINT positive_quantity CHECK(quantity>0),
+    unit varchar(10) NOT NULL,
+    times text[] NOT NULL,
+    CONSTRAINT one_target CHECK((id_sp IS NULL) <> (id_anim IS NULL))
+);
+
+CREATE TABLE Feedings (
+    id_feed bigserial PRIMARY KEY,
+    id_anim bigint NOT NULL REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    id_plan bigint REFERENCES Feeding_plans(id_plan) ON DELETE SET NULL,
+    food varchar(40) NOT NULL,
+    quantity double precision NOT NULL,
+    unit varchar(10) NOT NULL,
+    keeper varchar(40) NOT NULL,
+    fed_at timestamptz NOT NULL,
+    notes varchar(400) NOT NULL DEFAULT ''
+);
+CREATE INDEX feedings_id_anim_fed_at ON Feedings (id_anim, fed_at DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS Vaccinations;
+DROP TABLE IF EXISTS Medications;
+DROP TABLE IF EXISTS Medical_records;
@@ -0,0 +1,35 @@
+-- осмотры, диагнозы и лечение
+CREATE TABLE Medical_records (
+    id_rec bigserial PRIMARY KEY,
+    id_anim bigint NOT NULL REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    kind varchar(20) NOT NULL,
+    title varchar(100) NOT NULL,
+    details varchar(400) NOT NULL DEFAULT '',
+    vet varchar(40) NOT NULL,
+    recorded_at timestamptz NOT NULL
+);
+CREATE INDEX medical_records_id_anim ON Medical_records (id_anim, recorded_at DESC);
+
+CREATE TABLE Medications (
+    id_med bigserial PRIMARY KEY,
+    id_anim bigint NOT NULL REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    id_rec bigint REFERENCES Medical_records(id_rec) ON DELETE SET NULL,
+    drug varchar(40) NOT NULL,
+    dose double precision NOT NULL CONSTRAINT positive_dose CHECK(dose>0),
+    dose_unit varchar(10) NOT NULL,
+    frequency varchar(40) NOT NULL DEFAULT '',
+    started_at timestamptz NOT NULL,
+    days integer NOT NULL CONSTRAINT positive_days CHECK(days>0),
+    vet varchar(40) NOT NULL
+);
+CREATE INDEX medications_id_anim ON Medications (id_anim, started_at DESC);
+
+CREATE TABLE Vaccinations (
+    id_vac bigserial PRIMARY KEY,
+    id_anim bigint NOT NULL REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    vaccine varchar(40) NOT NULL,
+    given_at timestamptz NOT NULL,
+    due_at timestamptz,
+    vet varchar(40) NOT NULL
+);
+CREATE INDEX vaccinations_id_anim ON Vaccinations (id_anim, vaccine, given_at DESC);
@@ -0,0 +1,13 @@
+CREATE TABLE Measurements (
+    id_meas bigserial PRIMARY KEY,
+    id_anim bigint NOT NULL REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    measured_at timestamptz NOT NULL,
+    weight_kg double precision CONSTRAINT positive_weight CHECK(weight_kg>0),
+    length_cm double precision CONSTRAINT positive_length CHECK(length_cm>0),
+    -- упитанность по шкале 1-9
+    body_condition smallint CONSTRAINT body_condition_scale CHECK(body_condition BETWEEN 1 AND 9),
+    notes varchar(400) NOT NULL DEFAULT '',
+    CONSTRAINT any_value CHECK(num_nonnulls(weight_kg, length_cm, body_condition) > 0)
+);
+CREATE INDEX measurements_id_anim_measured_at ON Measurements (id_anim, measured_at);
+CREATE INDEX measurements_measured_at ON Measurements (measured_at);
@@ -0,0 +1,6 @@
+ALTER TABLE Animals ADD COLUMN age integer;
+UPDATE Animals SET age = date_part('year', age(birth_date));
+ALTER TABLE Animals ALTER COLUMN age SET NOT NULL;
+DROP INDEX IF EXISTS animals_birth_date;
+ALTER TABLE Animals DROP COLUMN birth_precision;
+ALTER TABLE Animals DROP COLUMN birth_date;
@@ -0,0 +1,9 @@
+-- возраст в годах хранился на момент записи и устаревал; вместо него дата рождения.
+-- Для уже записанных животных дата восстанавливается из возраста и помечается как оценка
+ALTER TABLE Animals ADD COLUMN birth_date date;
+ALTER TABLE Animals ADD COLUMN birth_precision varchar(10) NOT NULL DEFAULT 'exact'
+    CONSTRAINT known_birth_precision CHECK(birth_precision IN ('exact', 'month', 'year', 'estimated'));
+UPDATE Animals SET birth_date = current_date - make_interval(years => age), birth_precision = 'estimated';
+ALTER TABLE Animals ALTER COLUMN birth_date SET NOT NULL;
+ALTER TABLE Animals DROP COLUMN age;
+CREATE INDEX animals_birth_date ON Animals (birth_date);
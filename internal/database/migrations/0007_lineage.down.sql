@@ -0,0 +1,4 @@
+ALTER TABLE Animals DROP COLUMN IF EXISTS mother_id;
+ALTER TABLE Animals DROP COLUMN IF EXISTS mother_ext;
+ALTER TABLE Animals DROP COLUMN IF EXISTS father_id;
+ALTER TABLE Animals DROP COLUMN IF EXISTS father_ext;
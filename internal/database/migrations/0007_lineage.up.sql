@@ -0,0 +1,11 @@
+-- родители: животное зоопарка (mother_id, father_id) или внешнее, известное по метке
+-- из племенной книги (mother_ext, father_ext); ни того ни другого - родитель неизвестен.
+-- Без ON DELETE: животное с записанным потомством удалить нельзя, иначе пропадет родословная
+ALTER TABLE Animals ADD COLUMN mother_id bigint REFERENCES Animals(id_anim);
+ALTER TABLE Animals ADD COLUMN mother_ext varchar(100) NOT NULL DEFAULT '';
+ALTER TABLE Animals ADD COLUMN father_id bigint REFERENCES Animals(id_anim);
+ALTER TABLE Animals ADD COLUMN father_ext varchar(100) NOT NULL DEFAULT '';
+ALTER TABLE Animals ADD CONSTRAINT one_mother CHECK(mother_id IS NULL OR mother_ext = '');
+ALTER TABLE Animals ADD CONSTRAINT one_father CHECK(father_id IS NULL OR father_ext = '');
+CREATE INDEX animals_mother_id ON Animals (mother_id);
+CREATE INDEX animals_father_id ON Animals (father_id);
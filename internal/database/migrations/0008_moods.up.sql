@@ -0,0 +1,13 @@
+-- настроения: отмеченные смотрителями и вычисленные правилами; id_encl - вольер в момент записи
+CREATE TABLE Moods (
+    id_mood bigserial PRIMARY KEY,
+    id_anim bigint NOT NULL REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    mood varchar(40) NOT NULL CONSTRAINT non_empty_mood CHECK(length(mood)>0),
+    source varchar(10) NOT NULL CONSTRAINT mood_source CHECK(source IN ('keeper', 'computed')),
+    keeper varchar(40) NOT NULL DEFAULT '',
+    notes varchar(400) NOT NULL DEFAULT '',
+    id_encl bigint REFERENCES Enclosures(id_encl) ON DELETE SET NULL,
+    observed_at timestamptz NOT NULL
+);
+CREATE INDEX moods_id_anim_observed_at ON Moods (id_anim, observed_at);
+CREATE INDEX moods_observed_at ON Moods (observed_at);
@@ -0,0 +1,18 @@
+-- наблюдения смотрителей за поведением; пустые activity, appetite и social - не оценивались
+CREATE TABLE Observations (
+    id_obs bigserial PRIMARY KEY,
+    id_anim bigint NOT NULL REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    keeper varchar(40) NOT NULL CONSTRAINT non_empty_keeper CHECK(length(keeper)>0),
+    observed_at timestamptz NOT NULL,
+    activity varchar(20) NOT NULL DEFAULT '',
+    appetite varchar(20) NOT NULL DEFAULT '',
+    social varchar(20) NOT NULL DEFAULT '',
+    stereotypic boolean NOT NULL DEFAULT false,
+    severity varchar(10) NOT NULL CONSTRAINT observation_severity CHECK(severity IN ('info', 'low', 'medium', 'high')),
+    notes varchar(400) NOT NULL DEFAULT '',
+    tags varchar(40)[] NOT NULL DEFAULT '{}'
+);
+CREATE INDEX observations_id_anim_observed_at ON Observations (id_anim, observed_at);
+CREATE INDEX observations_observed_at ON Observations (observed_at);
+CREATE INDEX observations_keeper ON Observations (keeper);
+CREATE INDEX observations_tags ON Observations USING GIN (tags);
@@ -0,0 +1,7 @@
+-- сотрудники и их роли; name совпадает с субъектом api-ключа или токена
+CREATE TABLE Users (
+    id_user bigserial PRIMARY KEY,
+    name varchar(40) NOT NULL UNIQUE CONSTRAINT non_empty_user_name CHECK(length(name)>0),
+    role varchar(20) NOT NULL CONSTRAINT user_role CHECK(role IN ('viewer', 'keeper', 'vet', 'curator', 'admin')),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
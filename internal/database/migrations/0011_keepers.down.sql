@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS Keeper_assignments;
+DROP TABLE IF EXISTS Keeper_species;
+DROP TABLE IF EXISTS Keepers;
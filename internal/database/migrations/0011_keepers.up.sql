@@ -0,0 +1,28 @@
+-- справочник смотрителей; contact - телефон или почта в свободной форме
+CREATE TABLE Keepers (
+    id_keeper bigserial PRIMARY KEY,
+    name varchar(40) NOT NULL UNIQUE CONSTRAINT non_empty_keeper_name CHECK(length(name)>0),
+    role varchar(20) NOT NULL,
+    contact varchar(100) NOT NULL DEFAULT ''
+);
+
+-- виды, с которыми смотритель умеет работать
+CREATE TABLE Keeper_species (
+    id_keeper bigint NOT NULL REFERENCES Keepers(id_keeper) ON DELETE CASCADE,
+    id_sp bigint NOT NULL REFERENCES Species(id_sp) ON DELETE CASCADE,
+    PRIMARY KEY (id_keeper, id_sp)
+);
+
+-- закрепление смотрителя за животным или за вольером целиком
+CREATE TABLE Keeper_assignments (
+    id_assign bigserial PRIMARY KEY,
+    id_keeper bigint NOT NULL REFERENCES Keepers(id_keeper) ON DELETE CASCADE,
+    id_anim bigint REFERENCES Animals(id_anim) ON DELETE CASCADE,
+    id_encl bigint REFERENCES Enclosures(id_encl) ON DELETE CASCADE,
+    assigned_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT assignment_target CHECK((id_anim IS NULL) <> (id_encl IS NULL)),
+    UNIQUE (id_keeper, id_anim),
+    UNIQUE (id_keeper, id_encl)
+);
+CREATE INDEX keeper_assignments_id_anim ON Keeper_assignments (id_anim);
+CREATE INDEX keeper_assignments_id_encl ON Keeper_assignments (id_encl);
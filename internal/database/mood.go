@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	insertMood  = "INSERT INTO Moods (id_anim, mood, source, keeper, notes, id_encl, observed_at) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id_mood"
+	selectMoods = "SELECT id_mood, id_anim, mood, source, keeper, notes, id_encl, observed_at FROM Moods"
+)
+
+// moodGroups - откуда берется название группы для распределения
+var moodGroups = map[models.MoodGrouping]string{
+	models.MoodByEnclosure: "Enclosures.name FROM Moods JOIN Enclosures ON Moods.id_encl = Enclosures.id_encl",
+	models.MoodBySpecies: `Species.title FROM Moods JOIN Animals ON Moods.id_anim = Animals.id_anim
+	JOIN Species ON Animals.id_sp = Species.id_sp`,
+}
+
+type MoodRepository interface {
+	Add(ctx context.Context, m *models.MoodEntry) (int64, error)
+	// List отдает историю животного от новых к старым
+	List(ctx context.Context, f models.MoodFilter) ([]models.MoodEntry, error)
+	// Distribution - число записей по группам и настроениям, упорядочено по группе и настроению.
+	// При группировке по вольеру записи без вольера не учитываются
+	Distribution(ctx context.Context, f models.MoodDistributionFilter) ([]models.MoodCount, error)
+}
+
+type PgMoodRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMoodRepository(ctx context.Context, p *pgxpool.Pool) (*PgMoodRepository, error) {
+	return &PgMoodRepository{pool: p}, nil
+}
+
+func scanMood(row pgx.Row, m *models.MoodEntry) error {
+	return row.Scan(&m.IdMood, &m.IdAnim, &m.Mood, &m.Source, &m.Keeper, &m.Notes, &m.IdEncl, &m.ObservedAt)
+}
+
+func (r *PgMoodRepository) Add(ctx context.Context, m *models.MoodEntry) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, insertMood, m.IdAnim, m.Mood, m.Source, m.Keeper, m.Notes, m.IdEncl, m.ObservedAt).Scan(&id)
+	if err != nil {
+		return -1, moodError(err)
+	}
+	return id, nil
+}
+
+// moodConds - общие условия по источнику и периоду
+func moodConds(b *sqlBuilder, source models.MoodSource, from, to time.Time) {
+	if source != "" {
+		b.cond("source = %s", b.arg(source))
+	}
+	if !from.IsZero() {
+		b.cond("observed_at >= %s", b.arg(from))
+	}
+	if !to.IsZero() {
+		b.cond("observed_at < %s", b.arg(to))
+	}
+}
+
+func (r *PgMoodRepository) List(ctx context.Context, f models.MoodFilter) ([]models.MoodEntry, error) {
+	var b sqlBuilder
+	b.cond("id_anim = %s", b.arg(f.IdAnim))
+	moodConds(&b, f.Source, f.From, f.To)
+	query := fmt.Sprintf("%s\n\tWHERE %s\n\tORDER BY observed_at DESC, id_mood DESC", selectMoods, strings.Join(b.where, " AND "))
+	if f.Limit > 0 {
+		query += "\n\tLIMIT " + b.arg(f.Limit)
+	}
+	return collect(ctx, r.pool, query, b.args, scanMood)
+}
+
+func (r *PgMoodRepository) Distribution(ctx context.Context, f models.MoodDistributionFilter) ([]models.MoodCount, error) {
+	group, ok := moodGroups[f.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown mood grouping %q", f.GroupBy)
+	}
+	var b sqlBuilder
+	moodConds(&b, f.Source, f.From, f.To)
+	query := "SELECT mood, count(*), " + group
+	if len(b.where) > 0 {
+		query += "\n\tWHERE " + strings.Join(b.where, " AND ")
+	}
+	query += "\n\tGROUP BY 3, 1\n\tORDER BY 3, 1"
+	return collect(ctx, r.pool, query, b.args, func(row pgx.Row, c *models.MoodCount) error {
+		return row.Scan(&c.Mood, &c.Count, &c.Group)
+	})
+}
+
+// moodError - ссылка на удаленное животное или вольер
+func moodError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		if strings.Contains(pgErr.ConstraintName, "id_encl") {
+			return ErrEnclosureNotFound
+		}
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	insertObservation = `INSERT INTO Observations (id_anim, keeper, observed_at, activity, appetite, social, stereotypic, severity, notes, tags)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id_obs`
+	selectObservations = "SELECT id_obs, id_anim, keeper, observed_at, activity, appetite, social, stereotypic, severity, notes, tags FROM Observations"
+)
+
+type ObservationRepository interface {
+	Add(ctx context.Context, o *models.Observation) (int64, error)
+	// List отдает наблюдения от новых к старым
+	List(ctx context.Context, f models.ObservationFilter) ([]models.Observation, error)
+}
+
+type PgObservationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewObservationRepository(ctx context.Context, p *pgxpool.Pool) (*PgObservationRepository, error) {
+	return &PgObservationRepository{pool: p}, nil
+}
+
+func scanObservation(row pgx.Row, o *models.Observation) error {
+	return row.Scan(&o.IdObs, &o.IdAnim, &o.Keeper, &o.ObservedAt, &o.Activity, &o.Appetite, &o.Social, &o.Stereotypic, &o.Severity, &o.Notes, &o.Tags)
+}
+
+func (r *PgObservationRepository) Add(ctx context.Context, o *models.Observation) (int64, error) {
+	tags := o.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	var id int64
+	err := r.pool.QueryRow(ctx, insertObservation, o.IdAnim, o.Keeper, o.ObservedAt, o.Activity, o.Appetite, o.Social, o.Stereotypic, o.Severity, o.Notes, tags).Scan(&id)
+	if err != nil {
+		return -1, observationError(err)
+	}
+	return id, nil
+}
+
+func (r *PgObservationRepository) List(ctx context.Context, f models.ObservationFilter) ([]models.Observation, error) {
+	var b sqlBuilder
+	if f.IdAnim != nil {
+		b.cond("id_anim = %s", b.arg(*f.IdAnim))
+	}
+	if f.Keeper != "" {
+		b.cond("keeper = %s", b.arg(f.Keeper))
+	}
+	if f.Tag != "" {
+		// @> вместо ANY, чтобы работал GIN-индекс
+		b.cond("tags @> ARRAY[%s]::varchar[]", b.arg(f.Tag))
+	}
+	if !f.From.IsZero() {
+		b.cond("observed_at >= %s", b.arg(f.From))
+	}
+	if !f.To.IsZero() {
+		b.cond("observed_at < %s", b.arg(f.To))
+	}
+	query := selectObservations
+	if len(b.where) > 0 {
+		query += "\n\tWHERE " + strings.Join(b.where, " AND ")
+	}
+	query += "\n\tORDER BY observed_at DESC, id_obs DESC"
+	if f.Limit > 0 {
+		query += "\n\tLIMIT " + b.arg(f.Limit)
+	}
+	return collect(ctx, r.pool, query, b.args, scanObservation)
+}
+
+// observationError - наблюдение ссылается только на животное
+func observationError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return ErrNotFound
+	}
+	return err
+}
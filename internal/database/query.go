@@ -0,0 +1,114 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const selectAnimals = `SELECT id_anim, name_an, birth_date, birth_precision, gender, title, descrip, id_encl FROM
+	Animals JOIN Species ON Animals.id_sp = Species.id_sp`
+
+type (
+	// AnimalCursor - последняя запись предыдущей страницы. Из полей сортировки
+	// используется только то, по которому идет сортировка, плюс id для однозначности
+	AnimalCursor struct {
+		ID        int64
+		Name      string
+		BirthDate time.Time
+	}
+
+	// AnimalQuery описывает выборку для AnimalRepository.GetAll:
+	// фильтр, порядок и keyset-позицию, после которой нужно продолжить
+	AnimalQuery struct {
+		Filter models.AnimalFilter
+		Order  models.AnimalOrder
+		After  *AnimalCursor
+		Limit  int
+	}
+)
+
+var sortColumns = map[models.AnimalSortField]string{
+	models.SortByID:   "id_anim",
+	models.SortByName: "name_an",
+	models.SortByAge:  "birth_date",
+}
+
+type sqlBuilder struct {
+	where []string
+	args  []any
+}
+
+func (b *sqlBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *sqlBuilder) cond(format string, v ...any) {
+	b.where = append(b.where, fmt.Sprintf(format, v...))
+}
+
+func (q AnimalQuery) sql() (string, []any, error) {
+	order := q.Order
+	if order.Field == "" {
+		order.Field = models.SortByID
+	}
+	column, ok := sortColumns[order.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown sort field %q", order.Field)
+	}
+
+	var b sqlBuilder
+	f := q.Filter
+	if f.Species != "" {
+		b.cond("Species.title = %s", b.arg(f.Species))
+	}
+	if f.Gender != "" {
+		b.cond("gender = %s", b.arg(f.Gender))
+	}
+	if f.NamePrefix != "" {
+		b.cond("starts_with(name_an, %s)", b.arg(f.NamePrefix))
+	}
+	if f.BornAfter != nil {
+		b.cond("birth_date > %s", b.arg(*f.BornAfter))
+	}
+	if f.BornBefore != nil {
+		b.cond("birth_date < %s", b.arg(*f.BornBefore))
+	}
+	if f.Enclosure != nil {
+		b.cond("id_encl = %s", b.arg(*f.Enclosure))
+	}
+
+	// чем раньше родился, тем старше: возраст по возрастанию - дата рождения по убыванию
+	desc := order.Desc != (order.Field == models.SortByAge)
+	cmp, dir := ">", "ASC"
+	if desc {
+		cmp, dir = "<", "DESC"
+	}
+	if q.After != nil {
+		switch order.Field {
+		case models.SortByID:
+			b.cond("id_anim %s %s", cmp, b.arg(q.After.ID))
+		case models.SortByName:
+			b.cond("(name_an, id_anim) %s (%s, %s)", cmp, b.arg(q.After.Name), b.arg(q.After.ID))
+		case models.SortByAge:
+			b.cond("(birth_date, id_anim) %s (%s, %s)", cmp, b.arg(q.After.BirthDate), b.arg(q.After.ID))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(selectAnimals)
+	if len(b.where) > 0 {
+		sb.WriteString("\n\tWHERE ")
+		sb.WriteString(strings.Join(b.where, " AND "))
+	}
+	if column == "id_anim" {
+		fmt.Fprintf(&sb, "\n\tORDER BY id_anim %s", dir)
+	} else {
+		fmt.Fprintf(&sb, "\n\tORDER BY %s %s, id_anim %s", column, dir, dir)
+	}
+	fmt.Fprintf(&sb, "\n\tLIMIT %s", b.arg(q.Limit))
+	return sb.String(), b.args, nil
+}
@@ -4,72 +4,65 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
 	models "github.com/mi-raf/zooad/internal/models"
 )
 
 const (
-	delete     = "DELETE FROM Animals WHERE id_anim = $1"
-	insert     = "INSERT INTO Animals (name_an, age, gender, id_sp) VALUES($1, $2, $3, $4) RETURNING id_anim"
+	deleteAnim = "DELETE FROM Animals WHERE id_anim = $1"
+	insert     = "INSERT INTO Animals (name_an, birth_date, birth_precision, gender, id_sp) VALUES($1, $2, $3, $4, $5) RETURNING id_anim"
 	searchIdSp = "SELECT id_sp FROM Species WHERE title = $1"
-	search     = `SELECT id_anim, name_an, age, gender, title, descrip FROM 
+	search     = `SELECT id_anim, name_an, birth_date, birth_precision, gender, title, descrip, id_encl FROM 
 	Animals JOIN Species ON Animals.id_sp = Species.id_sp
 	WHERE id_anim = $1`
-	searchGetAll = `SELECT id_anim, name_an, age, gender, title, descrip FROM 
-	Animals JOIN Species ON Animals.id_sp = Species.id_sp
-	ORDER BY id_anim
-	LIMIT $1
-	OFFSET $2`
-	update = "UPDATE Animals SET name_an = $1, age = $2, gender = $3, id_sp = (SELECT id_sp FROM Species WHERE title = $4) WHERE id_anim = $5;"
+	setEnclosure   = "UPDATE Animals SET id_encl = $1 WHERE id_anim = $2"
+	unsetEnclosure = "UPDATE Animals SET id_encl = NULL WHERE id_anim = $1"
+	lockEnclosure  = "SELECT capacity FROM Enclosures WHERE id_encl = $1 FOR UPDATE"
+	countOccupants = "SELECT count(*) FROM Animals WHERE id_encl = $1 AND id_anim <> $2"
+	update         = "UPDATE Animals SET name_an = $1, birth_date = $2, birth_precision = $3, gender = $4, id_sp = (SELECT id_sp FROM Species WHERE title = $5) WHERE id_anim = $6;"
 )
 
+var ErrNotFound error = errs.NotFound("animal not found")
+
 type AnimalRepository interface {
 	Delete(ctx context.Context, idAnim int64) error
 	Add(ctx context.Context, individual *models.Animal) (int64, error)
 	Get(ctx context.Context, idAnim int64) (*models.Animal, error)
-	GetAll(ctx context.Context, offset, limit int) ([]models.Animal, error)
+	// GetAll возвращает не больше q.Limit животных, подходящих под фильтр,
+	// в порядке q.Order, начиная после позиции q.After
+	GetAll(ctx context.Context, q AnimalQuery) ([]models.Animal, error)
+	// Update не меняет вольер, для этого есть SetEnclosure
 	Update(ctx context.Context, individual *models.Animal) error
+	// SetEnclosure переселяет животное, nil - убрать из вольера.
+	// Вместимость проверяется атомарно с переселением, при нехватке мест ErrEnclosureFull
+	SetEnclosure(ctx context.Context, idAnim int64, idEncl *int64) error
 }
 
-// type PgAnimalRepository struct {
-// 	pool *pgxpool.Pool
-// }
-
-// todo
 type PgAnimalRepository struct {
 	pool *pgxpool.Pool
-
-	//mux         sync.RWMutex
-	requestTime time.Time
 }
 
-// todo
-// func (r *PgAnimalRepository) Init(ctx context.Context) error {
-// 	conn := ""
-// 	NewAnimalRepository(context.TODO(), conn)
-// 	panic("t")
-// }
+// PgAnimalRepository - ресурс для ServiceKeeper: Ping проверяет пул,
+// Close закрывает его при остановке приложения
+func (r *PgAnimalRepository) Init(ctx context.Context) error {
+	return r.pool.Ping(ctx)
+}
 
-// todo
 func (r *PgAnimalRepository) Ping(ctx context.Context) error {
-	if time.Since(r.requestTime).Minutes() > 1 {
-		r.requestTime = time.Now()
-		return r.refresh(ctx)
-	}
-	return nil
+	return r.pool.Ping(ctx)
 }
 
-func (r *PgAnimalRepository) Close(ctx context.Context) error {
-	r.pool.Close()
-	return nil
+func (r *PgAnimalRepository) Name() string {
+	return "postgres"
 }
 
-// todo
-func (r *PgAnimalRepository) refresh(ctx context.Context) error {
-	return r.pool.Ping(ctx)
+func (r *PgAnimalRepository) Close() error {
+	r.pool.Close()
+	return nil
 }
 
 func NewAnimalRepository(ctx context.Context, p *pgxpool.Pool) (*PgAnimalRepository, error) {
@@ -78,8 +71,19 @@ func NewAnimalRepository(ctx context.Context, p *pgxpool.Pool) (*PgAnimalReposit
 }
 
 func (r *PgAnimalRepository) Delete(ctx context.Context, idAnim int64) error {
-	_, err := r.pool.Exec(ctx, delete, idAnim)
-	return err
+	tag, err := r.pool.Exec(ctx, deleteAnim, idAnim)
+	// на животное ссылаются только mother_id и father_id потомков
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return ErrAnimalHasOffspring
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 func (r *PgAnimalRepository) Add(ctx context.Context, individual *models.Animal) (int64, error) {
@@ -99,12 +103,15 @@ func (r *PgAnimalRepository) Add(ctx context.Context, individual *models.Animal)
 
 	var id_sp int64
 	err = tx.QueryRow(ctx, searchIdSp, individual.Title).Scan(&id_sp)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return -1, errs.UnknownSpecies(individual.Title)
+	}
 	if err != nil {
 		return -1, err
 	}
 
 	var id_an int64
-	err = tx.QueryRow(ctx, insert, individual.NameAn, individual.Age, individual.Gender, id_sp).Scan(&id_an)
+	err = tx.QueryRow(ctx, insert, individual.NameAn, individual.BirthDate, individual.BirthPrecision, individual.Gender, id_sp).Scan(&id_an)
 	if err != nil {
 		return -1, err
 	}
@@ -117,23 +124,31 @@ func (r *PgAnimalRepository) Get(ctx context.Context, idAnim int64) (*models.Ani
 
 	animalFull := models.Animal{}
 
-	err := r.pool.QueryRow(ctx, search, idAnim).Scan(&animalFull.IdAnim, &animalFull.NameAn, &animalFull.Age, &animalFull.Gender, &animalFull.Title, &animalFull.Descrip)
+	err := r.pool.QueryRow(ctx, search, idAnim).Scan(&animalFull.IdAnim, &animalFull.NameAn, &animalFull.BirthDate, &animalFull.BirthPrecision, &animalFull.Gender, &animalFull.Title, &animalFull.Descrip, &animalFull.IdEncl)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &animalFull, ErrNotFound
+	}
 
 	return &animalFull, err
 }
 
-func (r *PgAnimalRepository) GetAll(ctx context.Context, offset, limit int) ([]models.Animal, error) {
+func (r *PgAnimalRepository) GetAll(ctx context.Context, q AnimalQuery) ([]models.Animal, error) {
+	query, args, err := q.sql()
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := r.pool.Query(ctx, searchGetAll, limit, offset)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	animalsFull := make([]models.Animal, 0)
 
 	for rows.Next() {
 		var an models.Animal
-		err = rows.Scan(&an.IdAnim, &an.NameAn, &an.Age, &an.Gender, &an.Title, &an.Descrip)
+		err = rows.Scan(&an.IdAnim, &an.NameAn, &an.BirthDate, &an.BirthPrecision, &an.Gender, &an.Title, &an.Descrip, &an.IdEncl)
 		if err != nil {
 			return nil, err
 		}
@@ -153,10 +168,63 @@ func (r *PgAnimalRepository) GetAll(ctx context.Context, offset, limit int) ([]m
 
 func (r *PgAnimalRepository) Update(ctx context.Context, individual *models.Animal) error {
 	var newTitle string
-	r.pool.QueryRow(ctx, "SELECT title FROM Species WHERE title = $1", individual.Title).Scan(&newTitle)
-	if newTitle != individual.Title {
-		return errors.New("title is not exists")
+	err := r.pool.QueryRow(ctx, "SELECT title FROM Species WHERE title = $1", individual.Title).Scan(&newTitle)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errs.UnknownSpecies(individual.Title)
+	}
+	if err != nil {
+		return err
+	}
+	tag, err := r.pool.Exec(ctx, update, individual.NameAn, individual.BirthDate, individual.BirthPrecision, individual.Gender, individual.Title, individual.IdAnim)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PgAnimalRepository) SetEnclosure(ctx context.Context, idAnim int64, idEncl *int64) error {
+	if idEncl == nil {
+		tag, err := r.pool.Exec(ctx, unsetEnclosure, idAnim)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		return nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// блокировка строки вольера выстраивает конкурентные заселения в очередь,
+	// иначе два запроса могут одновременно занять последнее место
+	var capacity, occupied int
+	err = tx.QueryRow(ctx, lockEnclosure, *idEncl).Scan(&capacity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrEnclosureNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.QueryRow(ctx, countOccupants, *idEncl, idAnim).Scan(&occupied); err != nil {
+		return err
+	}
+	if occupied >= capacity {
+		return ErrEnclosureFull
+	}
+	tag, err := tx.Exec(ctx, setEnclosure, *idEncl, idAnim)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
 	}
-	_, err := r.pool.Exec(ctx, update, individual.NameAn, individual.Age, individual.Gender, individual.Title, individual.IdAnim)
-	return err
+	return tx.Commit(ctx)
 }
@@ -1,13 +1,21 @@
+//go:build integration
+
+// Тесты с Postgres в контейнере: go test -tags integration ./internal/database.
+// С тегом отсутствие Docker - ошибка, а не пропуск
+
 package database_test
 
 import (
 	"context"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
 	models "github.com/mi-raf/zooad/internal/models"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
@@ -18,6 +26,14 @@ import (
 type RepositoryTestSuite struct {
 	suite.Suite
 	r           database.AnimalRepository
+	sp          database.SpeciesRepository
+	enc         database.EnclosureRepository
+	feed        database.FeedingRepository
+	med         database.MedicalRepository
+	meas        database.MeasurementRepository
+	lin         database.LineageRepository
+	moods       database.MoodRepository
+	obs         database.ObservationRepository
 	pgContainer *postgres.PostgresContainer
 	ctx         context.Context
 }
@@ -27,7 +43,6 @@ func (suite *RepositoryTestSuite) SetupSuite() {
 	var err error
 	suite.pgContainer, err = postgres.RunContainer(suite.ctx,
 		testcontainers.WithImage("postgres:15.3-alpine"),
-		postgres.WithInitScripts(filepath.Join("..", "..", "testdata", "init-db.sql")),
 		postgres.WithDatabase("test-db"),
 		postgres.WithUsername("postgres"),
 		postgres.WithPassword("postgres"),
@@ -35,14 +50,39 @@ func (suite *RepositoryTestSuite) SetupSuite() {
 			wait.ForLog("database system is ready to accept connections").
 				WithOccurrence(2).WithStartupTimeout(5*time.Second)),
 	)
-	suite.NoError(err)
+	suite.Require().NoError(err, "integration tests need a container provider such as Docker")
 	connStr, err := suite.pgContainer.ConnectionString(suite.ctx, "sslmode=disable")
 
 	suite.NoError(err)
 	p, err := pgxpool.New(suite.ctx, connStr)
 	suite.NoError(err)
+
+	m, err := database.NewMigrator(p)
+	suite.Require().NoError(err)
+	_, err = m.Up(suite.ctx)
+	suite.Require().NoError(err)
+	seed, err := os.ReadFile(filepath.Join("..", "..", "testdata", "init-db.sql"))
+	suite.Require().NoError(err)
+	_, err = p.Exec(suite.ctx, string(seed))
+	suite.Require().NoError(err)
 	suite.r, err = database.NewAnimalRepository(suite.ctx, p)
 	suite.NoError(err)
+	suite.sp, err = database.NewSpeciesRepository(suite.ctx, p)
+	suite.NoError(err)
+	suite.enc, err = database.NewEnclosureRepository(suite.ctx, p)
+	suite.NoError(err)
+	suite.feed, err = database.NewFeedingRepository(suite.ctx, p)
+	suite.NoError(err)
+	suite.med, err = database.NewMedicalRepository(suite.ctx, p)
+	suite.NoError(err)
+	suite.meas, err = database.NewMeasurementRepository(suite.ctx, p)
+	suite.NoError(err)
+	suite.lin, err = database.NewLineageRepository(suite.ctx, p)
+	suite.NoError(err)
+	suite.moods, err = database.NewMoodRepository(suite.ctx, p)
+	suite.NoError(err)
+	suite.obs, err = database.NewObservationRepository(suite.ctx, p)
+	suite.NoError(err)
 
 }
 
@@ -54,12 +94,13 @@ func (s *RepositoryTestSuite) TearDownSuite() {
 func (s *RepositoryTestSuite) TestCreateAnimals() {
 	//given
 	expAnimalFull := &models.Animal{
-		IdAnim:  10500,
-		NameAn:  "star",
-		Age:     23,
-		Gender:  "f",
-		Title:   "cat",
-		Descrip: "pp"}
+		IdAnim:         10500,
+		NameAn:         "star",
+		BirthDate:      date(2003, 7, 1),
+		BirthPrecision: "month",
+		Gender:         "f",
+		Title:          "cat",
+		Descrip:        "pp"}
 	//when
 	id, err := s.r.Add(s.ctx, expAnimalFull)
 	//then
@@ -70,7 +111,8 @@ func (s *RepositoryTestSuite) TestCreateAnimals() {
 	s.NoError(err)
 	s.NotNil(animalFull)
 	s.Equal(expAnimalFull.NameAn, animalFull.NameAn)
-	s.Equal(expAnimalFull.Age, animalFull.Age)
+	s.Equal(expAnimalFull.BirthDate, animalFull.BirthDate)
+	s.Equal(expAnimalFull.BirthPrecision, animalFull.BirthPrecision)
 	s.Equal(expAnimalFull.Gender, animalFull.Gender)
 	s.Equal(expAnimalFull.Title, animalFull.Title)
 	s.Equal("The party gave out a bowl of rice and a cat wife", animalFull.Descrip)
@@ -96,7 +138,8 @@ func (s *RepositoryTestSuite) TestGetAnimals() {
 	s.NoError(err)
 	s.NotNil(animalFull)
 	s.Equal("Wahaha", animalFull.NameAn)
-	s.Equal(1, animalFull.Age)
+	s.Equal(date(2025, 6, 1), animalFull.BirthDate)
+	s.Equal("month", animalFull.BirthPrecision)
 	s.Equal("m", animalFull.Gender)
 	s.Equal("cat", animalFull.Title)
 	s.Equal("The party gave out a bowl of rice and a cat wife", animalFull.Descrip)
@@ -113,7 +156,7 @@ func (s *RepositoryTestSuite) TestGetAnimalsWithError() {
 
 func (s *RepositoryTestSuite) TestGetAllAnimals() {
 	//when
-	animals, err := s.r.GetAll(s.ctx, 2, 3)
+	animals, err := s.r.GetAll(s.ctx, database.AnimalQuery{After: &database.AnimalCursor{ID: 2}, Limit: 3})
 	//then
 	s.NoError(err)
 	s.NotNil(animals)
@@ -122,13 +165,36 @@ func (s *RepositoryTestSuite) TestGetAllAnimals() {
 
 func (s *RepositoryTestSuite) TestGetAllAnimalsWithoutRows() {
 	//when
-	animals, err := s.r.GetAll(s.ctx, 123, 300)
+	animals, err := s.r.GetAll(s.ctx, database.AnimalQuery{After: &database.AnimalCursor{ID: 123}, Limit: 300})
 	//then
 	s.NoError(err)
 	s.NotNil(animals)
 	s.Equal(0, len(animals))
 }
 
+func (s *RepositoryTestSuite) TestGetAllAnimalsFiltered() {
+	//given
+	bornBefore := date(2025, 1, 1)
+	q := database.AnimalQuery{
+		Filter: models.AnimalFilter{Species: "cat", Gender: "f", NamePrefix: "Z", BornBefore: &bornBefore},
+		Order:  models.AnimalOrder{Field: models.SortByAge, Desc: true},
+		Limit:  10,
+	}
+	//when
+	animals, err := s.r.GetAll(s.ctx, q)
+	//then
+	s.NoError(err)
+	s.Require().Len(animals, 2)
+	s.Equal("Zu", animals[0].NameAn)
+	s.Equal("Zina", animals[1].NameAn)
+
+	q.After = &database.AnimalCursor{ID: animals[0].IdAnim, BirthDate: animals[0].BirthDate}
+	animals, err = s.r.GetAll(s.ctx, q)
+	s.NoError(err)
+	s.Require().Len(animals, 1)
+	s.Equal("Zina", animals[0].NameAn)
+}
+
 func (s *RepositoryTestSuite) TestDeleteAnimals() {
 	//when
 	err := s.r.Delete(s.ctx, 1)
@@ -141,12 +207,12 @@ func (s *RepositoryTestSuite) TestDeleteAnimals() {
 
 func (s *RepositoryTestSuite) TestDeleteWithoutAnimals() {
 	//when
-	beforeArr, _ := s.r.GetAll(s.ctx, 0, 600)
+	beforeArr, _ := s.r.GetAll(s.ctx, database.AnimalQuery{Limit: 600})
 	err := s.r.Delete(s.ctx, 500)
 	//then
-	s.NoError(err)
+	s.ErrorIs(err, database.ErrNotFound)
 
-	afterArr, _ := s.r.GetAll(s.ctx, 0, 600)
+	afterArr, _ := s.r.GetAll(s.ctx, database.AnimalQuery{Limit: 600})
 	s.Equal(len(beforeArr), len(afterArr))
 
 }
@@ -154,12 +220,13 @@ func (s *RepositoryTestSuite) TestDeleteWithoutAnimals() {
 func (s *RepositoryTestSuite) TestUpdateAnimals() {
 	//given
 	individ := models.Animal{
-		IdAnim:  3,
-		NameAn:  "star",
-		Age:     60,
-		Gender:  "m",
-		Title:   "rat",
-		Descrip: "pp"}
+		IdAnim:         3,
+		NameAn:         "star",
+		BirthDate:      date(1966, 1, 1),
+		BirthPrecision: "year",
+		Gender:         "m",
+		Title:          "rat",
+		Descrip:        "pp"}
 	//when
 	s.r.Update(s.ctx, &individ)
 	//then
@@ -168,7 +235,7 @@ func (s *RepositoryTestSuite) TestUpdateAnimals() {
 	s.NoError(err)
 	s.NotNil(animalFull)
 	s.Equal("star", animalFull.NameAn)
-	s.Equal(60, animalFull.Age)
+	s.Equal(date(1966, 1, 1), animalFull.BirthDate)
 	s.Equal("m", animalFull.Gender)
 	s.Equal("rat", animalFull.Title)
 	s.Equal("You are a rat, and I am a rat", animalFull.Descrip)
@@ -178,15 +245,16 @@ func (s *RepositoryTestSuite) TestUpdateAnimals() {
 func (s *RepositoryTestSuite) TestUpdateWithoutAnimals() {
 	//given
 	individ := models.Animal{
-		IdAnim:  666,
-		NameAn:  "star",
-		Age:     60,
-		Gender:  "m",
-		Title:   "rat",
-		Descrip: "pp"}
+		IdAnim:         666,
+		NameAn:         "star",
+		BirthDate:      date(1966, 1, 1),
+		BirthPrecision: "year",
+		Gender:         "m",
+		Title:          "rat",
+		Descrip:        "pp"}
 	//when
 	err := s.r.Update(s.ctx, &individ)
-	s.NoError(err)
+	s.ErrorIs(err, database.ErrNotFound)
 	//then
 	animalFull, _ := s.r.Get(s.ctx, 666)
 	animalFullEmpty := &models.Animal{}
@@ -198,12 +266,13 @@ func (s *RepositoryTestSuite) TestUpdateAnimalsErrorTitle() {
 	//given
 	exAnimalFull, _ := s.r.Get(s.ctx, 2)
 	individ := models.Animal{
-		IdAnim:  2,
-		NameAn:  "star",
-		Age:     60,
-		Gender:  "m",
-		Title:   "fox",
-		Descrip: "pp"}
+		IdAnim:         2,
+		NameAn:         "star",
+		BirthDate:      date(1966, 1, 1),
+		BirthPrecision: "year",
+		Gender:         "m",
+		Title:          "fox",
+		Descrip:        "pp"}
 	//when
 	err := s.r.Update(s.ctx, &individ)
 	s.Error(err)
@@ -213,6 +282,315 @@ func (s *RepositoryTestSuite) TestUpdateAnimalsErrorTitle() {
 
 }
 
+func (s *RepositoryTestSuite) TestSpeciesCRUD() {
+	//given
+	owl := &models.Specie{Title: "owl", Descrip: "Hoo"}
+	//when
+	id, err := s.sp.Add(s.ctx, owl)
+	s.NoError(err)
+	owl.IdSp = id
+	owl.Title = "eagle owl"
+	s.NoError(s.sp.Update(s.ctx, owl))
+	//then
+	got, err := s.sp.Get(s.ctx, id)
+	s.NoError(err)
+	s.Equal(owl, got)
+
+	s.NoError(s.sp.Delete(s.ctx, id))
+	_, err = s.sp.Get(s.ctx, id)
+	s.ErrorIs(err, database.ErrSpeciesNotFound)
+}
+
+func (s *RepositoryTestSuite) TestSpeciesDuplicateTitle() {
+	_, err := s.sp.Add(s.ctx, &models.Specie{Title: "dog", Descrip: "another dog"})
+	s.ErrorIs(err, database.ErrSpeciesExists)
+}
+
+func (s *RepositoryTestSuite) TestSpeciesDeleteInUse() {
+	all, err := s.sp.List(s.ctx)
+	s.NoError(err)
+	for _, sp := range all {
+		if sp.Title == "cat" {
+			s.ErrorIs(s.sp.Delete(s.ctx, sp.IdSp), database.ErrSpeciesInUse)
+		}
+	}
+}
+
+func (s *RepositoryTestSuite) TestEnclosureCapacity() {
+	id, err := s.enc.Add(s.ctx, &models.Enclosure{
+		Name: "Cat house", Zone: "north", Capacity: 1, Habitat: "forest", Species: []string{"cat", "dog"},
+	})
+	s.Require().NoError(err)
+	_, err = s.enc.Add(s.ctx, &models.Enclosure{Name: "Cat house", Zone: "south", Capacity: 1, Habitat: "forest"})
+	s.ErrorIs(err, database.ErrEnclosureExists)
+
+	a1, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Lodger", BirthDate: date(2025, 1, 1), BirthPrecision: "exact", Gender: "m", Title: "cat"})
+	s.Require().NoError(err)
+	a2, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Latecomer", BirthDate: date(2025, 1, 1), BirthPrecision: "exact", Gender: "m", Title: "cat"})
+	s.Require().NoError(err)
+
+	s.NoError(s.r.SetEnclosure(s.ctx, a1, &id))
+	s.ErrorIs(s.r.SetEnclosure(s.ctx, a2, &id), database.ErrEnclosureFull)
+	s.ErrorIs(s.enc.Delete(s.ctx, id), database.ErrEnclosureInUse)
+
+	enc, err := s.enc.Get(s.ctx, id)
+	s.Require().NoError(err)
+	s.Equal([]string{"cat", "dog"}, enc.Species)
+	s.Equal(1, enc.Occupancy)
+	got, err := s.r.Get(s.ctx, a1)
+	s.Require().NoError(err)
+	s.Equal(&id, got.IdEncl)
+
+	s.NoError(s.r.SetEnclosure(s.ctx, a1, nil))
+	s.NoError(s.enc.Delete(s.ctx, id))
+}
+
+func (s *RepositoryTestSuite) TestFeedings() {
+	an, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Hungry", BirthDate: date(2025, 1, 1), BirthPrecision: "exact", Gender: "m", Title: "cat"})
+	s.Require().NoError(err)
+	_, err = s.feed.AddPlan(s.ctx, &models.FeedingPlan{Species: "parrot", Food: "seeds", Quantity: 1, Unit: "g", Times: []string{"08:00"}})
+	s.ErrorIs(err, errs.ErrUnknownSpecies)
+	idPlan, err := s.feed.AddPlan(s.ctx, &models.FeedingPlan{Species: "cat", Food: "fish", Quantity: 200, Unit: "g", Times: []string{"08:00", "18:00"}})
+	s.Require().NoError(err)
+	plan, err := s.feed.GetPlan(s.ctx, idPlan)
+	s.Require().NoError(err)
+	s.Equal(&models.FeedingPlan{IdPlan: idPlan, Species: "cat", Food: "fish", Quantity: 200, Unit: "g", Times: []string{"08:00", "18:00"}}, plan)
+
+	missing := int64(100500)
+	_, err = s.feed.AddFeeding(s.ctx, &models.Feeding{IdAnim: an, IdPlan: &missing, Food: "fish", Quantity: 1, Unit: "g", Keeper: "Ivan", FedAt: time.Now()})
+	s.ErrorIs(err, database.ErrFeedingPlanNotFound)
+	day := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		_, err := s.feed.AddFeeding(s.ctx, &models.Feeding{IdAnim: an, IdPlan: &idPlan, Food: "fish", Quantity: 200, Unit: "g", Keeper: "Ivan", FedAt: day.AddDate(0, 0, i)})
+		s.Require().NoError(err)
+	}
+	feeds, err := s.feed.ListFeedings(s.ctx, models.FeedingFilter{IdAnim: an, To: day.AddDate(0, 0, 2), Limit: 10})
+	s.Require().NoError(err)
+	s.Require().Len(feeds, 2)
+	s.True(day.AddDate(0, 0, 1).Equal(feeds[0].FedAt))
+	latest, err := s.feed.LatestFeedings(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(latest, 1)
+	s.True(day.AddDate(0, 0, 2).Equal(latest[0].FedAt))
+
+	s.NoError(s.feed.DeletePlan(s.ctx, idPlan))
+	s.ErrorIs(s.feed.DeletePlan(s.ctx, idPlan), database.ErrFeedingPlanNotFound)
+	s.NoError(s.r.Delete(s.ctx, an))
+}
+
+func (s *RepositoryTestSuite) TestMedicalHistory() {
+	an, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Patient", BirthDate: date(2025, 1, 1), BirthPrecision: "exact", Gender: "m", Title: "cat"})
+	s.Require().NoError(err)
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	idRec, err := s.med.AddRecord(s.ctx, &models.MedicalRecord{IdAnim: an, Kind: "treatment", Title: "bandage", Vet: "Dr. Aibolit", RecordedAt: day})
+	s.Require().NoError(err)
+	missing := int64(100500)
+	_, err = s.med.AddMedication(s.ctx, &models.Medication{IdAnim: an, IdRec: &missing, Drug: "meloxicam", Dose: 1, DoseUnit: "mg", StartedAt: day, Days: 3, Vet: "Dr. Aibolit"})
+	s.ErrorIs(err, database.ErrMedicalRecordNotFound)
+	_, err = s.med.AddMedication(s.ctx, &models.Medication{IdAnim: an, IdRec: &idRec, Drug: "meloxicam", Dose: 1, DoseUnit: "mg", StartedAt: day, Days: 3, Vet: "Dr. Aibolit"})
+	s.Require().NoError(err)
+	for i, due := range []time.Time{day.AddDate(0, 1, 0), day.AddDate(1, 0, 0)} {
+		_, err := s.med.AddVaccination(s.ctx, &models.Vaccination{IdAnim: an, Vaccine: "rabies", GivenAt: day.AddDate(0, 0, i), DueAt: &due, Vet: "Dr. Aibolit"})
+		s.Require().NoError(err)
+	}
+
+	h, err := s.med.History(s.ctx, models.MedicalFilter{IdAnim: an})
+	s.Require().NoError(err)
+	s.Len(h.Records, 1)
+	s.Require().Len(h.Medications, 1)
+	s.Equal(&idRec, h.Medications[0].IdRec)
+	s.Len(h.Vaccinations, 2)
+
+	// ранний срок перекрыт повторной прививкой
+	due, err := s.med.DueVaccinations(s.ctx, day.AddDate(0, 6, 0))
+	s.Require().NoError(err)
+	s.Empty(due)
+	due, err = s.med.DueVaccinations(s.ctx, day.AddDate(2, 0, 0))
+	s.Require().NoError(err)
+	s.Require().Len(due, 1)
+	s.Equal("Patient", due[0].NameAn)
+
+	s.NoError(s.r.Delete(s.ctx, an))
+	h, err = s.med.History(s.ctx, models.MedicalFilter{IdAnim: an})
+	s.Require().NoError(err)
+	s.Empty(h.Vaccinations)
+}
+
+func (s *RepositoryTestSuite) TestMeasurements() {
+	an, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Measured", BirthDate: date(2025, 1, 1), BirthPrecision: "exact", Gender: "m", Title: "cat"})
+	s.Require().NoError(err)
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	kg, bcs := 4.2, 5
+
+	_, err = s.meas.Add(s.ctx, &models.Measurement{IdAnim: an, MeasuredAt: day})
+	s.Error(err, "at least one value is required by the schema")
+	for i := range 3 {
+		_, err := s.meas.Add(s.ctx, &models.Measurement{IdAnim: an, MeasuredAt: day.AddDate(0, 0, i), WeightKg: &kg, BodyCondition: &bcs})
+		s.Require().NoError(err)
+	}
+	ms, err := s.meas.List(s.ctx, models.MeasurementFilter{IdAnim: an, From: day.AddDate(0, 0, 1)})
+	s.Require().NoError(err)
+	s.Require().Len(ms, 2)
+	s.True(day.AddDate(0, 0, 1).Equal(ms[0].MeasuredAt))
+	s.Equal(&kg, ms[0].WeightKg)
+	s.Equal(&bcs, ms[0].BodyCondition)
+	s.Nil(ms[0].LengthCm)
+
+	latest, err := s.meas.Latest(s.ctx, an)
+	s.Require().NoError(err)
+	s.Require().NotNil(latest)
+	s.True(day.AddDate(0, 0, 2).Equal(latest.MeasuredAt))
+	latest, err = s.meas.Latest(s.ctx, -1)
+	s.NoError(err)
+	s.Nil(latest)
+
+	ms, err = s.meas.Since(s.ctx, day.AddDate(0, 0, 2))
+	s.Require().NoError(err)
+	s.Len(ms, 1)
+
+	s.NoError(s.meas.Delete(s.ctx, ms[0].IdMeas))
+	s.ErrorIs(s.meas.Delete(s.ctx, ms[0].IdMeas), database.ErrMeasurementNotFound)
+	s.NoError(s.r.Delete(s.ctx, an))
+}
+
+func (s *RepositoryTestSuite) TestLineage() {
+	mother, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Mother", BirthDate: date(2015, 1, 1), BirthPrecision: "year", Gender: "f", Title: "cat"})
+	s.Require().NoError(err)
+	kid, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Kid", BirthDate: date(2020, 1, 1), BirthPrecision: "year", Gender: "m", Title: "cat"})
+	s.Require().NoError(err)
+	missing := int64(100500)
+
+	s.ErrorIs(s.lin.SetParents(s.ctx, kid, models.Parent{IdAnim: &missing}, models.Parent{}), database.ErrParentNotFound)
+	s.Require().NoError(s.lin.SetParents(s.ctx, kid, models.Parent{IdAnim: &mother}, models.Parent{External: "EEP 7"}))
+
+	kin, err := s.lin.Kin(s.ctx, []int64{kid, mother})
+	s.Require().NoError(err)
+	s.Require().Len(kin, 2)
+	s.Equal(models.Parent{}, kin[0].Mother)
+	s.Equal(models.Parent{IdAnim: &mother}, kin[1].Mother)
+	s.Equal(models.Parent{External: "EEP 7"}, kin[1].Father)
+
+	children, err := s.lin.Children(s.ctx, []int64{mother})
+	s.Require().NoError(err)
+	s.Require().Len(children, 1)
+	s.Equal("Kid", children[0].NameAn)
+
+	s.ErrorIs(s.r.Delete(s.ctx, mother), database.ErrAnimalHasOffspring)
+	s.NoError(s.r.Delete(s.ctx, kid))
+	s.NoError(s.r.Delete(s.ctx, mother))
+}
+
+func (s *RepositoryTestSuite) TestMoods() {
+	id, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Moody", BirthDate: date(2019, 1, 1), BirthPrecision: "year", Gender: "m", Title: "dog"})
+	s.Require().NoError(err)
+	now := time.Now().Truncate(time.Second)
+	for i, m := range []models.MoodEntry{
+		{IdAnim: id, Mood: "calm", Source: models.MoodSourceComputed, ObservedAt: now.Add(-3 * time.Hour)},
+		{IdAnim: id, Mood: "playful", Source: models.MoodSourceKeeper, Keeper: "Ivan", ObservedAt: now.Add(-2 * time.Hour)},
+		{IdAnim: id, Mood: "calm", Source: models.MoodSourceComputed, ObservedAt: now.Add(-time.Hour)},
+	} {
+		_, err := s.moods.Add(s.ctx, &m)
+		s.Require().NoError(err, i)
+	}
+	_, err = s.moods.Add(s.ctx, &models.MoodEntry{IdAnim: 100500, Mood: "calm", Source: models.MoodSourceKeeper, ObservedAt: now})
+	s.ErrorIs(err, database.ErrNotFound)
+
+	last, err := s.moods.List(s.ctx, models.MoodFilter{IdAnim: id, Source: models.MoodSourceComputed, Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(last, 1)
+	s.True(now.Add(-time.Hour).Equal(last[0].ObservedAt))
+
+	all, err := s.moods.List(s.ctx, models.MoodFilter{IdAnim: id, From: now.Add(-150 * time.Minute)})
+	s.Require().NoError(err)
+	s.Len(all, 2)
+
+	counts, err := s.moods.Distribution(s.ctx, models.MoodDistributionFilter{GroupBy: models.MoodBySpecies, From: now.Add(-4 * time.Hour)})
+	s.Require().NoError(err)
+	s.Contains(counts, models.MoodCount{Group: "dog", Mood: "calm", Count: 2})
+	s.Contains(counts, models.MoodCount{Group: "dog", Mood: "playful", Count: 1})
+	s.NoError(s.r.Delete(s.ctx, id))
+}
+
+func (s *RepositoryTestSuite) TestObservations() {
+	id, err := s.r.Add(s.ctx, &models.Animal{NameAn: "Watched", BirthDate: date(2019, 1, 1), BirthPrecision: "year", Gender: "f", Title: "cat"})
+	s.Require().NoError(err)
+	now := time.Now().Truncate(time.Second)
+	_, err = s.obs.Add(s.ctx, &models.Observation{IdAnim: id, Keeper: "Ivan", ObservedAt: now.Add(-time.Hour), Stereotypic: true, Severity: "medium", Tags: []string{"fence", "pacing"}})
+	s.Require().NoError(err)
+	_, err = s.obs.Add(s.ctx, &models.Observation{IdAnim: id, Keeper: "Olga", ObservedAt: now, Appetite: "good", Severity: "info"})
+	s.Require().NoError(err)
+	_, err = s.obs.Add(s.ctx, &models.Observation{IdAnim: 100500, Keeper: "Olga", ObservedAt: now, Severity: "info", Notes: "ghost"})
+	s.ErrorIs(err, database.ErrNotFound)
+
+	obs, err := s.obs.List(s.ctx, models.ObservationFilter{Tag: "pacing"})
+	s.Require().NoError(err)
+	s.Require().Len(obs, 1)
+	s.Equal([]string{"fence", "pacing"}, obs[0].Tags)
+	s.True(obs[0].Stereotypic)
+
+	obs, err = s.obs.List(s.ctx, models.ObservationFilter{IdAnim: &id, Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(obs, 1)
+	s.Equal("Olga", obs[0].Keeper)
+	s.Equal([]string{}, obs[0].Tags)
+
+	obs, err = s.obs.List(s.ctx, models.ObservationFilter{Keeper: "Ivan", From: now})
+	s.Require().NoError(err)
+	s.Empty(obs)
+	s.NoError(s.r.Delete(s.ctx, id))
+}
+
+func (s *RepositoryTestSuite) TestMigrationsAreIdempotent() {
+	p, err := pgxpool.New(s.ctx, s.connStr())
+	s.Require().NoError(err)
+	defer p.Close()
+	m, err := database.NewMigrator(p)
+	s.Require().NoError(err)
+
+	n, err := m.Up(s.ctx)
+	s.NoError(err)
+	s.Equal(0, n)
+	statuses, err := m.Status(s.ctx)
+	s.NoError(err)
+	for _, st := range statuses {
+		s.NotNil(st.AppliedAt, st.Name)
+	}
+}
+
+func (s *RepositoryTestSuite) TestMigrationsRollBackAndReapply() {
+	p, err := pgxpool.New(s.ctx, s.connStr())
+	s.Require().NoError(err)
+	defer p.Close()
+	// отдельная база, чтобы откат не задел данные остальных тестов
+	_, err = p.Exec(s.ctx, "CREATE DATABASE migrations_roundtrip")
+	s.Require().NoError(err)
+	fresh, err := pgxpool.New(s.ctx, strings.Replace(s.connStr(), "/test-db?", "/migrations_roundtrip?", 1))
+	s.Require().NoError(err)
+	defer fresh.Close()
+	m, err := database.NewMigrator(fresh)
+	s.Require().NoError(err)
+
+	n, err := m.Up(s.ctx)
+	s.Require().NoError(err)
+	s.Positive(n)
+	for range n {
+		_, err := m.Down(s.ctx)
+		s.Require().NoError(err)
+	}
+	_, err = m.Down(s.ctx)
+	s.ErrorIs(err, database.ErrNoMigrations)
+	again, err := m.Up(s.ctx)
+	s.NoError(err)
+	s.Equal(n, again)
+}
+
+func (s *RepositoryTestSuite) connStr() string {
+	connStr, err := s.pgContainer.ConnectionString(s.ctx, "sslmode=disable")
+	s.Require().NoError(err)
+	return connStr
+}
+
 func TestCustomerRepoTestSuite(t *testing.T) {
 	suite.Run(t, new(RepositoryTestSuite))
 }
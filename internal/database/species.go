@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	selectSpecies         = "SELECT id_sp, title, descrip FROM Species ORDER BY title"
+	selectSpecie          = "SELECT id_sp, title, descrip FROM Species WHERE id_sp = $1"
+	insertSpecie          = "INSERT INTO Species (title, descrip) VALUES($1, $2) RETURNING id_sp"
+	updateSpecie          = "UPDATE Species SET title = $1, descrip = $2 WHERE id_sp = $3"
+	deleteSpecie          = "DELETE FROM Species WHERE id_sp = $1"
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+var (
+	ErrSpeciesNotFound error = errs.NotFound("species not found")
+	ErrSpeciesExists   error = errs.Conflict("species with this title already exists")
+	ErrSpeciesInUse    error = errs.Conflict("species is referenced by animals")
+)
+
+type SpeciesRepository interface {
+	List(ctx context.Context) ([]models.Specie, error)
+	Get(ctx context.Context, idSp int64) (*models.Specie, error)
+	Add(ctx context.Context, sp *models.Specie) (int64, error)
+	Update(ctx context.Context, sp *models.Specie) error
+	Delete(ctx context.Context, idSp int64) error
+}
+
+type PgSpeciesRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSpeciesRepository(ctx context.Context, p *pgxpool.Pool) (*PgSpeciesRepository, error) {
+	return &PgSpeciesRepository{pool: p}, nil
+}
+
+func (r *PgSpeciesRepository) List(ctx context.Context) ([]models.Specie, error) {
+	rows, err := r.pool.Query(ctx, selectSpecies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	species := make([]models.Specie, 0)
+	for rows.Next() {
+		var sp models.Specie
+		if err := rows.Scan(&sp.IdSp, &sp.Title, &sp.Descrip); err != nil {
+			return nil, err
+		}
+		species = append(species, sp)
+	}
+	return species, rows.Err()
+}
+
+func (r *PgSpeciesRepository) Get(ctx context.Context, idSp int64) (*models.Specie, error) {
+	var sp models.Specie
+	err := r.pool.QueryRow(ctx, selectSpecie, idSp).Scan(&sp.IdSp, &sp.Title, &sp.Descrip)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSpeciesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sp, nil
+}
+
+func (r *PgSpeciesRepository) Add(ctx context.Context, sp *models.Specie) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, insertSpecie, sp.Title, sp.Descrip).Scan(&id)
+	if err != nil {
+		return -1, speciesError(err)
+	}
+	return id, nil
+}
+
+func (r *PgSpeciesRepository) Update(ctx context.Context, sp *models.Specie) error {
+	tag, err := r.pool.Exec(ctx, updateSpecie, sp.Title, sp.Descrip, sp.IdSp)
+	if err != nil {
+		return speciesError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSpeciesNotFound
+	}
+	return nil
+}
+
+// Delete не дает удалить вид, пока на него ссылаются животные -
+// проверку делает внешний ключ Animals.id_sp, так что гонки с вставкой нет
+func (r *PgSpeciesRepository) Delete(ctx context.Context, idSp int64) error {
+	tag, err := r.pool.Exec(ctx, deleteSpecie, idSp)
+	if err != nil {
+		return speciesError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSpeciesNotFound
+	}
+	return nil
+}
+
+func speciesError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return ErrSpeciesExists
+		case pgForeignKeyViolation:
+			return ErrSpeciesInUse
+		}
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	selectUsers      = "SELECT id_user, name, role, created_at FROM Users ORDER BY name"
+	selectUser       = "SELECT id_user, name, role, created_at FROM Users WHERE id_user = $1"
+	selectUserByName = "SELECT id_user, name, role, created_at FROM Users WHERE name = $1"
+	insertUser       = "INSERT INTO Users (name, role) VALUES($1, $2) RETURNING id_user, created_at"
+	updateUser       = "UPDATE Users SET name = $1, role = $2 WHERE id_user = $3"
+	deleteUser       = "DELETE FROM Users WHERE id_user = $1"
+)
+
+var (
+	ErrUserNotFound error = errs.NotFound("user not found")
+	ErrUserExists   error = errs.Conflict("user with this name already exists")
+)
+
+type UserRepository interface {
+	List(ctx context.Context) ([]models.User, error)
+	Get(ctx context.Context, idUser int64) (*models.User, error)
+	GetByName(ctx context.Context, name string) (*models.User, error)
+	// Add заполняет IdUser и CreatedAt
+	Add(ctx context.Context, u *models.User) error
+	Update(ctx context.Context, u *models.User) error
+	Delete(ctx context.Context, idUser int64) error
+}
+
+type PgUserRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewUserRepository(ctx context.Context, p *pgxpool.Pool) (*PgUserRepository, error) {
+	return &PgUserRepository{pool: p}, nil
+}
+
+func (r *PgUserRepository) List(ctx context.Context) ([]models.User, error) {
+	rows, err := r.pool.Query(ctx, selectUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]models.User, 0)
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.IdUser, &u.Name, &u.Role, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (r *PgUserRepository) Get(ctx context.Context, idUser int64) (*models.User, error) {
+	return r.get(ctx, selectUser, idUser)
+}
+
+func (r *PgUserRepository) GetByName(ctx context.Context, name string) (*models.User, error) {
+	return r.get(ctx, selectUserByName, name)
+}
+
+func (r *PgUserRepository) get(ctx context.Context, query string, arg any) (*models.User, error) {
+	var u models.User
+	err := r.pool.QueryRow(ctx, query, arg).Scan(&u.IdUser, &u.Name, &u.Role, &u.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *PgUserRepository) Add(ctx context.Context, u *models.User) error {
+	err := r.pool.QueryRow(ctx, insertUser, u.Name, u.Role).Scan(&u.IdUser, &u.CreatedAt)
+	return userError(err)
+}
+
+func (r *PgUserRepository) Update(ctx context.Context, u *models.User) error {
+	tag, err := r.pool.Exec(ctx, updateUser, u.Name, u.Role, u.IdUser)
+	if err != nil {
+		return userError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *PgUserRepository) Delete(ctx context.Context, idUser int64) error {
+	tag, err := r.pool.Exec(ctx, deleteUser, idUser)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func userError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return ErrUserExists
+	}
+	return err
+}
@@ -32,6 +32,11 @@ func (e arrError) Error() string {
 	return s
 }
 
+// Unwrap позволяет искать причину через errors.Is/As
+func (e arrError) Unwrap() []error {
+	return e
+}
+
 type paralleRun struct {
 	mux sync.Mutex
 	wg  sync.WaitGroup
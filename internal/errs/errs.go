@@ -0,0 +1,152 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind - категория доменной ошибки, по ней транспорт выбирает HTTP- и gRPC-код
+type Kind uint8
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindUnknownSpecies
+	KindValidation
+	KindConflict
+	KindBadRequest
+	KindUnauthenticated
+	KindForbidden
+)
+
+var kindNames = [...]string{
+	KindInternal:        "internal",
+	KindNotFound:        "not_found",
+	KindUnknownSpecies:  "unknown_species",
+	KindValidation:      "validation",
+	KindConflict:        "conflict",
+	KindBadRequest:      "bad_request",
+	KindUnauthenticated: "unauthenticated",
+	KindForbidden:       "forbidden",
+}
+
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return kindNames[KindInternal]
+}
+
+// Error - ошибка, которую слои repository и service отдают наружу.
+// Message и Fields уходят клиенту как есть, Err - только в лог
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// FieldError - нарушение одного правила проверки в одном поле
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// Сравнение через errors.Is с ними проверяет только Kind
+var (
+	ErrNotFound        = &Error{Kind: KindNotFound}
+	ErrUnknownSpecies  = &Error{Kind: KindUnknownSpecies}
+	ErrValidation      = &Error{Kind: KindValidation}
+	ErrConflict        = &Error{Kind: KindConflict}
+	ErrBadRequest      = &Error{Kind: KindBadRequest}
+	ErrUnauthenticated = &Error{Kind: KindUnauthenticated}
+	ErrForbidden       = &Error{Kind: KindForbidden}
+)
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Kind.String()
+	}
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Message == "" && t.Err == nil && t.Fields == nil && t.Kind == e.Kind
+}
+
+func New(kind Kind, format string, args ...any) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+func NotFound(format string, args ...any) *Error {
+	return New(KindNotFound, format, args...)
+}
+
+func UnknownSpecies(title string) *Error {
+	return New(KindUnknownSpecies, "unknown species %q", title)
+}
+
+func Validation(format string, args ...any) *Error {
+	return New(KindValidation, format, args...)
+}
+
+// Invalid - ошибка проверки со списком всех нарушений сразу
+func Invalid(fields []FieldError) *Error {
+	return &Error{Kind: KindValidation, Message: "validation failed", Fields: fields}
+}
+
+func Conflict(format string, args ...any) *Error {
+	return New(KindConflict, format, args...)
+}
+
+func BadRequest(format string, args ...any) *Error {
+	return New(KindBadRequest, format, args...)
+}
+
+func Unauthenticated(format string, args ...any) *Error {
+	return New(KindUnauthenticated, format, args...)
+}
+
+func Forbidden(format string, args ...any) *Error {
+	return New(KindForbidden, format, args...)
+}
+
+// KindOf возвращает KindInternal для всего, что не является *Error
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// Fields возвращает нарушения из ошибки проверки
+func Fields(err error) []FieldError {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Fields
+	}
+	return nil
+}
+
+// Message - текст для клиента: для внутренних ошибок детали не раскрываются
+func Message(err error) string {
+	var e *Error
+	if errors.As(err, &e) && e.Kind != KindInternal {
+		if e.Message != "" {
+			return e.Message
+		}
+		return e.Kind.String()
+	}
+	return "internal error"
+}
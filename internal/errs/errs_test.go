@@ -0,0 +1,24 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mi-raf/zooad/internal/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMatchesKind(t *testing.T) {
+	err := fmt.Errorf("get: %w", errs.NotFound("animal %d not found", 7))
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NotErrorIs(t, err, errs.ErrConflict)
+	assert.Equal(t, errs.KindNotFound, errs.KindOf(err))
+	assert.Equal(t, "animal 7 not found", errs.Message(err))
+}
+
+func TestInternalDetailsAreHidden(t *testing.T) {
+	err := errors.New("connection refused")
+	assert.Equal(t, errs.KindInternal, errs.KindOf(err))
+	assert.Equal(t, "internal error", errs.Message(err))
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mi-raf/zooad/internal"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "zooad"
+
+// Metrics - собственный реестр сервиса, глобальный prometheus.DefaultRegisterer не трогаем,
+// чтобы тесты могли создавать сколько угодно экземпляров
+type Metrics struct {
+	reg *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	grpcRequests *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+	repoDuration *prometheus.HistogramVec
+	checks       *prometheus.CounterVec
+	checkUp      *prometheus.GaugeVec
+	checkLatency *prometheus.GaugeVec
+	overdue      prometheus.Gauge
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		reg: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by route and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "gRPC calls by method and status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "gRPC call latency by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		repoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "query_duration_seconds",
+			Help:      "Repository call latency by repository, method and outcome.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"repository", "method", "outcome"}),
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "service",
+			Name:      "checks_total",
+			Help:      "ServiceKeeper Init and Ping results by service.",
+		}, []string{"service", "status"}),
+		checkUp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "service",
+			Name:      "up",
+			Help:      "1 if the last ServiceKeeper check of the service succeeded.",
+		}, []string{"service"}),
+		checkLatency: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "service",
+			Name:      "check_latency_seconds",
+			Help:      "Latency of the last ServiceKeeper check of the service.",
+		}, []string{"service"}),
+		overdue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "feeding",
+			Name:      "overdue_animals",
+			Help:      "Animals with a missed scheduled feeding at the last check.",
+		}),
+	}
+	m.reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.grpcRequests, m.grpcDuration,
+		m.repoDuration,
+		m.checks, m.checkUp, m.checkLatency,
+		m.overdue,
+	)
+	return m
+}
+
+// Handler отдает метрики в формате prometheus для /metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{Registry: m.reg})
+}
+
+func (m *Metrics) Gatherer() prometheus.Gatherer {
+	return m.reg
+}
+
+// ObserveHTTP - route это шаблон маршрута echo (/animal/:id), а не url,
+// иначе у метрики будет по серии на каждый id
+func (m *Metrics) ObserveHTTP(method, route string, status int, d time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(d.Seconds())
+}
+
+func (m *Metrics) ObserveGRPC(method, code string, d time.Duration) {
+	m.grpcRequests.WithLabelValues(method, code).Inc()
+	m.grpcDuration.WithLabelValues(method, code).Observe(d.Seconds())
+}
+
+func (m *Metrics) observeRepo(repo, method string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	m.repoDuration.WithLabelValues(repo, method, outcome).Observe(time.Since(start).Seconds())
+}
+
+// ObserveCheck подключается к ServiceKeeper.OnCheck
+func (m *Metrics) ObserveCheck(st internal.ServiceStatus) {
+	up := 0.0
+	if st.Status == internal.StatusOK {
+		up = 1
+	}
+	m.checks.WithLabelValues(st.Name, st.Status).Inc()
+	m.checkUp.WithLabelValues(st.Name).Set(up)
+	m.checkLatency.WithLabelValues(st.Name).Set(st.Latency.Seconds())
+}
+
+// ObserveOverdue подключается к FeedingChecker.OnOverdue
+func (m *Metrics) ObserveOverdue(overdue []models.OverdueFeeding) {
+	animals := make(map[int64]struct{}, len(overdue))
+	for _, o := range overdue {
+		animals[o.IdAnim] = struct{}{}
+	}
+	m.overdue.Set(float64(len(animals)))
+}
+
+// Register добавляет сторонний коллектор, например статистику пула
+func (m *Metrics) Register(c prometheus.Collector) error {
+	return m.reg.Register(c)
+}
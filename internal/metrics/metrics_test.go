@@ -0,0 +1,54 @@
+package metrics_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal"
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/metrics"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryDurations(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	st := database.NewMemStorage()
+	species := metrics.NewSpeciesRepository(database.NewMemSpeciesRepository(st), m)
+	animals := metrics.NewAnimalRepository(database.NewMemAnimalRepository(st), m)
+
+	_, err := species.Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	id, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	_, err = animals.Get(ctx, id)
+	require.NoError(t, err)
+	_, err = animals.Get(ctx, id+1)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	problems, err := testutil.GatherAndLint(m.Gatherer())
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	count, err := testutil.GatherAndCount(m.Gatherer(), "zooad_repository_query_duration_seconds")
+	require.NoError(t, err)
+	// species/Add, animals/Add, animals/Get ok и error
+	require.Equal(t, 4, count)
+}
+
+func TestObserveCheck(t *testing.T) {
+	m := metrics.New()
+	m.ObserveCheck(internal.ServiceStatus{Name: "postgres", Status: internal.StatusOK, Latency: time.Millisecond})
+	m.ObserveCheck(internal.ServiceStatus{Name: "postgres", Status: internal.StatusFailing, Error: "refused"})
+
+	expected := `
+# HELP zooad_service_up 1 if the last ServiceKeeper check of the service succeeded.
+# TYPE zooad_service_up gauge
+zooad_service_up{service="postgres"} 0
+`
+	require.NoError(t, testutil.GatherAndCompare(m.Gatherer(), strings.NewReader(expected), "zooad_service_up"))
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector снимает pgxpool.Stat в момент scrape
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquired, idle, total, constructing, max *prometheus.Desc
+	acquireCount, emptyAcquire, canceled     *prometheus.Desc
+	acquireDuration, newConns                *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "pgxpool", name), help, nil, nil)
+	}
+	return &PoolCollector{
+		pool:            pool,
+		acquired:        desc("acquired_conns", "Connections currently acquired from the pool."),
+		idle:            desc("idle_conns", "Idle connections in the pool."),
+		total:           desc("total_conns", "Total connections in the pool."),
+		constructing:    desc("constructing_conns", "Connections being established."),
+		max:             desc("max_conns", "Maximum size of the pool."),
+		acquireCount:    desc("acquire_total", "Successful acquires from the pool."),
+		emptyAcquire:    desc("empty_acquire_total", "Acquires that had to wait for a connection."),
+		canceled:        desc("canceled_acquire_total", "Acquires canceled by context."),
+		acquireDuration: desc("acquire_wait_seconds_total", "Total time spent waiting to acquire connections."),
+		newConns:        desc("new_conns_total", "Connections opened by the pool."),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	st := c.pool.Stat()
+	gauge := func(d *prometheus.Desc, v int32) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, float64(v))
+	}
+	counter := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v)
+	}
+	gauge(c.acquired, st.AcquiredConns())
+	gauge(c.idle, st.IdleConns())
+	gauge(c.total, st.TotalConns())
+	gauge(c.constructing, st.ConstructingConns())
+	gauge(c.max, st.MaxConns())
+	counter(c.acquireCount, float64(st.AcquireCount()))
+	counter(c.emptyAcquire, float64(st.EmptyAcquireCount()))
+	counter(c.canceled, float64(st.CanceledAcquireCount()))
+	counter(c.acquireDuration, st.AcquireDuration().Seconds())
+	counter(c.newConns, float64(st.NewConnsCount()))
+}
@@ -0,0 +1,509 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	models "github.com/mi-raf/zooad/internal/models"
+)
+
+// AnimalRepository замеряет вызовы репозитория животных, не меняя их поведения
+type AnimalRepository struct {
+	next database.AnimalRepository
+	m    *Metrics
+}
+
+func NewAnimalRepository(next database.AnimalRepository, m *Metrics) *AnimalRepository {
+	return &AnimalRepository{next: next, m: m}
+}
+
+func (r *AnimalRepository) Delete(ctx context.Context, idAnim int64) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, idAnim)
+	r.m.observeRepo("animals", "Delete", start, err)
+	return err
+}
+
+func (r *AnimalRepository) Add(ctx context.Context, individual *models.Animal) (int64, error) {
+	start := time.Now()
+	id, err := r.next.Add(ctx, individual)
+	r.m.observeRepo("animals", "Add", start, err)
+	return id, err
+}
+
+func (r *AnimalRepository) Get(ctx context.Context, idAnim int64) (*models.Animal, error) {
+	start := time.Now()
+	an, err := r.next.Get(ctx, idAnim)
+	r.m.observeRepo("animals", "Get", start, err)
+	return an, err
+}
+
+func (r *AnimalRepository) GetAll(ctx context.Context, q database.AnimalQuery) ([]models.Animal, error) {
+	start := time.Now()
+	animals, err := r.next.GetAll(ctx, q)
+	r.m.observeRepo("animals", "GetAll", start, err)
+	return animals, err
+}
+
+func (r *AnimalRepository) Update(ctx context.Context, individual *models.Animal) error {
+	start := time.Now()
+	err := r.next.Update(ctx, individual)
+	r.m.observeRepo("animals", "Update", start, err)
+	return err
+}
+
+func (r *AnimalRepository) SetEnclosure(ctx context.Context, idAnim int64, idEncl *int64) error {
+	start := time.Now()
+	err := r.next.SetEnclosure(ctx, idAnim, idEncl)
+	r.m.observeRepo("animals", "SetEnclosure", start, err)
+	return err
+}
+
+// SpeciesRepository - то же для справочника видов
+type SpeciesRepository struct {
+	next database.SpeciesRepository
+	m    *Metrics
+}
+
+func NewSpeciesRepository(next database.SpeciesRepository, m *Metrics) *SpeciesRepository {
+	return &SpeciesRepository{next: next, m: m}
+}
+
+func (r *SpeciesRepository) List(ctx context.Context) ([]models.Specie, error) {
+	start := time.Now()
+	species, err := r.next.List(ctx)
+	r.m.observeRepo("species", "List", start, err)
+	return species, err
+}
+
+func (r *SpeciesRepository) Get(ctx context.Context, idSp int64) (*models.Specie, error) {
+	start := time.Now()
+	sp, err := r.next.Get(ctx, idSp)
+	r.m.observeRepo("species", "Get", start, err)
+	return sp, err
+}
+
+func (r *SpeciesRepository) Add(ctx context.Context, sp *models.Specie) (int64, error) {
+	start := time.Now()
+	id, err := r.next.Add(ctx, sp)
+	r.m.observeRepo("species", "Add", start, err)
+	return id, err
+}
+
+func (r *SpeciesRepository) Update(ctx context.Context, sp *models.Specie) error {
+	start := time.Now()
+	err := r.next.Update(ctx, sp)
+	r.m.observeRepo("species", "Update", start, err)
+	return err
+}
+
+func (r *SpeciesRepository) Delete(ctx context.Context, idSp int64) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, idSp)
+	r.m.observeRepo("species", "Delete", start, err)
+	return err
+}
+
+type EnclosureRepository struct {
+	next database.EnclosureRepository
+	m    *Metrics
+}
+
+func NewEnclosureRepository(next database.EnclosureRepository, m *Metrics) *EnclosureRepository {
+	return &EnclosureRepository{next: next, m: m}
+}
+
+func (r *EnclosureRepository) List(ctx context.Context) ([]models.Enclosure, error) {
+	start := time.Now()
+	enclosures, err := r.next.List(ctx)
+	r.m.observeRepo("enclosures", "List", start, err)
+	return enclosures, err
+}
+
+func (r *EnclosureRepository) Get(ctx context.Context, idEncl int64) (*models.Enclosure, error) {
+	start := time.Now()
+	enc, err := r.next.Get(ctx, idEncl)
+	r.m.observeRepo("enclosures", "Get", start, err)
+	return enc, err
+}
+
+func (r *EnclosureRepository) Add(ctx context.Context, enc *models.Enclosure) (int64, error) {
+	start := time.Now()
+	id, err := r.next.Add(ctx, enc)
+	r.m.observeRepo("enclosures", "Add", start, err)
+	return id, err
+}
+
+func (r *EnclosureRepository) Update(ctx context.Context, enc *models.Enclosure) error {
+	start := time.Now()
+	err := r.next.Update(ctx, enc)
+	r.m.observeRepo("enclosures", "Update", start, err)
+	return err
+}
+
+func (r *EnclosureRepository) Delete(ctx context.Context, idEncl int64) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, idEncl)
+	r.m.observeRepo("enclosures", "Delete", start, err)
+	return err
+}
+
+type FeedingRepository struct {
+	next database.FeedingRepository
+	m    *Metrics
+}
+
+func NewFeedingRepository(next database.FeedingRepository, m *Metrics) *FeedingRepository {
+	return &FeedingRepository{next: next, m: m}
+}
+
+func (r *FeedingRepository) ListPlans(ctx context.Context, f models.FeedingPlanFilter) ([]models.FeedingPlan, error) {
+	start := time.Now()
+	plans, err := r.next.ListPlans(ctx, f)
+	r.m.observeRepo("feedings", "ListPlans", start, err)
+	return plans, err
+}
+
+func (r *FeedingRepository) GetPlan(ctx context.Context, idPlan int64) (*models.FeedingPlan, error) {
+	start := time.Now()
+	plan, err := r.next.GetPlan(ctx, idPlan)
+	r.m.observeRepo("feedings", "GetPlan", start, err)
+	return plan, err
+}
+
+func (r *FeedingRepository) AddPlan(ctx context.Context, plan *models.FeedingPlan) (int64, error) {
+	start := time.Now()
+	id, err := r.next.AddPlan(ctx, plan)
+	r.m.observeRepo("feedings", "AddPlan", start, err)
+	return id, err
+}
+
+func (r *FeedingRepository) UpdatePlan(ctx context.Context, plan *models.FeedingPlan) error {
+	start := time.Now()
+	err := r.next.UpdatePlan(ctx, plan)
+	r.m.observeRepo("feedings", "UpdatePlan", start, err)
+	return err
+}
+
+func (r *FeedingRepository) DeletePlan(ctx context.Context, idPlan int64) error {
+	start := time.Now()
+	err := r.next.DeletePlan(ctx, idPlan)
+	r.m.observeRepo("feedings", "DeletePlan", start, err)
+	return err
+}
+
+func (r *FeedingRepository) AddFeeding(ctx context.Context, feed *models.Feeding) (int64, error) {
+	start := time.Now()
+	id, err := r.next.AddFeeding(ctx, feed)
+	r.m.observeRepo("feedings", "AddFeeding", start, err)
+	return id, err
+}
+
+func (r *FeedingRepository) ListFeedings(ctx context.Context, f models.FeedingFilter) ([]models.Feeding, error) {
+	start := time.Now()
+	feeds, err := r.next.ListFeedings(ctx, f)
+	r.m.observeRepo("feedings", "ListFeedings", start, err)
+	return feeds, err
+}
+
+func (r *FeedingRepository) LatestFeedings(ctx context.Context) ([]models.Feeding, error) {
+	start := time.Now()
+	feeds, err := r.next.LatestFeedings(ctx)
+	r.m.observeRepo("feedings", "LatestFeedings", start, err)
+	return feeds, err
+}
+
+type MedicalRepository struct {
+	next database.MedicalRepository
+	m    *Metrics
+}
+
+func NewMedicalRepository(next database.MedicalRepository, m *Metrics) *MedicalRepository {
+	return &MedicalRepository{next: next, m: m}
+}
+
+func (r *MedicalRepository) AddRecord(ctx context.Context, rec *models.MedicalRecord) (int64, error) {
+	start := time.Now()
+	id, err := r.next.AddRecord(ctx, rec)
+	r.m.observeRepo("medical", "AddRecord", start, err)
+	return id, err
+}
+
+func (r *MedicalRepository) GetRecord(ctx context.Context, idRec int64) (*models.MedicalRecord, error) {
+	start := time.Now()
+	rec, err := r.next.GetRecord(ctx, idRec)
+	r.m.observeRepo("medical", "GetRecord", start, err)
+	return rec, err
+}
+
+func (r *MedicalRepository) AddMedication(ctx context.Context, med *models.Medication) (int64, error) {
+	start := time.Now()
+	id, err := r.next.AddMedication(ctx, med)
+	r.m.observeRepo("medical", "AddMedication", start, err)
+	return id, err
+}
+
+func (r *MedicalRepository) AddVaccination(ctx context.Context, vac *models.Vaccination) (int64, error) {
+	start := time.Now()
+	id, err := r.next.AddVaccination(ctx, vac)
+	r.m.observeRepo("medical", "AddVaccination", start, err)
+	return id, err
+}
+
+func (r *MedicalRepository) History(ctx context.Context, f models.MedicalFilter) (*models.MedicalHistory, error) {
+	start := time.Now()
+	h, err := r.next.History(ctx, f)
+	r.m.observeRepo("medical", "History", start, err)
+	return h, err
+}
+
+func (r *MedicalRepository) DueVaccinations(ctx context.Context, until time.Time) ([]models.DueVaccination, error) {
+	start := time.Now()
+	due, err := r.next.DueVaccinations(ctx, until)
+	r.m.observeRepo("medical", "DueVaccinations", start, err)
+	return due, err
+}
+
+type MeasurementRepository struct {
+	next database.MeasurementRepository
+	m    *Metrics
+}
+
+func NewMeasurementRepository(next database.MeasurementRepository, m *Metrics) *MeasurementRepository {
+	return &MeasurementRepository{next: next, m: m}
+}
+
+func (r *MeasurementRepository) Add(ctx context.Context, meas *models.Measurement) (int64, error) {
+	start := time.Now()
+	id, err := r.next.Add(ctx, meas)
+	r.m.observeRepo("measurements", "Add", start, err)
+	return id, err
+}
+
+func (r *MeasurementRepository) Delete(ctx context.Context, idMeas int64) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, idMeas)
+	r.m.observeRepo("measurements", "Delete", start, err)
+	return err
+}
+
+func (r *MeasurementRepository) List(ctx context.Context, f models.MeasurementFilter) ([]models.Measurement, error) {
+	start := time.Now()
+	ms, err := r.next.List(ctx, f)
+	r.m.observeRepo("measurements", "List", start, err)
+	return ms, err
+}
+
+func (r *MeasurementRepository) Latest(ctx context.Context, idAnim int64) (*models.Measurement, error) {
+	start := time.Now()
+	m, err := r.next.Latest(ctx, idAnim)
+	r.m.observeRepo("measurements", "Latest", start, err)
+	return m, err
+}
+
+func (r *MeasurementRepository) Since(ctx context.Context, from time.Time) ([]models.Measurement, error) {
+	start := time.Now()
+	ms, err := r.next.Since(ctx, from)
+	r.m.observeRepo("measurements", "Since", start, err)
+	return ms, err
+}
+
+type LineageRepository struct {
+	next database.LineageRepository
+	m    *Metrics
+}
+
+func NewLineageRepository(next database.LineageRepository, m *Metrics) *LineageRepository {
+	return &LineageRepository{next: next, m: m}
+}
+
+func (r *LineageRepository) SetParents(ctx context.Context, idAnim int64, mother, father models.Parent) error {
+	start := time.Now()
+	err := r.next.SetParents(ctx, idAnim, mother, father)
+	r.m.observeRepo("lineage", "SetParents", start, err)
+	return err
+}
+
+func (r *LineageRepository) Kin(ctx context.Context, ids []int64) ([]models.Kin, error) {
+	start := time.Now()
+	kin, err := r.next.Kin(ctx, ids)
+	r.m.observeRepo("lineage", "Kin", start, err)
+	return kin, err
+}
+
+func (r *LineageRepository) Children(ctx context.Context, ids []int64) ([]models.Kin, error) {
+	start := time.Now()
+	kin, err := r.next.Children(ctx, ids)
+	r.m.observeRepo("lineage", "Children", start, err)
+	return kin, err
+}
+
+type MoodRepository struct {
+	next database.MoodRepository
+	m    *Metrics
+}
+
+func NewMoodRepository(next database.MoodRepository, m *Metrics) *MoodRepository {
+	return &MoodRepository{next: next, m: m}
+}
+
+func (r *MoodRepository) Add(ctx context.Context, mood *models.MoodEntry) (int64, error) {
+	start := time.Now()
+	id, err := r.next.Add(ctx, mood)
+	r.m.observeRepo("moods", "Add", start, err)
+	return id, err
+}
+
+func (r *MoodRepository) List(ctx context.Context, f models.MoodFilter) ([]models.MoodEntry, error) {
+	start := time.Now()
+	moods, err := r.next.List(ctx, f)
+	r.m.observeRepo("moods", "List", start, err)
+	return moods, err
+}
+
+func (r *MoodRepository) Distribution(ctx context.Context, f models.MoodDistributionFilter) ([]models.MoodCount, error) {
+	start := time.Now()
+	counts, err := r.next.Distribution(ctx, f)
+	r.m.observeRepo("moods", "Distribution", start, err)
+	return counts, err
+}
+
+type ObservationRepository struct {
+	next database.ObservationRepository
+	m    *Metrics
+}
+
+func NewObservationRepository(next database.ObservationRepository, m *Metrics) *ObservationRepository {
+	return &ObservationRepository{next: next, m: m}
+}
+
+func (r *ObservationRepository) Add(ctx context.Context, o *models.Observation) (int64, error) {
+	start := time.Now()
+	id, err := r.next.Add(ctx, o)
+	r.m.observeRepo("observations", "Add", start, err)
+	return id, err
+}
+
+func (r *ObservationRepository) List(ctx context.Context, f models.ObservationFilter) ([]models.Observation, error) {
+	start := time.Now()
+	obs, err := r.next.List(ctx, f)
+	r.m.observeRepo("observations", "List", start, err)
+	return obs, err
+}
+
+type UserRepository struct {
+	next database.UserRepository
+	m    *Metrics
+}
+
+func NewUserRepository(next database.UserRepository, m *Metrics) *UserRepository {
+	return &UserRepository{next: next, m: m}
+}
+
+func (r *UserRepository) List(ctx context.Context) ([]models.User, error) {
+	start := time.Now()
+	users, err := r.next.List(ctx)
+	r.m.observeRepo("users", "List", start, err)
+	return users, err
+}
+
+func (r *UserRepository) Get(ctx context.Context, idUser int64) (*models.User, error) {
+	start := time.Now()
+	u, err := r.next.Get(ctx, idUser)
+	r.m.observeRepo("users", "Get", start, err)
+	return u, err
+}
+
+func (r *UserRepository) GetByName(ctx context.Context, name string) (*models.User, error) {
+	start := time.Now()
+	u, err := r.next.GetByName(ctx, name)
+	r.m.observeRepo("users", "GetByName", start, err)
+	return u, err
+}
+
+func (r *UserRepository) Add(ctx context.Context, u *models.User) error {
+	start := time.Now()
+	err := r.next.Add(ctx, u)
+	r.m.observeRepo("users", "Add", start, err)
+	return err
+}
+
+func (r *UserRepository) Update(ctx context.Context, u *models.User) error {
+	start := time.Now()
+	err := r.next.Update(ctx, u)
+	r.m.observeRepo("users", "Update", start, err)
+	return err
+}
+
+func (r *UserRepository) Delete(ctx context.Context, idUser int64) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, idUser)
+	r.m.observeRepo("users", "Delete", start, err)
+	return err
+}
+
+type KeeperRepository struct {
+	next database.KeeperRepository
+	m    *Metrics
+}
+
+func NewKeeperRepository(next database.KeeperRepository, m *Metrics) *KeeperRepository {
+	return &KeeperRepository{next: next, m: m}
+}
+
+func (r *KeeperRepository) List(ctx context.Context) ([]models.Keeper, error) {
+	start := time.Now()
+	keepers, err := r.next.List(ctx)
+	r.m.observeRepo("keepers", "List", start, err)
+	return keepers, err
+}
+
+func (r *KeeperRepository) Get(ctx context.Context, idKeeper int64) (*models.Keeper, error) {
+	start := time.Now()
+	k, err := r.next.Get(ctx, idKeeper)
+	r.m.observeRepo("keepers", "Get", start, err)
+	return k, err
+}
+
+func (r *KeeperRepository) Add(ctx context.Context, k *models.Keeper) (int64, error) {
+	start := time.Now()
+	id, err := r.next.Add(ctx, k)
+	r.m.observeRepo("keepers", "Add", start, err)
+	return id, err
+}
+
+func (r *KeeperRepository) Update(ctx context.Context, k *models.Keeper) error {
+	start := time.Now()
+	err := r.next.Update(ctx, k)
+	r.m.observeRepo("keepers", "Update", start, err)
+	return err
+}
+
+func (r *KeeperRepository) Delete(ctx context.Context, idKeeper int64) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, idKeeper)
+	r.m.observeRepo("keepers", "Delete", start, err)
+	return err
+}
+
+func (r *KeeperRepository) Assign(ctx context.Context, a *models.KeeperAssignment) error {
+	start := time.Now()
+	err := r.next.Assign(ctx, a)
+	r.m.observeRepo("keepers", "Assign", start, err)
+	return err
+}
+
+func (r *KeeperRepository) Unassign(ctx context.Context, idAssign int64) error {
+	start := time.Now()
+	err := r.next.Unassign(ctx, idAssign)
+	r.m.observeRepo("keepers", "Unassign", start, err)
+	return err
+}
+
+func (r *KeeperRepository) Assignments(ctx context.Context, f models.AssignmentFilter) ([]models.KeeperAssignment, error) {
+	start := time.Now()
+	assigns, err := r.next.Assignments(ctx, f)
+	r.m.observeRepo("keepers", "Assignments", start, err)
+	return assigns, err
+}
@@ -1,12 +1,16 @@
 package internal
 
+import "time"
+
 type (
 	AnimalSmall struct {
-		IdAnim int64
-		NameAn string
-		Age    int
-		Gender string
-		IdSp   int64
+		IdAnim         int64
+		NameAn         string
+		BirthDate      time.Time
+		BirthPrecision string
+		Gender         string
+		IdSp           int64
+		IdEncl         *int64
 	}
 	Specie struct {
 		IdSp    int64
@@ -14,19 +18,453 @@ type (
 		Descrip string
 	}
 
+	// Animal - животное. BirthDate известна с точностью BirthPrecision:
+	// при month день, при year еще и месяц не значимы и хранятся как первые
 	Animal struct {
+		IdAnim         int64
+		NameAn         string
+		BirthDate      time.Time
+		BirthPrecision string
+		Gender         string
+		Title          string
+		Descrip        string
+		// nil - животное не размещено ни в одном вольере
+		IdEncl *int64
+	}
+
+	// Age - возраст, вычисляется из даты рождения при чтении
+	Age struct {
+		Years  int
+		Months int
+	}
+
+	// Enclosure - вольер. Species - названия видов, которые можно в нем держать,
+	// животные других видов туда не селятся. Occupancy заполняется при чтении
+	Enclosure struct {
+		IdEncl    int64
+		Name      string
+		Zone      string
+		Capacity  int
+		Habitat   string
+		Species   []string
+		Occupancy int
+	}
+
+	// FeedingPlan - план кормления вида (Species) или конкретного животного (IdAnim),
+	// задается ровно одно. План животного заменяет для него планы вида.
+	// Times - время кормлений в течение суток в формате "15:04"
+	FeedingPlan struct {
+		IdPlan   int64
+		Species  string
+		IdAnim   *int64
+		Food     string
+		Quantity float64
+		Unit     string
+		Times    []string
+	}
+
+	// Feeding - запись о фактическом кормлении; IdPlan задан, если кормили по плану
+	Feeding struct {
+		IdFeed   int64
+		IdAnim   int64
+		IdPlan   *int64
+		Food     string
+		Quantity float64
+		Unit     string
+		Keeper   string
+		FedAt    time.Time
+		Notes    string
+	}
+
+	FeedingPlanFilter struct {
+		Species string
+		IdAnim  *int64
+	}
+
+	// FeedingFilter - журнал кормлений животного за [From, To), нулевые границы не ограничивают
+	FeedingFilter struct {
+		IdAnim int64
+		From   time.Time
+		To     time.Time
+		Limit  int
+	}
+
+	// OverdueFeeding - животное не покормили по плану к DueAt
+	OverdueFeeding struct {
+		IdAnim    int64
+		NameAn    string
+		Title     string
+		IdPlan    int64
+		Food      string
+		DueAt     time.Time
+		LastFedAt *time.Time
+	}
+
+	// MedicalRecord - осмотр, диагноз или лечение (Kind) в медицинской истории животного
+	MedicalRecord struct {
+		IdRec      int64
+		IdAnim     int64
+		Kind       string
+		Title      string
+		Details    string
+		Vet        string
+		RecordedAt time.Time
+	}
+
+	// Medication - курс препарата: доза Dose в DoseUnit с частотой Frequency
+	// в течение Days дней начиная с StartedAt; IdRec - лечение, к которому относится курс
+	Medication struct {
+		IdMed     int64
+		IdAnim    int64
+		IdRec     *int64
+		Drug      string
+		Dose      float64
+		DoseUnit  string
+		Frequency string
+		StartedAt time.Time
+		Days      int
+		Vet       string
+	}
+
+	// Vaccination - прививка; DueAt - когда нужна следующая, nil - повторять не нужно
+	Vaccination struct {
+		IdVac   int64
 		IdAnim  int64
-		NameAn  string
-		Age     int
-		Gender  string
-		Title   string
-		Descrip string
+		Vaccine string
+		GivenAt time.Time
+		DueAt   *time.Time
+		Vet     string
+	}
+
+	// DueVaccination - прививка, по которой подходит или прошел срок повторной
+	DueVaccination struct {
+		Vaccination
+		NameAn string
+		Title  string
+	}
+
+	// MedicalFilter - история животного за [From, To), нулевые границы не ограничивают
+	MedicalFilter struct {
+		IdAnim int64
+		From   time.Time
+		To     time.Time
+	}
+
+	// MedicalHistory - записи каждого вида от новых к старым
+	MedicalHistory struct {
+		Records      []MedicalRecord
+		Medications  []Medication
+		Vaccinations []Vaccination
+	}
+
+	// HealthSummary - сводка для карточки животного
+	HealthSummary struct {
+		LastExamination   *MedicalRecord
+		LastDiagnosis     *MedicalRecord
+		ActiveMedications []Medication
+		// последние прививки каждой вакциной, повтор которых нужен в ближайшие дни или просрочен
+		DueVaccinations []Vaccination
+	}
+
+	// Measurement - замер животного; задано хотя бы одно из значений.
+	// BodyCondition - упитанность по шкале 1-9
+	Measurement struct {
+		IdMeas        int64
+		IdAnim        int64
+		MeasuredAt    time.Time
+		WeightKg      *float64
+		LengthCm      *float64
+		BodyCondition *int
+		Notes         string
+	}
+
+	// MeasurementFilter - замеры животного за [From, To), нулевые границы не ограничивают
+	MeasurementFilter struct {
+		IdAnim int64
+		From   time.Time
+		To     time.Time
+	}
+
+	// MeasurementPoint - средние значения за период, начавшийся в At;
+	// nil - в периоде не было замеров этого значения
+	MeasurementPoint struct {
+		At            time.Time
+		Count         int
+		WeightKg      *float64
+		LengthCm      *float64
+		BodyCondition *float64
+	}
+
+	// MeasurementAlert - резкое изменение веса: от From к To доля Change
+	// (отрицательная - потеря)
+	MeasurementAlert struct {
+		IdAnim int64
+		NameAn string
+		Kind   string
+		From   Measurement
+		To     Measurement
+		Change float64
+	}
+
+	// Parent - мать или отец: животное зоопарка (IdAnim) или внешнее, известное только
+	// по метке External - номеру в племенной книге, кличке из другого зоопарка.
+	// Пустой Parent - родитель неизвестен
+	Parent struct {
+		IdAnim   *int64
+		External string
+	}
+
+	// Kin - животное с родителями, звено родословной
+	Kin struct {
+		IdAnim    int64
+		NameAn    string
+		Gender    string
+		Title     string
+		BirthDate time.Time
+		Mother    Parent
+		Father    Parent
+	}
+
+	// Relative - животное зоопарка (IdAnim) или внешнее (External) в родословной
+	Relative struct {
+		IdAnim   *int64
+		External string
+		NameAn   string
+	}
+
+	// PedigreeNode - дерево предков; Mother и Father - nil, если родитель
+	// неизвестен или дальше запрошенного числа поколений
+	PedigreeNode struct {
+		Relative
+		Mother *PedigreeNode
+		Father *PedigreeNode
+	}
+
+	// Descendant - потомок в поколении Generation: 1 - дети, 2 - внуки
+	Descendant struct {
+		IdAnim     int64
+		NameAn     string
+		Generation int
+	}
+
+	Pedigree struct {
+		PedigreeNode
+		Descendants []Descendant
+	}
+
+	// BreedingEvaluation - оценка пары: Inbreeding - коэффициент инбридинга
+	// будущего потомства, Acceptable - не выше допустимого MaxInbreeding
+	BreedingEvaluation struct {
+		Sire            Relative
+		Dam             Relative
+		Inbreeding      float64
+		CommonAncestors []Relative
+		MaxInbreeding   float64
+		Acceptable      bool
+	}
+
+	// AnimalPatch - частичное изменение животного, nil-поля не меняются
+	AnimalPatch struct {
+		NameAn         *string
+		BirthDate      *time.Time
+		BirthPrecision *string
+		Gender         *string
+		Title          *string
 	}
 
 	AnimalFull struct {
 		Animal
-		Mood Mood
+		Mood        Mood
+		MoodReasons []MoodReason
+	}
+
+	AnimalPage struct {
+		Animals       []Animal
+		NextPageToken string
 	}
 
 	Mood string
+
+	// MoodReason - вклад правила Rule в настроение Mood с весом Weight
+	MoodReason struct {
+		Rule   string
+		Mood   Mood
+		Weight int
+		Reason string
+	}
+
+	// Observation - наблюдение смотрителя за поведением животного. Пустые Activity, Appetite
+	// и Social - не оценивались; Severity - насколько наблюдение тревожное, Tags - метки поведения
+	Observation struct {
+		IdObs       int64
+		IdAnim      int64
+		Keeper      string
+		ObservedAt  time.Time
+		Activity    string
+		Appetite    string
+		Social      string
+		Stereotypic bool
+		Severity    string
+		Notes       string
+		Tags        []string
+	}
+
+	// ObservationFilter - наблюдения за [From, To) от новых к старым; пустые поля не фильтруют,
+	// Limit 0 - без ограничения
+	ObservationFilter struct {
+		IdAnim *int64
+		Keeper string
+		Tag    string
+		From   time.Time
+		To     time.Time
+		Limit  int
+	}
+
+	// Role - роль сотрудника; от нее зависят разрешенные операции
+	Role string
+
+	// User - сотрудник с доступом к API. Name совпадает с субъектом из ключа API или токена
+	User struct {
+		IdUser    int64
+		Name      string
+		Role      Role
+		CreatedAt time.Time
+	}
+
+	// Keeper - смотритель из справочника персонала. Species - виды, с которыми
+	// он умеет работать: закрепить за ним можно только животных этих видов
+	Keeper struct {
+		IdKeeper int64
+		Name     string
+		Role     string
+		Contact  string
+		Species  []string
+	}
+
+	// KeeperAssignment - смотритель отвечает за животное (IdAnim) или за весь
+	// вольер (IdEncl), задается ровно одно
+	KeeperAssignment struct {
+		IdAssign   int64
+		IdKeeper   int64
+		IdAnim     *int64
+		IdEncl     *int64
+		AssignedAt time.Time
+	}
+
+	// AssignmentFilter - нулевые поля не ограничивают
+	AssignmentFilter struct {
+		IdKeeper *int64
+		IdAnim   *int64
+		IdEncl   *int64
+	}
+
+	// Responsible - смотритель, отвечающий за животное; IdEncl задан,
+	// если он закреплен за вольером животного, а не за ним самим
+	Responsible struct {
+		Keeper
+		IdAssign int64
+		IdEncl   *int64
+	}
+
+	// MoodEntry - настроение в момент ObservedAt: отмеченное смотрителем Keeper
+	// или вычисленное правилами, тогда в Notes - причины. IdEncl - вольер в тот момент
+	MoodEntry struct {
+		IdMood     int64
+		IdAnim     int64
+		Mood       Mood
+		Source     MoodSource
+		Keeper     string
+		Notes      string
+		IdEncl     *int64
+		ObservedAt time.Time
+	}
+
+	MoodSource string
+
+	// MoodFilter - история животного за [From, To) от новых к старым; пустой Source - оба
+	// источника, Limit 0 - без ограничения
+	MoodFilter struct {
+		IdAnim int64
+		Source MoodSource
+		From   time.Time
+		To     time.Time
+		Limit  int
+	}
+
+	MoodGrouping string
+
+	// MoodDistributionFilter - записи всех животных за [From, To) по группам GroupBy
+	MoodDistributionFilter struct {
+		GroupBy MoodGrouping
+		Source  MoodSource
+		From    time.Time
+		To      time.Time
+	}
+
+	// MoodCount - сколько раз в группе Group (название вольера или вида) отмечено настроение
+	MoodCount struct {
+		Group string
+		Mood  Mood
+		Count int
+	}
+
+	MoodShare struct {
+		Mood  Mood
+		Count int
+		Share float64
+	}
+
+	// MoodDistribution - настроения группы от частых к редким
+	MoodDistribution struct {
+		Group string
+		Total int
+		Moods []MoodShare
+	}
+
+	AnimalSortField string
+
+	// AnimalFilter - условия отбора животных, пустые поля не фильтруют.
+	// Границы BornAfter и BornBefore не включаются. MinAge и MaxAge (в полных годах)
+	// сервис переводит в границы даты рождения, репозиторий их не смотрит
+	AnimalFilter struct {
+		Species    string
+		Gender     string
+		NamePrefix string
+		MinAge     *int
+		MaxAge     *int
+		BornAfter  *time.Time
+		BornBefore *time.Time
+		Enclosure  *int64
+	}
+
+	AnimalOrder struct {
+		Field AnimalSortField
+		Desc  bool
+	}
+)
+
+const (
+	SortByID   AnimalSortField = "id"
+	SortByName AnimalSortField = "name"
+	// SortByAge - по возрасту, то есть по дате рождения в обратном порядке
+	SortByAge AnimalSortField = "age"
+)
+
+const (
+	MoodSourceKeeper   MoodSource = "keeper"
+	MoodSourceComputed MoodSource = "computed"
+)
+
+const (
+	// MoodByEnclosure - по вольеру, в котором животное было в момент записи
+	MoodByEnclosure MoodGrouping = "enclosure"
+	MoodBySpecies   MoodGrouping = "species"
+)
+
+const (
+	RoleViewer  Role = "viewer"
+	RoleKeeper  Role = "keeper"
+	RoleVet     Role = "vet"
+	RoleCurator Role = "curator"
+	RoleAdmin   Role = "admin"
 )
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+)
+
+// Permission - право на группу операций; проверяется в сервисах,
+// поэтому одинаково действует для REST и gRPC
+type Permission string
+
+const (
+	PermZooRead           Permission = "zoo:read"
+	PermAnimalsWrite      Permission = "animals:write"
+	PermSpeciesWrite      Permission = "species:write"
+	PermEnclosuresWrite   Permission = "enclosures:write"
+	PermFeedingPlansWrite Permission = "feeding_plans:write"
+	PermFeedingsWrite     Permission = "feedings:write"
+	PermMedicalWrite      Permission = "medical:write"
+	PermMeasurementsWrite Permission = "measurements:write"
+	PermLineageWrite      Permission = "lineage:write"
+	PermBehaviourWrite    Permission = "behaviour:write"
+	PermStaffWrite        Permission = "staff:write"
+	PermUsersManage       Permission = "users:manage"
+)
+
+// Roles - все роли от меньших прав к большим
+var Roles = []mod.Role{mod.RoleViewer, mod.RoleKeeper, mod.RoleVet, mod.RoleCurator, mod.RoleAdmin}
+
+// rolePermissions - что разрешено каждой роли; у admin все права
+var rolePermissions = map[mod.Role][]Permission{
+	mod.RoleViewer: {PermZooRead},
+	mod.RoleKeeper: {PermZooRead, PermFeedingsWrite, PermMeasurementsWrite, PermBehaviourWrite},
+	mod.RoleVet:    {PermZooRead, PermMedicalWrite, PermMeasurementsWrite, PermBehaviourWrite},
+	mod.RoleCurator: {PermZooRead, PermAnimalsWrite, PermSpeciesWrite, PermEnclosuresWrite, PermFeedingPlansWrite,
+		PermFeedingsWrite, PermMeasurementsWrite, PermLineageWrite, PermBehaviourWrite, PermStaffWrite},
+	mod.RoleAdmin: {PermZooRead, PermAnimalsWrite, PermSpeciesWrite, PermEnclosuresWrite, PermFeedingPlansWrite,
+		PermFeedingsWrite, PermMedicalWrite, PermMeasurementsWrite, PermLineageWrite, PermBehaviourWrite, PermStaffWrite, PermUsersManage},
+}
+
+// Permissions - права роли; для неизвестной роли пусто
+func Permissions(role mod.Role) []Permission {
+	return slices.Clone(rolePermissions[role])
+}
+
+// authorize пропускает вызов, если у личности из контекста есть право p.
+// Вызов без личности отклоняется: транспорт всегда ее кладет, фоновые задачи
+// работают от auth.System
+func authorize(ctx context.Context, p Permission) error {
+	id := auth.FromContext(ctx)
+	if id == nil || !slices.Contains(rolePermissions[id.Role], p) {
+		return errs.Forbidden("permission %s required", p)
+	}
+	return nil
+}
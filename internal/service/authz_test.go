@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorization(t *testing.T) {
+	st := database.NewMemStorage()
+	species := service.NewSpeciesService(database.NewMemSpeciesRepository(st), service.NewValidator())
+	as := func(role models.Role) context.Context {
+		return auth.WithIdentity(context.Background(), &auth.Identity{Subject: "x", Role: role})
+	}
+
+	_, err := species.ListSpecies(context.Background())
+	assert.ErrorIs(t, err, errs.ErrForbidden, "no identity")
+	_, err = species.ListSpecies(as(""))
+	assert.ErrorIs(t, err, errs.ErrForbidden, "no role")
+	_, err = species.ListSpecies(as(models.RoleViewer))
+	assert.NoError(t, err)
+	_, err = species.AddSpecie(as(models.RoleKeeper), &models.Specie{Title: "cat", Descrip: "meow"})
+	assert.EqualError(t, err, "permission species:write required")
+	_, err = species.AddSpecie(as(models.RoleCurator), &models.Specie{Title: "cat", Descrip: "meow"})
+	assert.NoError(t, err)
+	_, err = species.AddSpecie(auth.WithIdentity(context.Background(), auth.System), &models.Specie{Title: "dog", Descrip: "woof"})
+	assert.NoError(t, err)
+
+	for _, role := range service.Roles {
+		assert.Contains(t, service.Permissions(role), service.PermZooRead, role)
+	}
+	assert.Contains(t, service.Permissions(models.RoleAdmin), service.PermUsersManage)
+	assert.Empty(t, service.Permissions("director"))
+}
+
+func TestRoleResolver(t *testing.T) {
+	ctx := adminCtx()
+	users := database.NewMemUserRepository(database.NewMemStorage())
+	_, err := service.NewUserService(users, service.NewValidator()).AddUser(ctx, &models.User{Name: "ivan", Role: models.RoleKeeper})
+	require.NoError(t, err)
+	keys, err := auth.NewAPIKeys(map[string]string{
+		"ivan": "ivan-key-0123456789", "root": "root-key-0123456789", "olga": "olga-key-0123456789"})
+	require.NoError(t, err)
+	r := service.NewRoleResolver(auth.Chain{keys, auth.Anonymous{Role: models.RoleViewer}}, users, []string{"root"})
+
+	for key, role := range map[string]models.Role{
+		"ivan-key-0123456789": models.RoleKeeper,
+		"root-key-0123456789": models.RoleAdmin,
+		"olga-key-0123456789": "",
+		"":                    models.RoleViewer,
+	} {
+		id, err := r.Authenticate(ctx, auth.Credentials{APIKey: key})
+		require.NoError(t, err, key)
+		assert.Equal(t, role, id.Role, key)
+	}
+	_, err = r.Authenticate(ctx, auth.Credentials{APIKey: "wrong-key-0123456789"})
+	assert.ErrorIs(t, err, errs.ErrUnauthenticated)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"time"
+
+	mod "github.com/mi-raf/zooad/internal/models"
+)
+
+// AgeAt - полный возраст на дату now. Дата рождения с точностью до месяца или года
+// хранится как первое число, от него и считается
+func AgeAt(birth, now time.Time) mod.Age {
+	years := now.Year() - birth.Year()
+	months := int(now.Month()) - int(birth.Month())
+	if now.Day() < birth.Day() {
+		months--
+	}
+	if months < 0 {
+		years--
+		months += 12
+	}
+	return mod.Age{Years: years, Months: months}
+}
+
+// normalizeBirth отбрасывает время и незначимые при заданной точности части даты;
+// без точности дата считается точной
+func normalizeBirth(a *mod.Animal) {
+	if a.BirthPrecision == "" {
+		a.BirthPrecision = "exact"
+	}
+	if a.BirthDate.IsZero() {
+		return
+	}
+	y, m, d := a.BirthDate.Date()
+	switch a.BirthPrecision {
+	case "month":
+		d = 1
+	case "year":
+		m, d = time.January, 1
+	}
+	a.BirthDate = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// resolveAge переводит MinAge и MaxAge в границы даты рождения на день now,
+// сужая уже заданные BornAfter и BornBefore
+func resolveAge(f mod.AnimalFilter, now time.Time) mod.AnimalFilter {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if f.MinAge != nil {
+		// исполнилось MinAge лет - родился не позже, чем MinAge лет назад
+		before := today.AddDate(-*f.MinAge, 0, 1)
+		if f.BornBefore == nil || before.Before(*f.BornBefore) {
+			f.BornBefore = &before
+		}
+	}
+	if f.MaxAge != nil {
+		// еще не исполнилось MaxAge+1 лет
+		after := today.AddDate(-*f.MaxAge-1, 0, 0)
+		if f.BornAfter == nil || after.After(*f.BornAfter) {
+			f.BornAfter = &after
+		}
+	}
+	f.MinAge, f.MaxAge = nil, nil
+	return f
+}
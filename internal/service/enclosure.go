@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+)
+
+type EnclosureService struct {
+	r       database.EnclosureRepository
+	keepers database.KeeperRepository
+	v       *Validator
+}
+
+func NewEnclosureService(r database.EnclosureRepository, keepers database.KeeperRepository, v *Validator) *EnclosureService {
+	return &EnclosureService{r: r, keepers: keepers, v: v}
+}
+
+func (s *EnclosureService) ListEnclosures(ctx context.Context) ([]mod.Enclosure, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.List(ctx)
+}
+
+func (s *EnclosureService) GetEnclosure(ctx context.Context, idEncl int64) (*mod.Enclosure, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, idEncl)
+}
+
+func (s *EnclosureService) AddEnclosure(ctx context.Context, enc *mod.Enclosure) (*mod.Enclosure, error) {
+	if err := authorize(ctx, PermEnclosuresWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.Enclosure(enc); err != nil {
+		return nil, err
+	}
+	id, err := s.r.Add(ctx, enc)
+	if err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, id)
+}
+
+// UpdateEnclosure не дает уменьшить вместимость ниже числа жильцов, убрать из допустимых
+// вид, который в вольере уже живет, и добавить вид, с которым не умеет работать
+// закрепленный за вольером смотритель
+func (s *EnclosureService) UpdateEnclosure(ctx context.Context, enc *mod.Enclosure) (*mod.Enclosure, error) {
+	if err := authorize(ctx, PermEnclosuresWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.Enclosure(enc); err != nil {
+		return nil, err
+	}
+	// вместимость и виды жильцов проверяет репозиторий в одной транзакции с обновлением
+	err := checkAssignedKeepers(ctx, s.keepers, mod.AssignmentFilter{IdEncl: &enc.IdEncl}, enc.Species, "enclosure "+enc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.r.Update(ctx, enc); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, enc.IdEncl)
+}
+
+func (s *EnclosureService) DeleteEnclosure(ctx context.Context, idEncl int64) error {
+	if err := authorize(ctx, PermEnclosuresWrite); err != nil {
+		return err
+	}
+	return s.r.Delete(ctx, idEncl)
+}
+
+// checkHousing - правила заселения: вид животного допустим в вольере и там есть место
+func checkHousing(an *mod.Animal, enc *mod.Enclosure) error {
+	if !slices.Contains(enc.Species, an.Title) {
+		return errs.Conflict("species %s is not allowed in enclosure %s", an.Title, enc.Name)
+	}
+	occupied := enc.Occupancy
+	if an.IdEncl != nil && *an.IdEncl == enc.IdEncl {
+		// животное уже учтено в Occupancy
+		occupied--
+	}
+	if occupied >= enc.Capacity {
+		return errs.Conflict("enclosure %s is full (capacity %d)", enc.Name, enc.Capacity)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type housing struct {
+	st         *database.MemStorage
+	animals    *service.AnimalService
+	enclosures *service.EnclosureService
+}
+
+func newHousing(t *testing.T) *housing {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	species := database.NewMemSpeciesRepository(st)
+	for _, title := range []string{"cat", "dog"} {
+		_, err := species.Add(ctx, &models.Specie{Title: title, Descrip: title})
+		require.NoError(t, err)
+	}
+	tokens, err := service.NewPageTokenCodec("secret")
+	require.NoError(t, err)
+	v := service.NewValidator()
+	animals := database.NewMemAnimalRepository(st)
+	enclosures := database.NewMemEnclosureRepository(st)
+	return &housing{
+		st:         st,
+		animals:    service.NewAnimalService(animals, enclosures, database.NewMemLineageRepository(st), database.NewMemKeeperRepository(st), newMoodEngine(st, &service.MoodConfig{}), tokens, v),
+		enclosures: service.NewEnclosureService(enclosures, database.NewMemKeeperRepository(st), v),
+	}
+}
+
+func (h *housing) animal(t *testing.T, name, title string) int64 {
+	an, err := h.animals.AddAnimal(adminCtx(), &models.Animal{NameAn: name, BirthDate: born, Gender: "m", Title: title})
+	require.NoError(t, err)
+	return an.IdAnim
+}
+
+func TestAssignEnclosureRules(t *testing.T) {
+	ctx := adminCtx()
+	h := newHousing(t)
+	enc, err := h.enclosures.AddEnclosure(ctx, &models.Enclosure{
+		Name: "Cats", Zone: "north", Capacity: 2, Habitat: "forest", Species: []string{"cat"},
+	})
+	require.NoError(t, err)
+
+	klepa, tom, zu := h.animal(t, "Klepa", "cat"), h.animal(t, "Tom", "cat"), h.animal(t, "Zu", "cat")
+	rex := h.animal(t, "Rex", "dog")
+
+	_, err = h.animals.AssignEnclosure(ctx, rex, &enc.IdEncl)
+	assert.ErrorIs(t, err, errs.ErrConflict, "dog is not allowed with cats")
+
+	for _, id := range []int64{klepa, tom} {
+		_, err = h.animals.AssignEnclosure(ctx, id, &enc.IdEncl)
+		require.NoError(t, err)
+	}
+	// повторное заселение в тот же вольер места не занимает
+	_, err = h.animals.AssignEnclosure(ctx, tom, &enc.IdEncl)
+	require.NoError(t, err)
+	_, err = h.animals.AssignEnclosure(ctx, zu, &enc.IdEncl)
+	assert.ErrorIs(t, err, errs.ErrConflict, "capacity exceeded")
+
+	// смена вида на недопустимый в вольере
+	dog := "dog"
+	_, err = h.animals.Patch(ctx, klepa, &models.AnimalPatch{Title: &dog})
+	assert.ErrorIs(t, err, errs.ErrConflict)
+
+	enc.Capacity = 1
+	_, err = h.enclosures.UpdateEnclosure(ctx, enc)
+	assert.ErrorIs(t, err, errs.ErrConflict, "capacity below occupancy")
+
+	got, err := h.enclosures.GetEnclosure(ctx, enc.IdEncl)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Occupancy)
+}
+
+func TestEnclosureValidation(t *testing.T) {
+	h := newHousing(t)
+	_, err := h.enclosures.AddEnclosure(adminCtx(), &models.Enclosure{Name: "x", Zone: "z", Habitat: "moon"})
+	require.ErrorIs(t, err, errs.ErrValidation)
+	var rules []string
+	for _, f := range errs.Fields(err) {
+		rules = append(rules, f.Field+":"+f.Rule)
+	}
+	assert.Equal(t, []string{"capacity:min", "habitat:one_of", "species:required"}, rules)
+}
+
+func TestConflictMessageKeepsPercent(t *testing.T) {
+	ctx := adminCtx()
+	h := newHousing(t)
+	enc, err := h.enclosures.AddEnclosure(ctx, &models.Enclosure{Name: "100% cats", Zone: "z", Capacity: 1, Habitat: "forest", Species: []string{"cat"}})
+	require.NoError(t, err)
+	_, err = h.animals.AssignEnclosure(ctx, h.animal(t, "Rex", "dog"), &enc.IdEncl)
+	assert.EqualError(t, err, "species dog is not allowed in enclosure 100% cats")
+}
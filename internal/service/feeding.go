@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/tracing"
+)
+
+type (
+	FeedingConfig struct {
+		// Grace - насколько можно опоздать с кормлением, прежде чем оно считается пропущенным
+		Grace time.Duration
+		// Location - часовой пояс, в котором заданы Times планов
+		Location *time.Location
+	}
+
+	FeedingService struct {
+		r       database.FeedingRepository
+		animals database.AnimalRepository
+		v       *Validator
+		grace   time.Duration
+		loc     *time.Location
+		now     func() time.Time
+	}
+)
+
+func NewFeedingService(r database.FeedingRepository, animals database.AnimalRepository, v *Validator, cfg *FeedingConfig) *FeedingService {
+	loc := cfg.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	return &FeedingService{r: r, animals: animals, v: v, grace: cfg.Grace, loc: loc, now: time.Now}
+}
+
+func (s *FeedingService) ListPlans(ctx context.Context, f mod.FeedingPlanFilter) ([]mod.FeedingPlan, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.ListPlans(ctx, f)
+}
+
+func (s *FeedingService) GetPlan(ctx context.Context, idPlan int64) (*mod.FeedingPlan, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.GetPlan(ctx, idPlan)
+}
+
+func (s *FeedingService) AddPlan(ctx context.Context, plan *mod.FeedingPlan) (*mod.FeedingPlan, error) {
+	if err := authorize(ctx, PermFeedingPlansWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.FeedingPlan(plan); err != nil {
+		return nil, err
+	}
+	if err := s.checkPlanAnimal(ctx, plan); err != nil {
+		return nil, err
+	}
+	id, err := s.r.AddPlan(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	return s.r.GetPlan(ctx, id)
+}
+
+func (s *FeedingService) UpdatePlan(ctx context.Context, plan *mod.FeedingPlan) (*mod.FeedingPlan, error) {
+	if err := authorize(ctx, PermFeedingPlansWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.FeedingPlan(plan); err != nil {
+		return nil, err
+	}
+	if err := s.checkPlanAnimal(ctx, plan); err != nil {
+		return nil, err
+	}
+	if err := s.r.UpdatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	return s.r.GetPlan(ctx, plan.IdPlan)
+}
+
+func (s *FeedingService) DeletePlan(ctx context.Context, idPlan int64) error {
+	if err := authorize(ctx, PermFeedingPlansWrite); err != nil {
+		return err
+	}
+	return s.r.DeletePlan(ctx, idPlan)
+}
+
+func (s *FeedingService) checkPlanAnimal(ctx context.Context, plan *mod.FeedingPlan) error {
+	if plan.IdAnim == nil {
+		return nil
+	}
+	_, err := s.animals.Get(ctx, *plan.IdAnim)
+	return err
+}
+
+// RecordFeeding записывает кормление. Если задан план, он должен относиться к животному,
+// а незаполненные корм, количество и единица берутся из плана. Без FedAt - кормление сейчас
+func (s *FeedingService) RecordFeeding(ctx context.Context, feed *mod.Feeding) (_ *mod.Feeding, err error) {
+	ctx, span := tracing.Start(ctx, "FeedingService.RecordFeeding")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermFeedingsWrite); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if feed.FedAt.IsZero() {
+		feed.FedAt = now
+	}
+	if feed.IdPlan != nil {
+		animal, err := s.animals.Get(ctx, feed.IdAnim)
+		if err != nil {
+			return nil, err
+		}
+		plan, err := s.r.GetPlan(ctx, *feed.IdPlan)
+		if err != nil {
+			return nil, err
+		}
+		if !planApplies(plan, animal) {
+			return nil, errs.Conflict("feeding plan %d is not for animal %d", plan.IdPlan, animal.IdAnim)
+		}
+		if feed.Food == "" {
+			feed.Food = plan.Food
+		}
+		if feed.Quantity == 0 {
+			feed.Quantity = plan.Quantity
+		}
+		if feed.Unit == "" {
+			feed.Unit = plan.Unit
+		}
+	}
+	if err := s.v.Feeding(feed, now); err != nil {
+		return nil, err
+	}
+	id, err := s.r.AddFeeding(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+	res := *feed
+	res.IdFeed = id
+	return &res, nil
+}
+
+// ListFeedings отдает журнал кормлений животного от новых к старым, не больше MaxPageSize записей
+func (s *FeedingService) ListFeedings(ctx context.Context, f mod.FeedingFilter) ([]mod.Feeding, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.animals.Get(ctx, f.IdAnim); err != nil {
+		return nil, err
+	}
+	f.Limit = pageSize(f.Limit)
+	return s.r.ListFeedings(ctx, f)
+}
+
+func planApplies(plan *mod.FeedingPlan, an *mod.Animal) bool {
+	if plan.IdAnim != nil {
+		return *plan.IdAnim == an.IdAnim
+	}
+	return plan.Species == an.Title
+}
+
+// feedKey - по какому признаку кормление засчитывается плану:
+// по id плана или, для кормлений без плана, по корму
+type feedKey struct {
+	idAnim int64
+	idPlan int64
+	food   string
+}
+
+// Overdue ищет животных, не накормленных по плану. Для каждого плана животного
+// (собственного, а если его нет - плана вида) берется последнее время кормления,
+// наступившее не позже now-grace; кормление засчитывается, если было не раньше чем за grace до него
+func (s *FeedingService) Overdue(ctx context.Context, now time.Time) (_ []mod.OverdueFeeding, err error) {
+	ctx, span := tracing.Start(ctx, "FeedingService.Overdue")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	plans, err := s.r.ListPlans(ctx, mod.FeedingPlanFilter{})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]mod.OverdueFeeding, 0)
+	if len(plans) == 0 {
+		return res, nil
+	}
+	byAnimal := make(map[int64][]mod.FeedingPlan)
+	bySpecies := make(map[string][]mod.FeedingPlan)
+	for _, p := range plans {
+		if p.IdAnim != nil {
+			byAnimal[*p.IdAnim] = append(byAnimal[*p.IdAnim], p)
+		} else {
+			bySpecies[p.Species] = append(bySpecies[p.Species], p)
+		}
+	}
+
+	latest, err := s.r.LatestFeedings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fed := make(map[feedKey]time.Time, len(latest))
+	for _, f := range latest {
+		k := feedKey{idAnim: f.IdAnim, food: f.Food}
+		if f.IdPlan != nil {
+			k = feedKey{idAnim: f.IdAnim, idPlan: *f.IdPlan}
+		}
+		if f.FedAt.After(fed[k]) {
+			fed[k] = f.FedAt
+		}
+	}
+
+	cutoff := now.Add(-s.grace)
+	q := database.AnimalQuery{Order: mod.AnimalOrder{Field: mod.SortByID}, Limit: MaxPageSize}
+	for {
+		animals, err := s.animals.GetAll(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, an := range animals {
+			animalPlans := byAnimal[an.IdAnim]
+			if len(animalPlans) == 0 {
+				animalPlans = bySpecies[an.Title]
+			}
+			for _, p := range animalPlans {
+				due, ok := lastSlot(p.Times, cutoff, s.loc)
+				if !ok {
+					continue
+				}
+				last := fed[feedKey{idAnim: an.IdAnim, idPlan: p.IdPlan}]
+				if t := fed[feedKey{idAnim: an.IdAnim, food: p.Food}]; t.After(last) {
+					last = t
+				}
+				if !last.IsZero() && !last.Before(due.Add(-s.grace)) {
+					continue
+				}
+				o := mod.OverdueFeeding{IdAnim: an.IdAnim, NameAn: an.NameAn, Title: an.Title, IdPlan: p.IdPlan, Food: p.Food, DueAt: due}
+				if !last.IsZero() {
+					o.LastFedAt = &last
+				}
+				res = append(res, o)
+			}
+		}
+		if len(animals) < q.Limit {
+			return res, nil
+		}
+		q.After = &database.AnimalCursor{ID: animals[len(animals)-1].IdAnim}
+	}
+}
+
+// lastSlot - последнее время кормления из times не позже t, сегодня или вчера в loc
+func lastSlot(times []string, t time.Time, loc *time.Location) (time.Time, bool) {
+	t = t.In(loc)
+	for d := 0; d < 2; d++ {
+		day := t.AddDate(0, 0, -d)
+		var best time.Time
+		for _, v := range times {
+			tod, err := time.Parse(TimeOfDayLayout, v)
+			if err != nil {
+				continue
+			}
+			slot := time.Date(day.Year(), day.Month(), day.Day(), tod.Hour(), tod.Minute(), 0, 0, loc)
+			if !slot.After(t) && slot.After(best) {
+				best = slot
+			}
+		}
+		if !best.IsZero() {
+			return best, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	mod "github.com/mi-raf/zooad/internal/models"
+	zl "github.com/rs/zerolog/log"
+)
+
+// FeedingChecker раз в period ищет пропущенные кормления. Живет в ServiceKeeper:
+// Init запускает проверки, Close их останавливает
+type FeedingChecker struct {
+	s      *FeedingService
+	period time.Duration
+	// OnOverdue получает результат каждой проверки, например для метрик
+	OnOverdue func([]mod.OverdueFeeding)
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mux sync.Mutex
+	// уже залогированные пропуски, чтобы не повторять их на каждой проверке
+	reported map[string]struct{}
+}
+
+func NewFeedingChecker(s *FeedingService, period time.Duration) *FeedingChecker {
+	return &FeedingChecker{s: s, period: period, reported: make(map[string]struct{})}
+}
+
+func (c *FeedingChecker) Name() string {
+	return "feeding-checker"
+}
+
+func (c *FeedingChecker) Init(ctx context.Context) error {
+	if c.period <= 0 {
+		return fmt.Errorf("feeding check period must be positive, got %s", c.period)
+	}
+	// проверки идут от имени сервиса, а не пользователя
+	ctx, c.cancel = context.WithCancel(auth.WithIdentity(context.Background(), auth.System))
+	c.done = make(chan struct{})
+	go c.watch(ctx)
+	return nil
+}
+
+// Ping ничего не проверяет: ошибка одной проверки не повод останавливать приложение,
+// а недоступность базы заметит Ping хранилища
+func (c *FeedingChecker) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (c *FeedingChecker) Close() error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+	<-c.done
+	return nil
+}
+
+func (c *FeedingChecker) watch(ctx context.Context) {
+	defer close(c.done)
+	ticker := time.NewTicker(c.period)
+	defer ticker.Stop()
+	for {
+		if _, err := c.check(ctx); err != nil && ctx.Err() == nil {
+			zl.Error().Err(err).Msg("feeding check failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check возвращает пропуски, которых не было на прошлой проверке
+func (c *FeedingChecker) check(ctx context.Context) ([]mod.OverdueFeeding, error) {
+	overdue, err := c.s.Overdue(ctx, c.s.now())
+	if err != nil {
+		return nil, err
+	}
+	if c.OnOverdue != nil {
+		c.OnOverdue(overdue)
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	current := make(map[string]struct{}, len(overdue))
+	var missed []mod.OverdueFeeding
+	for _, o := range overdue {
+		key := fmt.Sprintf("%d/%d/%d", o.IdAnim, o.IdPlan, o.DueAt.Unix())
+		current[key] = struct{}{}
+		if _, ok := c.reported[key]; ok {
+			continue
+		}
+		missed = append(missed, o)
+		zl.Warn().
+			Int64("id_anim", o.IdAnim).
+			Str("name_animal", o.NameAn).
+			Int64("id_plan", o.IdPlan).
+			Str("food", o.Food).
+			Time("due_at", o.DueAt).
+			Msg("missed feeding")
+	}
+	c.reported = current
+	return missed, nil
+}
@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverdueFeedings(t *testing.T) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	s := service.NewFeedingService(database.NewMemFeedingRepository(st), animals, service.NewValidator(),
+		&service.FeedingConfig{Grace: 30 * time.Minute, Location: time.UTC})
+
+	klepa, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: born, Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	tom, err := animals.Add(ctx, &models.Animal{NameAn: "Tom", BirthDate: born, Gender: "m", Title: "cat"})
+	require.NoError(t, err)
+
+	catPlan, err := s.AddPlan(ctx, &models.FeedingPlan{Species: "cat", Food: "fish", Quantity: 200, Unit: "g", Times: []string{"08:00", "18:00"}})
+	require.NoError(t, err)
+	// собственный план Тома заменяет план вида
+	_, err = s.AddPlan(ctx, &models.FeedingPlan{IdAnim: &tom, Food: "mice", Quantity: 2, Unit: "pcs", Times: []string{"12:00"}})
+	require.NoError(t, err)
+
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	morning := day.Add(8*time.Hour + 5*time.Minute)
+	_, err = s.RecordFeeding(ctx, &models.Feeding{IdAnim: klepa, IdPlan: &catPlan.IdPlan, Keeper: "Ivan", FedAt: morning})
+	require.NoError(t, err)
+	_, err = s.RecordFeeding(ctx, &models.Feeding{IdAnim: tom, Food: "mice", Quantity: 2, Unit: "pcs", Keeper: "Ivan", FedAt: day.Add(11*time.Hour + 45*time.Minute)})
+	require.NoError(t, err)
+
+	// в 18:20 вечернее кормление еще не просрочено
+	overdue, err := s.Overdue(ctx, day.Add(18*time.Hour+20*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, overdue)
+
+	overdue, err = s.Overdue(ctx, day.Add(18*time.Hour+40*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, overdue, 1)
+	assert.Equal(t, klepa, overdue[0].IdAnim)
+	assert.Equal(t, day.Add(18*time.Hour), overdue[0].DueAt)
+	require.NotNil(t, overdue[0].LastFedAt)
+	assert.Equal(t, morning, *overdue[0].LastFedAt)
+
+	// кормление чуть раньше времени по плану засчитывается
+	_, err = s.RecordFeeding(ctx, &models.Feeding{IdAnim: klepa, IdPlan: &catPlan.IdPlan, Keeper: "Ivan", FedAt: day.Add(17*time.Hour + 50*time.Minute)})
+	require.NoError(t, err)
+	overdue, err = s.Overdue(ctx, day.Add(18*time.Hour+40*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, overdue)
+
+	// после полуночи последнее кормление - вчерашнее вечернее
+	overdue, err = s.Overdue(ctx, day.Add(25*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, overdue)
+}
+
+func TestRecordFeedingRules(t *testing.T) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	species := database.NewMemSpeciesRepository(st)
+	for _, title := range []string{"cat", "dog"} {
+		_, err := species.Add(ctx, &models.Specie{Title: title, Descrip: title})
+		require.NoError(t, err)
+	}
+	animals := database.NewMemAnimalRepository(st)
+	s := service.NewFeedingService(database.NewMemFeedingRepository(st), animals, service.NewValidator(), &service.FeedingConfig{})
+	rex, err := animals.Add(ctx, &models.Animal{NameAn: "Rex", BirthDate: born, Gender: "m", Title: "dog"})
+	require.NoError(t, err)
+	catPlan, err := s.AddPlan(ctx, &models.FeedingPlan{Species: "cat", Food: "fish", Quantity: 200, Unit: "g", Times: []string{"08:00"}})
+	require.NoError(t, err)
+
+	_, err = s.RecordFeeding(ctx, &models.Feeding{IdAnim: rex, IdPlan: &catPlan.IdPlan, Keeper: "Ivan"})
+	assert.ErrorIs(t, err, errs.ErrConflict)
+
+	_, err = s.RecordFeeding(ctx, &models.Feeding{IdAnim: rex, Food: "meat", Quantity: 1, Unit: "kg", Keeper: "Ivan", FedAt: time.Now().Add(time.Hour)})
+	require.ErrorIs(t, err, errs.ErrValidation)
+	assert.Equal(t, []string{"fed_at:max"}, fieldRules(err))
+
+	_, err = s.AddPlan(ctx, &models.FeedingPlan{Species: "cat", IdAnim: &rex, Food: "fish", Quantity: 0, Unit: "cup", Times: []string{"8am"}})
+	require.ErrorIs(t, err, errs.ErrValidation)
+	assert.Equal(t, []string{"animal_id:one_of", "quantity:min", "unit:one_of", "times[0]:format"}, fieldRules(err))
+
+	missing := int64(42)
+	_, err = s.AddPlan(ctx, &models.FeedingPlan{IdAnim: &missing, Food: "fish", Quantity: 1, Unit: "g", Times: []string{"08:00"}})
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+}
+
+func TestFeedingCheckerReportsOverdue(t *testing.T) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	_, err = animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: born, Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	s := service.NewFeedingService(database.NewMemFeedingRepository(st), animals, service.NewValidator(), &service.FeedingConfig{})
+	_, err = s.AddPlan(ctx, &models.FeedingPlan{Species: "cat", Food: "fish", Quantity: 200, Unit: "g", Times: []string{"00:00"}})
+	require.NoError(t, err)
+
+	c := service.NewFeedingChecker(s, time.Hour)
+	reports := make(chan []models.OverdueFeeding, 1)
+	c.OnOverdue = func(o []models.OverdueFeeding) { reports <- o }
+	require.NoError(t, c.Init(ctx))
+	defer c.Close()
+
+	// первая проверка идет сразу после Init; Клепу еще ни разу не кормили
+	select {
+	case o := <-reports:
+		require.Len(t, o, 1)
+		assert.Nil(t, o[0].LastFedAt)
+	case <-time.After(time.Second):
+		t.Fatal("checker did not run")
+	}
+}
+
+func fieldRules(err error) []string {
+	var rules []string
+	for _, f := range errs.Fields(err) {
+		rules = append(rules, f.Field+":"+f.Rule)
+	}
+	return rules
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+)
+
+type KeeperService struct {
+	r          database.KeeperRepository
+	animals    database.AnimalRepository
+	enclosures database.EnclosureRepository
+	v          *Validator
+}
+
+func NewKeeperService(r database.KeeperRepository, animals database.AnimalRepository, enclosures database.EnclosureRepository, v *Validator) *KeeperService {
+	return &KeeperService{r: r, animals: animals, enclosures: enclosures, v: v}
+}
+
+func (s *KeeperService) ListKeepers(ctx context.Context) ([]mod.Keeper, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.List(ctx)
+}
+
+func (s *KeeperService) GetKeeper(ctx context.Context, idKeeper int64) (*mod.Keeper, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, idKeeper)
+}
+
+func (s *KeeperService) AddKeeper(ctx context.Context, k *mod.Keeper) (*mod.Keeper, error) {
+	if err := authorize(ctx, PermStaffWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.Keeper(k); err != nil {
+		return nil, err
+	}
+	id, err := s.r.Add(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, id)
+}
+
+// UpdateKeeper не дает отнять у смотрителя вид, животные которого за ним закреплены
+func (s *KeeperService) UpdateKeeper(ctx context.Context, k *mod.Keeper) (*mod.Keeper, error) {
+	if err := authorize(ctx, PermStaffWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.Keeper(k); err != nil {
+		return nil, err
+	}
+	if _, err := s.r.Get(ctx, k.IdKeeper); err != nil {
+		return nil, err
+	}
+	assigns, err := s.r.Assignments(ctx, mod.AssignmentFilter{IdKeeper: &k.IdKeeper})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assigns {
+		if err := s.checkQualified(ctx, k, &a); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.r.Update(ctx, k); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, k.IdKeeper)
+}
+
+func (s *KeeperService) DeleteKeeper(ctx context.Context, idKeeper int64) error {
+	if err := authorize(ctx, PermStaffWrite); err != nil {
+		return err
+	}
+	return s.r.Delete(ctx, idKeeper)
+}
+
+// Assign закрепляет смотрителя за животным или вольером. Смотритель должен
+// уметь работать с видом животного, а для вольера - со всеми видами, которые
+// в нем можно держать: тогда он годится для любого будущего жильца
+func (s *KeeperService) Assign(ctx context.Context, a *mod.KeeperAssignment) (*mod.KeeperAssignment, error) {
+	if err := authorize(ctx, PermStaffWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.KeeperAssignment(a); err != nil {
+		return nil, err
+	}
+	k, err := s.r.Get(ctx, a.IdKeeper)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkQualified(ctx, k, a); err != nil {
+		return nil, err
+	}
+	res := *a
+	if err := s.r.Assign(ctx, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (s *KeeperService) Unassign(ctx context.Context, idAssign int64) error {
+	if err := authorize(ctx, PermStaffWrite); err != nil {
+		return err
+	}
+	return s.r.Unassign(ctx, idAssign)
+}
+
+// Assignments - закрепления смотрителя
+func (s *KeeperService) Assignments(ctx context.Context, idKeeper int64) ([]mod.KeeperAssignment, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.r.Get(ctx, idKeeper); err != nil {
+		return nil, err
+	}
+	return s.r.Assignments(ctx, mod.AssignmentFilter{IdKeeper: &idKeeper})
+}
+
+// Responsible - кто отвечает за животное: закрепленные за ним самим
+// и за вольером, где оно сейчас живет
+func (s *KeeperService) Responsible(ctx context.Context, idAnim int64) ([]mod.Responsible, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	an, err := s.animals.Get(ctx, idAnim)
+	if err != nil {
+		return nil, err
+	}
+	assigns, err := s.r.Assignments(ctx, mod.AssignmentFilter{IdAnim: &idAnim})
+	if err != nil {
+		return nil, err
+	}
+	if an.IdEncl != nil {
+		viaEnclosure, err := s.r.Assignments(ctx, mod.AssignmentFilter{IdEncl: an.IdEncl})
+		if err != nil {
+			return nil, err
+		}
+		assigns = append(assigns, viaEnclosure...)
+	}
+	res := make([]mod.Responsible, 0, len(assigns))
+	for _, a := range assigns {
+		k, err := s.r.Get(ctx, a.IdKeeper)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, mod.Responsible{Keeper: *k, IdAssign: a.IdAssign, IdEncl: a.IdEncl})
+	}
+	return res, nil
+}
+
+// CoveredAnimals - животные, за которые отвечает смотритель, напрямую
+// или через вольер; каждое один раз, по id
+func (s *KeeperService) CoveredAnimals(ctx context.Context, idKeeper int64) ([]mod.Animal, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	assigns, err := s.Assignments(ctx, idKeeper)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]mod.Animal, 0)
+	for _, a := range assigns {
+		if a.IdAnim != nil {
+			an, err := s.animals.Get(ctx, *a.IdAnim)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, *an)
+			continue
+		}
+		enc, err := s.enclosures.Get(ctx, *a.IdEncl)
+		if err != nil {
+			return nil, err
+		}
+		if enc.Occupancy == 0 {
+			continue
+		}
+		residents, err := s.animals.GetAll(ctx, database.AnimalQuery{
+			Filter: mod.AnimalFilter{Enclosure: a.IdEncl},
+			Limit:  enc.Occupancy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, residents...)
+	}
+	slices.SortFunc(res, func(a, b mod.Animal) int { return cmp.Compare(a.IdAnim, b.IdAnim) })
+	return slices.CompactFunc(res, func(a, b mod.Animal) bool { return a.IdAnim == b.IdAnim }), nil
+}
+
+// checkQualified проверяет, что k умеет работать со всеми видами цели закрепления
+func (s *KeeperService) checkQualified(ctx context.Context, k *mod.Keeper, a *mod.KeeperAssignment) error {
+	if a.IdAnim != nil {
+		an, err := s.animals.Get(ctx, *a.IdAnim)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(k.Species, an.Title) {
+			return errs.Conflict("keeper %s is not qualified for species %s of animal %s", k.Name, an.Title, an.NameAn)
+		}
+		return nil
+	}
+	enc, err := s.enclosures.Get(ctx, *a.IdEncl)
+	if err != nil {
+		return err
+	}
+	for _, sp := range enc.Species {
+		if !slices.Contains(k.Species, sp) {
+			return errs.Conflict("keeper %s is not qualified for species %s of enclosure %s", k.Name, sp, enc.Name)
+		}
+	}
+	return nil
+}
+
+// checkAssignedKeepers - смотрители закреплений из f умеют работать со всеми species;
+// нужна, когда у животного или вольера меняются виды уже после закрепления
+func checkAssignedKeepers(ctx context.Context, keepers database.KeeperRepository, f mod.AssignmentFilter, species []string, target string) error {
+	assigns, err := keepers.Assignments(ctx, f)
+	if err != nil {
+		return err
+	}
+	for _, a := range assigns {
+		k, err := keepers.Get(ctx, a.IdKeeper)
+		if err != nil {
+			return err
+		}
+		for _, sp := range species {
+			if !slices.Contains(k.Species, sp) {
+				return errs.Conflict("keeper %s assigned to %s is not qualified for species %s", k.Name, target, sp)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeeperAssignments(t *testing.T) {
+	ctx := adminCtx()
+	h := newHousing(t)
+	keepers := service.NewKeeperService(database.NewMemKeeperRepository(h.st),
+		database.NewMemAnimalRepository(h.st), database.NewMemEnclosureRepository(h.st), service.NewValidator())
+	enc, err := h.enclosures.AddEnclosure(ctx, &models.Enclosure{
+		Name: "Pets", Zone: "north", Capacity: 5, Habitat: "forest", Species: []string{"cat", "dog"},
+	})
+	require.NoError(t, err)
+	klepa, rex, tom := h.animal(t, "Klepa", "cat"), h.animal(t, "Rex", "dog"), h.animal(t, "Tom", "cat")
+	for _, id := range []int64{klepa, rex} {
+		_, err = h.animals.AssignEnclosure(ctx, id, &enc.IdEncl)
+		require.NoError(t, err)
+	}
+
+	_, err = keepers.AddKeeper(ctx, &models.Keeper{Name: "Ivan", Role: "janitor"})
+	assert.ErrorIs(t, err, errs.ErrValidation)
+	_, err = keepers.AddKeeper(ctx, &models.Keeper{Name: "Ivan", Role: "keeper", Species: []string{"cat", ""}})
+	require.ErrorIs(t, err, errs.ErrValidation)
+	require.Len(t, errs.Fields(err), 1)
+	assert.Equal(t, "species[1]", errs.Fields(err)[0].Field)
+	_, err = keepers.AddKeeper(ctx, &models.Keeper{Name: "Ivan", Role: "keeper", Species: []string{"parrot"}})
+	assert.ErrorIs(t, err, errs.ErrUnknownSpecies)
+	ivan, err := keepers.AddKeeper(ctx, &models.Keeper{Name: "Ivan", Role: "keeper", Contact: "+7 900 000-00-00", Species: []string{"cat"}})
+	require.NoError(t, err)
+	olga, err := keepers.AddKeeper(ctx, &models.Keeper{Name: "Olga", Role: "senior_keeper", Species: []string{"dog", "cat"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cat", "dog"}, olga.Species)
+
+	_, err = keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper, IdAnim: &rex})
+	assert.ErrorIs(t, err, errs.ErrConflict, "ivan can not keep dogs")
+	_, err = keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper, IdEncl: &enc.IdEncl})
+	assert.ErrorIs(t, err, errs.ErrConflict, "enclosure also allows dogs")
+	_, err = keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper})
+	assert.ErrorIs(t, err, errs.ErrValidation)
+	direct, err := keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper, IdAnim: &klepa})
+	require.NoError(t, err)
+	_, err = keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper, IdAnim: &klepa})
+	assert.ErrorIs(t, err, database.ErrAssignmentExists)
+	_, err = keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper, IdAnim: &tom})
+	require.NoError(t, err)
+	viaEnc, err := keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: olga.IdKeeper, IdEncl: &enc.IdEncl})
+	require.NoError(t, err)
+
+	responsible, err := keepers.Responsible(ctx, klepa)
+	require.NoError(t, err)
+	require.Len(t, responsible, 2)
+	assert.Equal(t, "Ivan", responsible[0].Name)
+	assert.Equal(t, direct.IdAssign, responsible[0].IdAssign)
+	assert.Nil(t, responsible[0].IdEncl)
+	assert.Equal(t, "Olga", responsible[1].Name)
+	assert.Equal(t, &enc.IdEncl, responsible[1].IdEncl)
+
+	covered, err := keepers.CoveredAnimals(ctx, olga.IdKeeper)
+	require.NoError(t, err)
+	require.Len(t, covered, 2)
+	assert.Equal(t, []int64{klepa, rex}, []int64{covered[0].IdAnim, covered[1].IdAnim})
+	covered, err = keepers.CoveredAnimals(ctx, ivan.IdKeeper)
+	require.NoError(t, err)
+	assert.Len(t, covered, 2)
+
+	// у Ольги нельзя отнять собак, пока за ней вольер с собаками
+	olga.Species = []string{"cat"}
+	_, err = keepers.UpdateKeeper(ctx, olga)
+	assert.ErrorIs(t, err, errs.ErrConflict)
+	require.NoError(t, keepers.Unassign(ctx, viaEnc.IdAssign))
+	_, err = keepers.UpdateKeeper(ctx, olga)
+	require.NoError(t, err)
+	assert.ErrorIs(t, keepers.Unassign(ctx, viaEnc.IdAssign), database.ErrAssignmentNotFound)
+
+	// удаление животного снимает закрепления за ним
+	require.NoError(t, h.animals.DeleteAnimal(ctx, tom))
+	assigns, err := keepers.Assignments(ctx, ivan.IdKeeper)
+	require.NoError(t, err)
+	assert.Len(t, assigns, 1)
+
+	viewer := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "guest", Role: models.RoleViewer})
+	_, err = keepers.Assign(viewer, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper, IdAnim: &rex})
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+}
+
+func TestSpeciesChangesKeepKeepersQualified(t *testing.T) {
+	ctx := adminCtx()
+	h := newHousing(t)
+	keepers := service.NewKeeperService(database.NewMemKeeperRepository(h.st),
+		database.NewMemAnimalRepository(h.st), database.NewMemEnclosureRepository(h.st), service.NewValidator())
+	enc, err := h.enclosures.AddEnclosure(ctx, &models.Enclosure{
+		Name: "Cats", Zone: "north", Capacity: 2, Habitat: "forest", Species: []string{"cat"},
+	})
+	require.NoError(t, err)
+	klepa := h.animal(t, "Klepa", "cat")
+	ivan, err := keepers.AddKeeper(ctx, &models.Keeper{Name: "Ivan", Role: "keeper", Species: []string{"cat"}})
+	require.NoError(t, err)
+	_, err = keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper, IdEncl: &enc.IdEncl})
+	require.NoError(t, err)
+	_, err = keepers.Assign(ctx, &models.KeeperAssignment{IdKeeper: ivan.IdKeeper, IdAnim: &klepa})
+	require.NoError(t, err)
+
+	enc.Species = []string{"cat", "dog"}
+	_, err = h.enclosures.UpdateEnclosure(ctx, enc)
+	assert.ErrorIs(t, err, errs.ErrConflict, "ivan can not keep dogs")
+	dog := "dog"
+	_, err = h.animals.Patch(ctx, klepa, &models.AnimalPatch{Title: &dog})
+	assert.ErrorIs(t, err, errs.ErrConflict)
+	_, err = h.animals.Update(ctx, &models.Animal{IdAnim: klepa, NameAn: "Klepa", BirthDate: born, Gender: "m", Title: "dog"})
+	assert.ErrorIs(t, err, errs.ErrConflict)
+
+	ivan.Species = []string{"cat", "dog"}
+	_, err = keepers.UpdateKeeper(ctx, ivan)
+	require.NoError(t, err)
+	_, err = h.enclosures.UpdateEnclosure(ctx, enc)
+	assert.NoError(t, err)
+	_, err = h.animals.Patch(ctx, klepa, &models.AnimalPatch{Title: &dog})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/tracing"
+)
+
+// Глубина родословной; оценка пары всегда смотрит на MaxGenerations поколений
+const (
+	DefaultGenerations = 3
+	MaxGenerations     = 10
+)
+
+type (
+	// BreedingConfig - пара допустима, если инбридинг потомства не выше MaxInbreeding
+	BreedingConfig struct {
+		MaxInbreeding float64
+	}
+
+	LineageService struct {
+		r       database.LineageRepository
+		animals database.AnimalRepository
+		v       *Validator
+		cfg     BreedingConfig
+	}
+
+	// lineNode - особь в загруженной части родословной, mother и father - ключи в lineage
+	lineNode struct {
+		rel            mod.Relative
+		mother, father string
+	}
+
+	// lineage - загруженная часть родословной по ключам "a:<id>" для животных зоопарка
+	// и "e:<метка>" для внешних. Внешние родители - основатели, их предки неизвестны
+	lineage map[string]*lineNode
+)
+
+var (
+	ErrInvalidGenerations error = errs.BadRequest("generations must be from 1 to %d", MaxGenerations)
+	// ErrPedigreeCycle - испорченные данные: животное оказалось собственным предком
+	ErrPedigreeCycle error = errs.Conflict("pedigree contains a cycle")
+)
+
+func NewLineageService(r database.LineageRepository, animals database.AnimalRepository, v *Validator, cfg *BreedingConfig) *LineageService {
+	return &LineageService{r: r, animals: animals, v: v, cfg: *cfg}
+}
+
+func animalKey(id int64) string {
+	return "a:" + strconv.FormatInt(id, 10)
+}
+
+// parentKey - пустая строка для неизвестного родителя
+func parentKey(p mod.Parent) string {
+	switch {
+	case p.IdAnim != nil:
+		return animalKey(*p.IdAnim)
+	case p.External != "":
+		return "e:" + p.External
+	}
+	return ""
+}
+
+// SetParents заменяет родителей животного. Родитель из зоопарка должен быть того же вида,
+// подходящего пола, родиться раньше потомка и не быть его потомком. Порядок рождений
+// сам по себе циклы не исключает: дату рождения можно поправить и позже
+func (s *LineageService) SetParents(ctx context.Context, idAnim int64, mother, father mod.Parent) (_ *mod.Kin, err error) {
+	ctx, span := tracing.Start(ctx, "LineageService.SetParents")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermLineageWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.Parents(mother, father); err != nil {
+		return nil, err
+	}
+	child, err := s.animals.Get(ctx, idAnim)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range []struct {
+		role, gender string
+		parent       mod.Parent
+	}{{"mother", "f", mother}, {"father", "m", father}} {
+		if p.parent.IdAnim == nil {
+			continue
+		}
+		if *p.parent.IdAnim == idAnim {
+			return nil, errs.Conflict("animal can not be its own %s", p.role)
+		}
+		parent, err := s.animals.Get(ctx, *p.parent.IdAnim)
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, database.ErrParentNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case parent.Gender != p.gender:
+			return nil, errs.Conflict("%s %s has gender %s", p.role, parent.NameAn, parent.Gender)
+		case parent.Title != child.Title:
+			return nil, errs.Conflict("%s %s is a %s, not a %s", p.role, parent.NameAn, parent.Title, child.Title)
+		case !parent.BirthDate.Before(child.BirthDate):
+			return nil, errs.Conflict("%s %s is not born before %s", p.role, parent.NameAn, child.NameAn)
+		}
+		descendant, err := isAncestor(ctx, s.r, idAnim, *p.parent.IdAnim)
+		if err != nil {
+			return nil, err
+		}
+		if descendant {
+			return nil, errs.Conflict("%s %s is a descendant of %s", p.role, parent.NameAn, child.NameAn)
+		}
+	}
+	if err := s.r.SetParents(ctx, idAnim, mother, father); err != nil {
+		return nil, err
+	}
+	kin, err := s.r.Kin(ctx, []int64{idAnim})
+	if err != nil {
+		return nil, err
+	}
+	if len(kin) == 0 {
+		return nil, database.ErrNotFound
+	}
+	return &kin[0], nil
+}
+
+// Pedigree - дерево предков животного и его потомки на generations поколений
+func (s *LineageService) Pedigree(ctx context.Context, idAnim int64, generations int) (_ *mod.Pedigree, err error) {
+	ctx, span := tracing.Start(ctx, "LineageService.Pedigree")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if generations < 1 || generations > MaxGenerations {
+		return nil, ErrInvalidGenerations
+	}
+	g, err := s.ancestors(ctx, []int64{idAnim}, generations)
+	if err != nil {
+		return nil, err
+	}
+	root := g.tree(animalKey(idAnim), generations)
+	if root == nil {
+		return nil, database.ErrNotFound
+	}
+	descendants, err := s.descendants(ctx, idAnim, generations)
+	if err != nil {
+		return nil, err
+	}
+	return &mod.Pedigree{PedigreeNode: *root, Descendants: descendants}, nil
+}
+
+// Evaluate оценивает предполагаемую пару. Коэффициент инбридинга потомства равен
+// коэффициенту родства родителей; предки дальше MaxGenerations считаются неизвестными
+func (s *LineageService) Evaluate(ctx context.Context, sireID, damID int64) (_ *mod.BreedingEvaluation, err error) {
+	ctx, span := tracing.Start(ctx, "LineageService.Evaluate")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	sire, err := s.animals.Get(ctx, sireID)
+	if err != nil {
+		return nil, err
+	}
+	dam, err := s.animals.Get(ctx, damID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case sireID == damID:
+		return nil, errs.Conflict("sire and dam must be different animals")
+	case sire.Gender != "m":
+		return nil, errs.Conflict("sire %s is not male", sire.NameAn)
+	case dam.Gender != "f":
+		return nil, errs.Conflict("dam %s is not female", dam.NameAn)
+	case sire.Title != dam.Title:
+		return nil, errs.Conflict("sire %s and dam %s are of different species", sire.NameAn, dam.NameAn)
+	}
+
+	g, err := s.ancestors(ctx, []int64{sireID, damID}, MaxGenerations)
+	if err != nil {
+		return nil, err
+	}
+	sk, dk := animalKey(sireID), animalKey(damID)
+	if g[sk] == nil || g[dk] == nil {
+		// животное удалили между Get и загрузкой родословной
+		return nil, database.ErrNotFound
+	}
+	inbreeding, err := g.kinship(sk, dk)
+	if err != nil {
+		return nil, err
+	}
+	res := &mod.BreedingEvaluation{
+		Sire:            g[sk].rel,
+		Dam:             g[dk].rel,
+		Inbreeding:      inbreeding,
+		CommonAncestors: g.common(sk, dk),
+		MaxInbreeding:   s.cfg.MaxInbreeding,
+	}
+	res.Acceptable = res.Inbreeding <= s.cfg.MaxInbreeding
+	return res, nil
+}
+
+// ancestors загружает ids и их предков на generations поколений вверх, по поколению за запрос.
+// У особей последнего поколения родители не заполняются
+func (s *LineageService) ancestors(ctx context.Context, ids []int64, generations int) (lineage, error) {
+	g := make(lineage)
+	frontier := ids
+	for gen := 0; gen <= generations && len(frontier) > 0; gen++ {
+		kin, err := s.r.Kin(ctx, frontier)
+		if err != nil {
+			return nil, err
+		}
+		frontier = nil
+		for _, k := range kin {
+			id := k.IdAnim
+			n := &lineNode{rel: mod.Relative{IdAnim: &id, NameAn: k.NameAn}}
+			g[animalKey(id)] = n
+			if gen == generations {
+				continue
+			}
+			n.mother, n.father = parentKey(k.Mother), parentKey(k.Father)
+			for _, p := range []mod.Parent{k.Mother, k.Father} {
+				key := parentKey(p)
+				switch {
+				case p.IdAnim != nil:
+					if _, ok := g[key]; !ok {
+						frontier = append(frontier, *p.IdAnim)
+					}
+				case p.External != "":
+					g[key] = &lineNode{rel: mod.Relative{External: p.External, NameAn: p.External}}
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+// isAncestor проверяет, есть ли ancestor среди предков idAnim, поднимаясь по поколению
+// за запрос до самых основателей. Уже встреченные особи не загружаются повторно,
+// поэтому и испорченная родословная с циклом не зациклит обход
+func isAncestor(ctx context.Context, r database.LineageRepository, ancestor, idAnim int64) (bool, error) {
+	seen := map[int64]bool{idAnim: true}
+	frontier := []int64{idAnim}
+	for len(frontier) > 0 {
+		kin, err := r.Kin(ctx, frontier)
+		if err != nil {
+			return false, err
+		}
+		frontier = nil
+		for _, k := range kin {
+			for _, p := range []mod.Parent{k.Mother, k.Father} {
+				if p.IdAnim == nil || seen[*p.IdAnim] {
+					continue
+				}
+				if *p.IdAnim == ancestor {
+					return true, nil
+				}
+				seen[*p.IdAnim] = true
+				frontier = append(frontier, *p.IdAnim)
+			}
+		}
+	}
+	return false, nil
+}
+
+// checkBirthOrder - после смены даты рождения an по-прежнему младше своих родителей
+// из зоопарка и старше своих детей
+func checkBirthOrder(ctx context.Context, r database.LineageRepository, an *mod.Animal) error {
+	kin, err := r.Kin(ctx, []int64{an.IdAnim})
+	if err != nil {
+		return err
+	}
+	var parentIDs []int64
+	for _, k := range kin {
+		for _, p := range []mod.Parent{k.Mother, k.Father} {
+			if p.IdAnim != nil {
+				parentIDs = append(parentIDs, *p.IdAnim)
+			}
+		}
+	}
+	if len(parentIDs) > 0 {
+		parents, err := r.Kin(ctx, parentIDs)
+		if err != nil {
+			return err
+		}
+		for _, p := range parents {
+			if !p.BirthDate.Before(an.BirthDate) {
+				return errs.Conflict("parent %s is not born before %s", p.NameAn, an.NameAn)
+			}
+		}
+	}
+	children, err := r.Children(ctx, []int64{an.IdAnim})
+	if err != nil {
+		return err
+	}
+	for _, c := range children {
+		if !an.BirthDate.Before(c.BirthDate) {
+			return errs.Conflict("%s is not born before its child %s", an.NameAn, c.NameAn)
+		}
+	}
+	return nil
+}
+
+// descendants - потомки в порядке поколений; встреченный по нескольким линиям
+// потомок попадает в ближайшее поколение
+func (s *LineageService) descendants(ctx context.Context, idAnim int64, generations int) ([]mod.Descendant, error) {
+	res := make([]mod.Descendant, 0)
+	seen := map[int64]bool{idAnim: true}
+	frontier := []int64{idAnim}
+	for gen := 1; gen <= generations && len(frontier) > 0; gen++ {
+		children, err := s.r.Children(ctx, frontier)
+		if err != nil {
+			return nil, err
+		}
+		frontier = nil
+		for _, c := range children {
+			if seen[c.IdAnim] {
+				continue
+			}
+			seen[c.IdAnim] = true
+			res = append(res, mod.Descendant{IdAnim: c.IdAnim, NameAn: c.NameAn, Generation: gen})
+			frontier = append(frontier, c.IdAnim)
+		}
+	}
+	return res, nil
+}
+
+func (g lineage) tree(key string, depth int) *mod.PedigreeNode {
+	n, ok := g[key]
+	if !ok {
+		return nil
+	}
+	node := &mod.PedigreeNode{Relative: n.rel}
+	if depth > 0 {
+		node.Mother = g.tree(n.mother, depth-1)
+		node.Father = g.tree(n.father, depth-1)
+	}
+	return node
+}
+
+// kinship - коэффициент родства по рекурсии f(x, y) = (f(мать x, y) + f(отец x, y)) / 2,
+// верной, пока x не предок y. Поэтому раскрывается более глубокий из двух: предок всегда
+// мельче потомка. f(x, x) = (1 + F(x)) / 2, где F(x) - родство родителей x.
+// Цикл в родословной рекурсию не обрывает, поэтому глубина и пары, которые еще
+// вычисляются, отмечаются, и повторный заход в них дает ErrPedigreeCycle
+func (g lineage) kinship(x, y string) (float64, error) {
+	cyclic := false
+	const inProgress = -2
+	depth := make(map[string]int)
+	var depthOf func(key string) int
+	depthOf = func(key string) int {
+		n, ok := g[key]
+		if !ok {
+			return -1
+		}
+		if d, ok := depth[key]; ok {
+			if d == inProgress {
+				cyclic = true
+				return 0
+			}
+			return d
+		}
+		depth[key] = inProgress
+		d := 1 + max(depthOf(n.mother), depthOf(n.father))
+		depth[key] = d
+		return d
+	}
+
+	memo := make(map[[2]string]float64)
+	busy := make(map[[2]string]bool)
+	var f func(x, y string) float64
+	f = func(x, y string) float64 {
+		if cyclic || g[x] == nil || g[y] == nil {
+			return 0
+		}
+		if x > y {
+			x, y = y, x
+		}
+		pair := [2]string{x, y}
+		if v, ok := memo[pair]; ok {
+			return v
+		}
+		if busy[pair] {
+			cyclic = true
+			return 0
+		}
+		busy[pair] = true
+		defer delete(busy, pair)
+		var v float64
+		if x == y {
+			v = (1 + f(g[x].mother, g[x].father)) / 2
+		} else {
+			if depthOf(x) < depthOf(y) {
+				x, y = y, x
+			}
+			v = (f(g[x].mother, y) + f(g[x].father, y)) / 2
+		}
+		memo[pair] = v
+		return v
+	}
+	v := f(x, y)
+	if cyclic {
+		return 0, ErrPedigreeCycle
+	}
+	return v, nil
+}
+
+// distances - число поколений от key до каждого его предка, сам key на расстоянии 0.
+// Обход в ширину с res в роли посещенных, так что цикл не зацикливает его
+func (g lineage) distances(key string) map[string]int {
+	res := map[string]int{key: 0}
+	frontier := []string{key}
+	for d := 1; len(frontier) > 0; d++ {
+		var next []string
+		for _, k := range frontier {
+			for _, p := range []string{g[k].mother, g[k].father} {
+				if _, ok := g[p]; !ok {
+					continue
+				}
+				if _, ok := res[p]; !ok {
+					res[p] = d
+					next = append(next, p)
+				}
+			}
+		}
+		frontier = next
+	}
+	return res
+}
+
+// common - общие предки пары, от ближайших к дальним. Если один из пары - предок другого,
+// он тоже попадает в список
+func (g lineage) common(x, y string) []mod.Relative {
+	dx, dy := g.distances(x), g.distances(y)
+	type shared struct {
+		key  string
+		dist int
+	}
+	var keys []shared
+	for k, d := range dx {
+		if e, ok := dy[k]; ok {
+			keys = append(keys, shared{k, d + e})
+		}
+	}
+	slices.SortFunc(keys, func(a, b shared) int {
+		return cmp.Or(cmp.Compare(a.dist, b.dist), cmp.Compare(a.key, b.key))
+	})
+	res := make([]mod.Relative, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, g[k.key].rel)
+	}
+	return res
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type family struct {
+	s       *service.LineageService
+	r       database.LineageRepository
+	animals database.AnimalRepository
+	ids     map[string]int64
+}
+
+func newFamily(t *testing.T) *family {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	f := &family{animals: database.NewMemAnimalRepository(st), ids: make(map[string]int64)}
+	f.r = database.NewMemLineageRepository(st)
+	f.s = service.NewLineageService(f.r, f.animals, service.NewValidator(), &service.BreedingConfig{MaxInbreeding: 0.0625})
+	for _, an := range []struct {
+		name, gender string
+		year         int
+	}{
+		{"Tom", "m", 2010}, {"Klepa", "f", 2010}, {"Mura", "f", 2011},
+		{"Vaska", "m", 2015}, {"Muska", "f", 2015}, {"Barsik", "m", 2016},
+		{"Leo", "m", 2015}, {"Lia", "f", 2015},
+	} {
+		id, err := f.animals.Add(ctx, &models.Animal{NameAn: an.name, BirthDate: time.Date(an.year, 1, 1, 0, 0, 0, 0, time.UTC), Gender: an.gender, Title: "cat"})
+		require.NoError(t, err)
+		f.ids[an.name] = id
+	}
+	f.parents(t, "Vaska", f.animal("Klepa"), f.animal("Tom"))
+	f.parents(t, "Muska", f.animal("Klepa"), f.animal("Tom"))
+	f.parents(t, "Barsik", f.animal("Mura"), f.animal("Tom"))
+	f.parents(t, "Leo", models.Parent{External: "EEP 7"}, models.Parent{External: "EEP 1"})
+	f.parents(t, "Lia", models.Parent{External: "EEP 7"}, models.Parent{})
+	return f
+}
+
+func (f *family) animal(name string) models.Parent {
+	id := f.ids[name]
+	return models.Parent{IdAnim: &id}
+}
+
+func (f *family) parents(t *testing.T, name string, mother, father models.Parent) {
+	_, err := f.s.SetParents(adminCtx(), f.ids[name], mother, father)
+	require.NoError(t, err, name)
+}
+
+func TestBreedingEvaluate(t *testing.T) {
+	f := newFamily(t)
+	for _, tc := range []struct {
+		sire, dam  string
+		inbreeding float64
+		common     []string
+	}{
+		{"Tom", "Mura", 0, nil},
+		{"Vaska", "Muska", 0.25, []string{"Tom", "Klepa"}},
+		{"Barsik", "Muska", 0.125, []string{"Tom"}},
+		{"Tom", "Muska", 0.25, []string{"Tom"}},
+		{"Leo", "Lia", 0.125, []string{"EEP 7"}},
+	} {
+		ev, err := f.s.Evaluate(adminCtx(), f.ids[tc.sire], f.ids[tc.dam])
+		require.NoError(t, err, tc.sire+" x "+tc.dam)
+		assert.InDelta(t, tc.inbreeding, ev.Inbreeding, 1e-9, tc.sire+" x "+tc.dam)
+		assert.Equal(t, tc.inbreeding <= 0.0625, ev.Acceptable)
+		var common []string
+		for _, r := range ev.CommonAncestors {
+			common = append(common, r.NameAn)
+		}
+		assert.ElementsMatch(t, tc.common, common, tc.sire+" x "+tc.dam)
+	}
+
+	_, err := f.s.Evaluate(adminCtx(), f.ids["Muska"], f.ids["Vaska"])
+	assert.ErrorIs(t, err, errs.ErrConflict)
+}
+
+func TestInbredOffspring(t *testing.T) {
+	f := newFamily(t)
+	ctx := adminCtx()
+	kitten, err := f.animals.Add(ctx, &models.Animal{NameAn: "Kitten", BirthDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Gender: "m", Title: "cat"})
+	require.NoError(t, err)
+	f.ids["Kitten"] = kitten
+	f.parents(t, "Kitten", f.animal("Muska"), f.animal("Vaska"))
+
+	// бабка по обеим линиям: (1/2)^3 по пути через Vaska и столько же через Muska
+	ev, err := f.s.Evaluate(ctx, kitten, f.ids["Klepa"])
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, ev.Inbreeding, 1e-9)
+
+	p, err := f.s.Pedigree(ctx, f.ids["Tom"], 2)
+	require.NoError(t, err)
+	assert.Nil(t, p.Mother)
+	assert.Equal(t, []models.Descendant{
+		{IdAnim: f.ids["Vaska"], NameAn: "Vaska", Generation: 1},
+		{IdAnim: f.ids["Muska"], NameAn: "Muska", Generation: 1},
+		{IdAnim: f.ids["Barsik"], NameAn: "Barsik", Generation: 1},
+		{IdAnim: kitten, NameAn: "Kitten", Generation: 2},
+	}, p.Descendants)
+
+	p, err = f.s.Pedigree(ctx, kitten, 1)
+	require.NoError(t, err)
+	require.NotNil(t, p.Mother)
+	assert.Equal(t, "Muska", p.Mother.NameAn)
+	assert.Nil(t, p.Mother.Mother, "deeper than requested")
+
+	assert.ErrorIs(t, f.animals.Delete(ctx, f.ids["Muska"]), database.ErrAnimalHasOffspring)
+}
+
+func TestSetParentsRules(t *testing.T) {
+	f := newFamily(t)
+	ctx := adminCtx()
+	missing := int64(404)
+
+	for name, tc := range map[string]struct {
+		child          string
+		mother, father models.Parent
+		err            error
+	}{
+		"male mother":       {"Barsik", f.animal("Tom"), models.Parent{}, errs.ErrConflict},
+		"younger father":    {"Tom", models.Parent{}, f.animal("Vaska"), errs.ErrConflict},
+		"own parent":        {"Tom", models.Parent{}, f.animal("Tom"), errs.ErrConflict},
+		"unknown parent":    {"Tom", models.Parent{IdAnim: &missing}, models.Parent{}, database.ErrParentNotFound},
+		"id and external":   {"Tom", models.Parent{IdAnim: f.animal("Mura").IdAnim, External: "EEP 3"}, models.Parent{}, errs.ErrValidation},
+		"unknown offspring": {"Nobody", models.Parent{}, models.Parent{}, database.ErrNotFound},
+	} {
+		_, err := f.s.SetParents(ctx, f.ids[tc.child], tc.mother, tc.father)
+		assert.ErrorIs(t, err, tc.err, name)
+	}
+
+	_, err := f.s.Pedigree(ctx, f.ids["Tom"], service.MaxGenerations+1)
+	assert.ErrorIs(t, err, service.ErrInvalidGenerations)
+}
+
+func TestPedigreeCycles(t *testing.T) {
+	ctx := adminCtx()
+	f := newFamily(t)
+	// дата рождения Клепы сдвинута в обход сервиса, порядок рождений уже не мешает
+	require.NoError(t, f.animals.Update(ctx, &models.Animal{IdAnim: f.ids["Klepa"], NameAn: "Klepa",
+		BirthDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Gender: "f", Title: "cat"}))
+	_, err := f.s.SetParents(ctx, f.ids["Klepa"], f.animal("Muska"), models.Parent{})
+	assert.ErrorIs(t, err, errs.ErrConflict, "Muska is Klepa's daughter")
+
+	// испорченная родословная дает ошибку, а не переполнение стека
+	require.NoError(t, f.r.SetParents(ctx, f.ids["Klepa"], f.animal("Muska"), models.Parent{}))
+	_, err = f.s.Evaluate(ctx, f.ids["Vaska"], f.ids["Muska"])
+	assert.ErrorIs(t, err, service.ErrPedigreeCycle)
+	_, err = f.s.Pedigree(ctx, f.ids["Vaska"], service.MaxGenerations)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/tracing"
+	zl "github.com/rs/zerolog/log"
+)
+
+// Периоды усреднения ряда замеров; границы дней и недель (с понедельника) - в UTC
+const (
+	BucketNone = ""
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+const (
+	AlertWeightLoss = "weight_loss"
+	AlertWeightGain = "weight_gain"
+)
+
+type (
+	// MeasurementConfig - пороги тревог: изменение веса больше MaxLoss или MaxGain
+	// (доли, 0.1 - 10%) за Window. Нулевой порог отключает тревогу
+	MeasurementConfig struct {
+		Window  time.Duration
+		MaxLoss float64
+		MaxGain float64
+	}
+
+	MeasurementService struct {
+		r       database.MeasurementRepository
+		animals database.AnimalRepository
+		v       *Validator
+		cfg     MeasurementConfig
+		now     func() time.Time
+	}
+)
+
+var ErrInvalidBucket error = errs.BadRequest("invalid bucket, expected day or week")
+
+func NewMeasurementService(r database.MeasurementRepository, animals database.AnimalRepository, v *Validator, cfg *MeasurementConfig) *MeasurementService {
+	return &MeasurementService{r: r, animals: animals, v: v, cfg: *cfg, now: time.Now}
+}
+
+// Record сохраняет замер (без MeasuredAt - сейчас) и возвращает тревоги, которые он вызвал
+func (s *MeasurementService) Record(ctx context.Context, m *mod.Measurement) (_ *mod.Measurement, _ []mod.MeasurementAlert, err error) {
+	ctx, span := tracing.Start(ctx, "MeasurementService.Record")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermMeasurementsWrite); err != nil {
+		return nil, nil, err
+	}
+
+	now := s.now()
+	if m.MeasuredAt.IsZero() {
+		m.MeasuredAt = now
+	}
+	if err := s.v.Measurement(m, now); err != nil {
+		return nil, nil, err
+	}
+	animal, err := s.animals.Get(ctx, m.IdAnim)
+	if err != nil {
+		return nil, nil, err
+	}
+	id, err := s.r.Add(ctx, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := *m
+	res.IdMeas = id
+
+	if res.WeightKg == nil {
+		return &res, nil, nil
+	}
+	// тревогу вызывает только сам новый замер, а не более поздние из уже записанных
+	window, err := s.r.List(ctx, mod.MeasurementFilter{IdAnim: m.IdAnim, From: m.MeasuredAt.Add(-s.cfg.Window), To: m.MeasuredAt.Add(time.Nanosecond)})
+	if err != nil {
+		return nil, nil, err
+	}
+	alerts := s.detect(window)
+	for i := range alerts {
+		alerts[i].NameAn = animal.NameAn
+		zl.Warn().
+			Int64("id_anim", animal.IdAnim).
+			Str("name_animal", animal.NameAn).
+			Str("kind", alerts[i].Kind).
+			Float64("change", alerts[i].Change).
+			Msg("abnormal weight change")
+	}
+	return &res, alerts, nil
+}
+
+func (s *MeasurementService) Delete(ctx context.Context, idMeas int64) error {
+	if err := authorize(ctx, PermMeasurementsWrite); err != nil {
+		return err
+	}
+	return s.r.Delete(ctx, idMeas)
+}
+
+func (s *MeasurementService) List(ctx context.Context, f mod.MeasurementFilter) ([]mod.Measurement, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.animals.Get(ctx, f.IdAnim); err != nil {
+		return nil, err
+	}
+	return s.r.List(ctx, f)
+}
+
+// Latest - последний замер животного, nil - замеров нет
+func (s *MeasurementService) Latest(ctx context.Context, idAnim int64) (*mod.Measurement, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	return s.r.Latest(ctx, idAnim)
+}
+
+// Series - ряд замеров животного, усредненный по дням или неделям
+func (s *MeasurementService) Series(ctx context.Context, f mod.MeasurementFilter, bucket string) ([]mod.MeasurementPoint, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if bucket != BucketDay && bucket != BucketWeek {
+		return nil, ErrInvalidBucket
+	}
+	ms, err := s.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return downsample(ms, bucket), nil
+}
+
+// Alerts - тревоги по всем животным, последний замер веса которых был не раньше Window назад
+func (s *MeasurementService) Alerts(ctx context.Context) (_ []mod.MeasurementAlert, err error) {
+	ctx, span := tracing.Start(ctx, "MeasurementService.Alerts")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	// для последнего замера нужен еще один Window истории перед ним
+	ms, err := s.r.Since(ctx, now.Add(-2*s.cfg.Window))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]mod.MeasurementAlert, 0)
+	for start := 0; start < len(ms); {
+		end := start
+		for end < len(ms) && ms[end].IdAnim == ms[start].IdAnim {
+			end++
+		}
+		alerts := s.detect(ms[start:end])
+		start = end
+		if len(alerts) == 0 || alerts[0].To.MeasuredAt.Before(now.Add(-s.cfg.Window)) {
+			continue
+		}
+		animal, err := s.animals.Get(ctx, alerts[0].IdAnim)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range alerts {
+			a.NameAn = animal.NameAn
+			res = append(res, a)
+		}
+	}
+	return res, nil
+}
+
+// detect сравнивает последний замер веса с самым большим и самым маленьким за Window до него;
+// ms - замеры одного животного от старых к новым
+func (s *MeasurementService) detect(ms []mod.Measurement) []mod.MeasurementAlert {
+	last := -1
+	for i := len(ms) - 1; i >= 0; i-- {
+		if ms[i].WeightKg != nil {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return nil
+	}
+	latest := ms[last]
+	var heaviest, lightest *mod.Measurement
+	for i := range ms[:last] {
+		m := &ms[i]
+		if m.WeightKg == nil || m.MeasuredAt.Before(latest.MeasuredAt.Add(-s.cfg.Window)) {
+			continue
+		}
+		if heaviest == nil || *m.WeightKg > *heaviest.WeightKg {
+			heaviest = m
+		}
+		if lightest == nil || *m.WeightKg < *lightest.WeightKg {
+			lightest = m
+		}
+	}
+	var alerts []mod.MeasurementAlert
+	if heaviest != nil && s.cfg.MaxLoss > 0 {
+		if change := *latest.WeightKg / *heaviest.WeightKg - 1; -change > s.cfg.MaxLoss {
+			alerts = append(alerts, mod.MeasurementAlert{IdAnim: latest.IdAnim, Kind: AlertWeightLoss, From: *heaviest, To: latest, Change: change})
+		}
+	}
+	if lightest != nil && s.cfg.MaxGain > 0 {
+		if change := *latest.WeightKg / *lightest.WeightKg - 1; change > s.cfg.MaxGain {
+			alerts = append(alerts, mod.MeasurementAlert{IdAnim: latest.IdAnim, Kind: AlertWeightGain, From: *lightest, To: latest, Change: change})
+		}
+	}
+	return alerts
+}
+
+// downsample усредняет замеры (от старых к новым) по периодам bucket
+func downsample(ms []mod.Measurement, bucket string) []mod.MeasurementPoint {
+	type acc struct {
+		sum [3]float64
+		n   [3]int
+	}
+	points := make([]mod.MeasurementPoint, 0)
+	var cur acc
+	flush := func() {
+		p := &points[len(points)-1]
+		avg := func(i int) *float64 {
+			if cur.n[i] == 0 {
+				return nil
+			}
+			v := cur.sum[i] / float64(cur.n[i])
+			return &v
+		}
+		p.WeightKg, p.LengthCm, p.BodyCondition = avg(0), avg(1), avg(2)
+		cur = acc{}
+	}
+	for _, m := range ms {
+		at := bucketStart(m.MeasuredAt, bucket)
+		if len(points) == 0 || !points[len(points)-1].At.Equal(at) {
+			if len(points) > 0 {
+				flush()
+			}
+			points = append(points, mod.MeasurementPoint{At: at})
+		}
+		points[len(points)-1].Count++
+		if m.WeightKg != nil {
+			cur.sum[0] += *m.WeightKg
+			cur.n[0]++
+		}
+		if m.LengthCm != nil {
+			cur.sum[1] += *m.LengthCm
+			cur.n[1]++
+		}
+		if m.BodyCondition != nil {
+			cur.sum[2] += float64(*m.BodyCondition)
+			cur.n[2]++
+		}
+	}
+	if len(points) > 0 {
+		flush()
+	}
+	return points
+}
+
+func bucketStart(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if bucket == BucketWeek {
+		// неделя начинается с понедельника
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return day
+}
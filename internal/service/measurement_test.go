@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMeasurements(t *testing.T) (*service.MeasurementService, database.AnimalRepository) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	s := service.NewMeasurementService(database.NewMemMeasurementRepository(st), animals, service.NewValidator(),
+		&service.MeasurementConfig{Window: 30 * 24 * time.Hour, MaxLoss: 0.1, MaxGain: 0.2})
+	return s, animals
+}
+
+func TestMeasurementSeries(t *testing.T) {
+	ctx := adminCtx()
+	s, animals := newMeasurements(t)
+	klepa, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: born, Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+
+	// среда 1 мая, четверг 2 мая и понедельник 6 мая 2024
+	wed := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	for _, m := range []models.Measurement{
+		{MeasuredAt: wed, WeightKg: ptr(4.0), BodyCondition: ptr(5)},
+		{MeasuredAt: wed.Add(8 * time.Hour), WeightKg: ptr(4.2)},
+		{MeasuredAt: wed.AddDate(0, 0, 1), LengthCm: ptr(46.0)},
+		{MeasuredAt: wed.AddDate(0, 0, 5), WeightKg: ptr(4.4), BodyCondition: ptr(6)},
+	} {
+		m.IdAnim = klepa
+		_, _, err := s.Record(ctx, &m)
+		require.NoError(t, err)
+	}
+
+	days, err := s.Series(ctx, models.MeasurementFilter{IdAnim: klepa}, service.BucketDay)
+	require.NoError(t, err)
+	require.Len(t, days, 3)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), days[0].At)
+	assert.Equal(t, 2, days[0].Count)
+	assert.InDelta(t, 4.1, *days[0].WeightKg, 1e-9)
+	assert.InDelta(t, 5, *days[0].BodyCondition, 1e-9)
+	assert.Nil(t, days[0].LengthCm)
+	assert.Nil(t, days[1].WeightKg)
+
+	weeks, err := s.Series(ctx, models.MeasurementFilter{IdAnim: klepa}, service.BucketWeek)
+	require.NoError(t, err)
+	require.Len(t, weeks, 2)
+	assert.Equal(t, time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), weeks[0].At)
+	assert.Equal(t, 3, weeks[0].Count)
+	assert.InDelta(t, 4.1, *weeks[0].WeightKg, 1e-9)
+	assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), weeks[1].At)
+
+	_, err = s.Series(ctx, models.MeasurementFilter{IdAnim: klepa}, "month")
+	assert.ErrorIs(t, err, errs.ErrBadRequest)
+
+	_, _, err = s.Record(ctx, &models.Measurement{IdAnim: klepa, BodyCondition: ptr(10)})
+	require.ErrorIs(t, err, errs.ErrValidation)
+	assert.Equal(t, []string{"body_condition:one_of"}, fieldRules(err))
+}
+
+func TestWeightAlerts(t *testing.T) {
+	ctx := adminCtx()
+	s, animals := newMeasurements(t)
+	klepa, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: born, Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	tom, err := animals.Add(ctx, &models.Animal{NameAn: "Tom", BirthDate: born, Gender: "m", Title: "cat"})
+	require.NoError(t, err)
+
+	now := time.Now()
+	record := func(idAnim int64, daysAgo int, kg float64) []models.MeasurementAlert {
+		_, alerts, err := s.Record(ctx, &models.Measurement{IdAnim: idAnim, MeasuredAt: now.AddDate(0, 0, -daysAgo), WeightKg: &kg})
+		require.NoError(t, err)
+		return alerts
+	}
+	// потеря 9% за месяц - еще норма
+	assert.Empty(t, record(klepa, 40, 5.0))
+	assert.Empty(t, record(klepa, 25, 4.6))
+	assert.Empty(t, record(klepa, 1, 4.2))
+	// 5.0 -> 4.2 растянуто больше чем на 30 дней, а 4.6 -> 4.1 - это 11% потери
+	alerts := record(klepa, 0, 4.1)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, service.AlertWeightLoss, alerts[0].Kind)
+	assert.InDelta(t, 4.6, *alerts[0].From.WeightKg, 1e-9)
+	assert.InDelta(t, -0.1087, alerts[0].Change, 1e-3)
+	assert.Equal(t, "Klepa", alerts[0].NameAn)
+
+	// у Тома резкий набор веса, но давно - в текущие тревоги он не попадает
+	assert.Empty(t, record(tom, 70, 4.0))
+	assert.Len(t, record(tom, 60, 5.0), 1)
+
+	current, err := s.Alerts(ctx)
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+	assert.Equal(t, klepa, current[0].IdAnim)
+}
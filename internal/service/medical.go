@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/tracing"
+)
+
+// DueWindow - за сколько до срока прививка попадает в список предстоящих
+const DueWindow = 30 * 24 * time.Hour
+
+type MedicalService struct {
+	r       database.MedicalRepository
+	animals database.AnimalRepository
+	v       *Validator
+	now     func() time.Time
+}
+
+func NewMedicalService(r database.MedicalRepository, animals database.AnimalRepository, v *Validator) *MedicalService {
+	return &MedicalService{r: r, animals: animals, v: v, now: time.Now}
+}
+
+// AddRecord добавляет осмотр, диагноз или лечение; без RecordedAt - сейчас
+func (s *MedicalService) AddRecord(ctx context.Context, rec *mod.MedicalRecord) (*mod.MedicalRecord, error) {
+	if err := authorize(ctx, PermMedicalWrite); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if rec.RecordedAt.IsZero() {
+		rec.RecordedAt = now
+	}
+	if err := s.v.MedicalRecord(rec, now); err != nil {
+		return nil, err
+	}
+	id, err := s.r.AddRecord(ctx, rec)
+	if err != nil {
+		return nil, err
+	}
+	res := *rec
+	res.IdRec = id
+	return &res, nil
+}
+
+// AddMedication назначает курс препарата; IdRec, если задан, должен быть записью того же животного
+func (s *MedicalService) AddMedication(ctx context.Context, med *mod.Medication) (*mod.Medication, error) {
+	if err := authorize(ctx, PermMedicalWrite); err != nil {
+		return nil, err
+	}
+
+	if med.StartedAt.IsZero() {
+		med.StartedAt = s.now()
+	}
+	if err := s.v.Medication(med); err != nil {
+		return nil, err
+	}
+	if med.IdRec != nil {
+		rec, err := s.r.GetRecord(ctx, *med.IdRec)
+		if err != nil {
+			return nil, err
+		}
+		if rec.IdAnim != med.IdAnim {
+			return nil, errs.Conflict("medical record %d belongs to another animal", rec.IdRec)
+		}
+	}
+	id, err := s.r.AddMedication(ctx, med)
+	if err != nil {
+		return nil, err
+	}
+	res := *med
+	res.IdMed = id
+	return &res, nil
+}
+
+func (s *MedicalService) AddVaccination(ctx context.Context, vac *mod.Vaccination) (*mod.Vaccination, error) {
+	if err := authorize(ctx, PermMedicalWrite); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if vac.GivenAt.IsZero() {
+		vac.GivenAt = now
+	}
+	if err := s.v.Vaccination(vac, now); err != nil {
+		return nil, err
+	}
+	id, err := s.r.AddVaccination(ctx, vac)
+	if err != nil {
+		return nil, err
+	}
+	res := *vac
+	res.IdVac = id
+	return &res, nil
+}
+
+func (s *MedicalService) History(ctx context.Context, f mod.MedicalFilter) (*mod.MedicalHistory, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.animals.Get(ctx, f.IdAnim); err != nil {
+		return nil, err
+	}
+	return s.r.History(ctx, f)
+}
+
+// DueVaccinations - прививки по всему зоопарку, повтор которых нужен в ближайшие within или уже просрочен
+func (s *MedicalService) DueVaccinations(ctx context.Context, within time.Duration) ([]mod.DueVaccination, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.DueVaccinations(ctx, s.now().Add(within))
+}
+
+// HealthSummary собирает сводку из всей истории животного
+func (s *MedicalService) HealthSummary(ctx context.Context, idAnim int64) (_ *mod.HealthSummary, err error) {
+	ctx, span := tracing.Start(ctx, "MedicalService.HealthSummary")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	h, err := s.r.History(ctx, mod.MedicalFilter{IdAnim: idAnim})
+	if err != nil {
+		return nil, err
+	}
+	return summarize(h, s.now()), nil
+}
+
+// summarize полагается на порядок истории: от новых записей к старым
+func summarize(h *mod.MedicalHistory, now time.Time) *mod.HealthSummary {
+	sum := &mod.HealthSummary{
+		ActiveMedications: make([]mod.Medication, 0),
+		DueVaccinations:   make([]mod.Vaccination, 0),
+	}
+	for i := range h.Records {
+		rec := &h.Records[i]
+		switch {
+		case rec.Kind == "examination" && sum.LastExamination == nil:
+			sum.LastExamination = rec
+		case rec.Kind == "diagnosis" && sum.LastDiagnosis == nil:
+			sum.LastDiagnosis = rec
+		}
+	}
+	for _, med := range h.Medications {
+		if !med.StartedAt.After(now) && now.Before(med.StartedAt.AddDate(0, 0, med.Days)) {
+			sum.ActiveMedications = append(sum.ActiveMedications, med)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, vac := range h.Vaccinations {
+		if seen[vac.Vaccine] {
+			// более старая прививка той же вакциной уже перекрыта
+			continue
+		}
+		seen[vac.Vaccine] = true
+		if vac.DueAt != nil && !vac.DueAt.After(now.Add(DueWindow)) {
+			sum.DueVaccinations = append(sum.DueVaccinations, vac)
+		}
+	}
+	return sum
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthSummary(t *testing.T) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	s := service.NewMedicalService(database.NewMemMedicalRepository(st), animals, service.NewValidator())
+	klepa, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: born, Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	tom, err := animals.Add(ctx, &models.Animal{NameAn: "Tom", BirthDate: born, Gender: "m", Title: "cat"})
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	days := func(n int) time.Time { return now.AddDate(0, 0, n) }
+	for _, rec := range []models.MedicalRecord{
+		{Kind: "examination", Title: "routine", RecordedAt: days(-60)},
+		{Kind: "examination", Title: "limping", RecordedAt: days(-10)},
+		{Kind: "diagnosis", Title: "sprain", RecordedAt: days(-10)},
+	} {
+		rec.IdAnim, rec.Vet = klepa, "Dr. Aibolit"
+		_, err := s.AddRecord(ctx, &rec)
+		require.NoError(t, err)
+	}
+	treatment, err := s.AddRecord(ctx, &models.MedicalRecord{IdAnim: klepa, Kind: "treatment", Title: "bandage", Vet: "Dr. Aibolit", RecordedAt: days(-10)})
+	require.NoError(t, err)
+	_, err = s.AddMedication(ctx, &models.Medication{IdAnim: klepa, IdRec: &treatment.IdRec, Drug: "meloxicam", Dose: 0.5, DoseUnit: "mg", Days: 14, Vet: "Dr. Aibolit", StartedAt: days(-10)})
+	require.NoError(t, err)
+	_, err = s.AddMedication(ctx, &models.Medication{IdAnim: klepa, Drug: "vitamins", Dose: 1, DoseUnit: "tablet", Days: 5, Vet: "Dr. Aibolit", StartedAt: days(-10)})
+	require.NoError(t, err)
+
+	// курс по лечению чужого животного не назначить
+	_, err = s.AddMedication(ctx, &models.Medication{IdAnim: tom, IdRec: &treatment.IdRec, Drug: "meloxicam", Dose: 0.5, DoseUnit: "mg", Days: 14, Vet: "Dr. Aibolit"})
+	assert.ErrorIs(t, err, errs.ErrConflict)
+
+	for _, vac := range []models.Vaccination{
+		{IdAnim: klepa, Vaccine: "rabies", GivenAt: days(-400), DueAt: ptr(days(-35))},
+		{IdAnim: klepa, Vaccine: "rabies", GivenAt: days(-30), DueAt: ptr(days(335))},
+		{IdAnim: klepa, Vaccine: "panleukopenia", GivenAt: days(-350), DueAt: ptr(days(15))},
+		{IdAnim: tom, Vaccine: "rabies", GivenAt: days(-380), DueAt: ptr(days(-15))},
+	} {
+		vac.Vet = "Dr. Aibolit"
+		_, err := s.AddVaccination(ctx, &vac)
+		require.NoError(t, err)
+	}
+
+	sum, err := s.HealthSummary(ctx, klepa)
+	require.NoError(t, err)
+	require.NotNil(t, sum.LastExamination)
+	assert.Equal(t, "limping", sum.LastExamination.Title)
+	require.NotNil(t, sum.LastDiagnosis)
+	assert.Equal(t, "sprain", sum.LastDiagnosis.Title)
+	require.Len(t, sum.ActiveMedications, 1)
+	assert.Equal(t, "meloxicam", sum.ActiveMedications[0].Drug)
+	// старый срок по бешенству перекрыт новой прививкой
+	require.Len(t, sum.DueVaccinations, 1)
+	assert.Equal(t, "panleukopenia", sum.DueVaccinations[0].Vaccine)
+
+	due, err := s.DueVaccinations(ctx, service.DueWindow)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, "Tom", due[0].NameAn)
+	assert.Equal(t, "Klepa", due[1].NameAn)
+	due, err = s.DueVaccinations(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	_, err = s.AddVaccination(ctx, &models.Vaccination{IdAnim: klepa, Vaccine: "rabies", GivenAt: days(1), DueAt: ptr(days(-1)), Vet: "Dr. Aibolit"})
+	require.ErrorIs(t, err, errs.ErrValidation)
+	assert.Equal(t, []string{"given_at:max", "due_at:min"}, fieldRules(err))
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,415 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mi-raf/zooad/internal/database"
+	mod "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/tracing"
+	zl "github.com/rs/zerolog/log"
+)
+
+// Настроения встроенных правил. MoodCalm - когда ни одному правилу нечего сказать
+const (
+	MoodSick     mod.Mood = "sick"
+	MoodHungry   mod.Mood = "hungry"
+	MoodStressed mod.Mood = "stressed"
+	MoodSleepy   mod.Mood = "sleepy"
+	MoodPlayful  mod.Mood = "playful"
+	MoodContent  mod.Mood = "content"
+	MoodCalm     mod.Mood = "calm"
+)
+
+// moodPriority - при равном весе побеждает настроение, стоящее раньше;
+// настроения сторонних правил идут после встроенных по алфавиту
+var moodPriority = []mod.Mood{MoodSick, MoodHungry, MoodStressed, MoodSleepy, MoodPlayful, MoodContent, MoodCalm}
+
+type (
+	// MoodConfig - пороги встроенных правил. Ночь - часы [NightFrom, NightTo) в Location,
+	// может переходить через полночь; равные часы отключают правило времени суток
+	MoodConfig struct {
+		HungryAfter   time.Duration
+		FedRecently   time.Duration
+		MedicalWindow time.Duration
+		// ObservationWindow - сколько учитывается последнее наблюдение смотрителя
+		ObservationWindow time.Duration
+		NightFrom         int
+		NightTo           int
+		Location          *time.Location
+		// RecordEvery - как часто сохранять неизменившееся вычисленное настроение;
+		// изменившееся сохраняется сразу
+		RecordEvery time.Duration
+	}
+
+	// MoodState - все, на что смотрят правила. Собирается один раз на запрос,
+	// Now уже в часовом поясе зоопарка
+	MoodState struct {
+		Animal mod.Animal
+		Now    time.Time
+		// nil - кормлений не записано
+		LastFedAt *time.Time
+		Medical   *mod.MedicalHistory
+		// nil - наблюдений нет
+		LastObservation *mod.Observation
+		// nil - животное не размещено
+		Enclosure *mod.Enclosure
+	}
+
+	// MoodRule - правило настроения. Apply возвращает вклады правила или ничего и должно
+	// зависеть только от state, иначе одно и то же состояние даст разное настроение.
+	// Rule во вкладах заполняет MoodEngine
+	MoodRule interface {
+		Name() string
+		Apply(state *MoodState) []mod.MoodReason
+	}
+
+	MoodService interface {
+		Mood(ctx context.Context, animal *mod.Animal) (mod.Mood, []mod.MoodReason, error)
+	}
+
+	// MoodEngine складывает веса вкладов всех правил по настроениям и выбирает настроение
+	// с наибольшим весом
+	MoodEngine struct {
+		rules       []MoodRule
+		feedings    database.FeedingRepository
+		medical     database.MedicalRepository
+		enclosures  database.EnclosureRepository
+		obs         database.ObservationRepository
+		history     database.MoodRepository
+		loc         *time.Location
+		recordEvery time.Duration
+		now         func() time.Time
+	}
+
+	// FeedingRule - голоден, если не кормили дольше HungryAfter, доволен сразу после кормления
+	FeedingRule struct {
+		HungryAfter time.Duration
+		FedRecently time.Duration
+	}
+
+	// MedicalRule - болен во время курса лечения и после недавнего диагноза,
+	// нервничает после недавнего осмотра или процедуры
+	MedicalRule struct {
+		Window time.Duration
+	}
+
+	// OccupancyRule - нервничает в заполненном вольере, доволен в просторном
+	OccupancyRule struct{}
+
+	// ObservationRule - переносит в настроение последнее наблюдение смотрителя, если оно не старше
+	// Window. Вес каждого вклада - по severity наблюдения
+	ObservationRule struct {
+		Window time.Duration
+	}
+
+	// TimeOfDayRule - сонный ночью
+	TimeOfDayRule struct {
+		NightFrom int
+		NightTo   int
+	}
+)
+
+// DefaultMoodRules - встроенные правила; порядок определяет порядок равных по весу причин
+func DefaultMoodRules(cfg *MoodConfig) []MoodRule {
+	return []MoodRule{
+		FeedingRule{HungryAfter: cfg.HungryAfter, FedRecently: cfg.FedRecently},
+		MedicalRule{Window: cfg.MedicalWindow},
+		OccupancyRule{},
+		ObservationRule{Window: cfg.ObservationWindow},
+		TimeOfDayRule{NightFrom: cfg.NightFrom, NightTo: cfg.NightTo},
+	}
+}
+
+func NewMoodEngine(rules []MoodRule, feedings database.FeedingRepository, medical database.MedicalRepository, enclosures database.EnclosureRepository,
+	obs database.ObservationRepository, history database.MoodRepository, cfg *MoodConfig) *MoodEngine {
+	loc := cfg.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	return &MoodEngine{
+		rules:       rules,
+		feedings:    feedings,
+		medical:     medical,
+		enclosures:  enclosures,
+		obs:         obs,
+		history:     history,
+		loc:         loc,
+		recordEvery: cfg.RecordEvery,
+		now:         time.Now,
+	}
+}
+
+func (e *MoodEngine) Init(ctx context.Context) error {
+	return nil
+}
+
+func (e *MoodEngine) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (e *MoodEngine) Name() string {
+	return "mood"
+}
+
+func (e *MoodEngine) Close() error {
+	return nil
+}
+
+// Mood собирает состояние животного на текущий момент, оценивает его и сохраняет в историю
+func (e *MoodEngine) Mood(ctx context.Context, animal *mod.Animal) (_ mod.Mood, _ []mod.MoodReason, err error) {
+	ctx, span := tracing.Start(ctx, "MoodEngine.Mood")
+	defer func() { tracing.End(span, err) }()
+
+	st := &MoodState{Animal: *animal, Now: e.now().In(e.loc)}
+	feeds, err := e.feedings.ListFeedings(ctx, mod.FeedingFilter{IdAnim: animal.IdAnim, Limit: 1})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(feeds) > 0 {
+		st.LastFedAt = &feeds[0].FedAt
+	}
+	// курс лечения мог начаться задолго до окна, поэтому история целиком
+	if st.Medical, err = e.medical.History(ctx, mod.MedicalFilter{IdAnim: animal.IdAnim}); err != nil {
+		return "", nil, err
+	}
+	obs, err := e.obs.List(ctx, mod.ObservationFilter{IdAnim: &animal.IdAnim, Limit: 1})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(obs) > 0 {
+		st.LastObservation = &obs[0]
+	}
+	if animal.IdEncl != nil {
+		if st.Enclosure, err = e.enclosures.Get(ctx, *animal.IdEncl); err != nil {
+			return "", nil, err
+		}
+	}
+	mood, reasons := e.Evaluate(st)
+	if err := e.record(ctx, st, mood, reasons); err != nil {
+		// настроение в ответе важнее пропуска в истории
+		zl.Warn().Err(err).Int64("id_anim", animal.IdAnim).Msg("can't record computed mood")
+	}
+	return mood, reasons, nil
+}
+
+// record сохраняет вычисленное настроение, если оно отличается от последнего вычисленного
+// или то записано больше recordEvery назад, чтобы частые запросы не забивали историю
+func (e *MoodEngine) record(ctx context.Context, st *MoodState, mood mod.Mood, reasons []mod.MoodReason) error {
+	last, err := e.history.List(ctx, mod.MoodFilter{IdAnim: st.Animal.IdAnim, Source: mod.MoodSourceComputed, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(last) > 0 && last[0].Mood == mood && st.Now.Sub(last[0].ObservedAt) < e.recordEvery {
+		return nil
+	}
+	notes := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		notes = append(notes, r.Reason)
+	}
+	_, err = e.history.Add(ctx, &mod.MoodEntry{
+		IdAnim:     st.Animal.IdAnim,
+		Mood:       mood,
+		Source:     mod.MoodSourceComputed,
+		Notes:      truncate(strings.Join(notes, "; "), MaxDescriptionLength),
+		IdEncl:     st.Animal.IdEncl,
+		ObservedAt: st.Now,
+	})
+	return err
+}
+
+// truncate обрезает s до n символов
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// Evaluate применяет правила к состоянию. При равном весе настроение выбирается по
+// moodPriority, причины идут от самых весомых, равные - в порядке правил
+func (e *MoodEngine) Evaluate(state *MoodState) (mod.Mood, []mod.MoodReason) {
+	reasons := make([]mod.MoodReason, 0)
+	score := make(map[mod.Mood]int)
+	for _, rule := range e.rules {
+		for _, r := range rule.Apply(state) {
+			if r.Weight <= 0 {
+				continue
+			}
+			r.Rule = rule.Name()
+			reasons = append(reasons, r)
+			score[r.Mood] += r.Weight
+		}
+	}
+	if len(reasons) == 0 {
+		return MoodCalm, reasons
+	}
+	slices.SortStableFunc(reasons, func(a, b mod.MoodReason) int {
+		return cmp.Compare(b.Weight, a.Weight)
+	})
+	moods := make([]mod.Mood, 0, len(score))
+	for m := range score {
+		moods = append(moods, m)
+	}
+	slices.SortFunc(moods, func(a, b mod.Mood) int {
+		return cmp.Or(cmp.Compare(score[b], score[a]), cmp.Compare(moodRank(a), moodRank(b)), cmp.Compare(a, b))
+	})
+	return moods[0], reasons
+}
+
+func moodRank(m mod.Mood) int {
+	if i := slices.Index(moodPriority, m); i >= 0 {
+		return i
+	}
+	return len(moodPriority)
+}
+
+// ago - "3h5m" вместо "3h5m0s"
+func ago(d time.Duration) string {
+	s := d.Round(time.Minute).String()
+	if s == "0s" {
+		return "just now"
+	}
+	return strings.TrimSuffix(s, "0s") + " ago"
+}
+
+func (FeedingRule) Name() string {
+	return "feeding"
+}
+
+func (r FeedingRule) Apply(st *MoodState) []mod.MoodReason {
+	if st.LastFedAt == nil {
+		return nil
+	}
+	since := st.Now.Sub(*st.LastFedAt)
+	switch {
+	case r.HungryAfter > 0 && since > r.HungryAfter:
+		return []mod.MoodReason{{Mood: MoodHungry, Weight: 3, Reason: "last fed " + ago(since)}}
+	case since < r.FedRecently:
+		return []mod.MoodReason{{Mood: MoodContent, Weight: 2, Reason: "fed " + ago(since)}}
+	}
+	return nil
+}
+
+func (MedicalRule) Name() string {
+	return "medical"
+}
+
+func (r MedicalRule) Apply(st *MoodState) []mod.MoodReason {
+	if st.Medical == nil {
+		return nil
+	}
+	var res []mod.MoodReason
+	if active := summarize(st.Medical, st.Now).ActiveMedications; len(active) > 0 {
+		drugs := make([]string, 0, len(active))
+		for _, med := range active {
+			drugs = append(drugs, med.Drug)
+		}
+		res = append(res, mod.MoodReason{Mood: MoodSick, Weight: 3, Reason: "taking " + strings.Join(drugs, ", ")})
+	}
+	var diagnosis, visit bool
+	for _, rec := range st.Medical.Records {
+		since := st.Now.Sub(rec.RecordedAt)
+		if since < 0 {
+			continue
+		}
+		if since > r.Window {
+			// записи от новых к старым
+			break
+		}
+		switch {
+		case rec.Kind == "diagnosis" && !diagnosis:
+			diagnosis = true
+			res = append(res, mod.MoodReason{Mood: MoodSick, Weight: 2, Reason: fmt.Sprintf("diagnosed %s %s", rec.Title, ago(since))})
+		case rec.Kind != "diagnosis" && !visit:
+			visit = true
+			res = append(res, mod.MoodReason{Mood: MoodStressed, Weight: 1, Reason: fmt.Sprintf("%s %s %s", rec.Kind, rec.Title, ago(since))})
+		}
+	}
+	return res
+}
+
+func (OccupancyRule) Name() string {
+	return "occupancy"
+}
+
+func (OccupancyRule) Apply(st *MoodState) []mod.MoodReason {
+	enc := st.Enclosure
+	if enc == nil || enc.Capacity <= 0 {
+		return nil
+	}
+	switch {
+	case enc.Occupancy >= enc.Capacity:
+		return []mod.MoodReason{{Mood: MoodStressed, Weight: 2, Reason: fmt.Sprintf("enclosure %s is full (%d/%d)", enc.Name, enc.Occupancy, enc.Capacity)}}
+	case enc.Occupancy*2 <= enc.Capacity:
+		return []mod.MoodReason{{Mood: MoodContent, Weight: 1, Reason: fmt.Sprintf("enclosure %s is spacious (%d/%d)", enc.Name, enc.Occupancy, enc.Capacity)}}
+	}
+	return nil
+}
+
+func (ObservationRule) Name() string {
+	return "observation"
+}
+
+func (r ObservationRule) Apply(st *MoodState) []mod.MoodReason {
+	o := st.LastObservation
+	if o == nil {
+		return nil
+	}
+	since := st.Now.Sub(o.ObservedAt)
+	if since < 0 || since > r.Window {
+		return nil
+	}
+	// info - 1, high - 4
+	weight := slices.Index(Severities, o.Severity) + 1
+	var res []mod.MoodReason
+	add := func(mood mod.Mood, what string) {
+		res = append(res, mod.MoodReason{Mood: mood, Weight: weight, Reason: fmt.Sprintf("%s, noted by %s %s", what, o.Keeper, ago(since))})
+	}
+	switch o.Appetite {
+	case "none", "poor":
+		add(MoodSick, o.Appetite+" appetite")
+	case "good":
+		add(MoodContent, "good appetite")
+	}
+	if o.Stereotypic {
+		add(MoodStressed, "stereotypic behaviour")
+	}
+	switch o.Social {
+	case "aggressive", "withdrawn":
+		add(MoodStressed, o.Social)
+	case "affiliative":
+		add(MoodContent, "affiliative")
+	}
+	switch o.Activity {
+	case "low":
+		add(MoodSleepy, "low activity")
+	case "high":
+		add(MoodPlayful, "high activity")
+	}
+	return res
+}
+
+func (TimeOfDayRule) Name() string {
+	return "time_of_day"
+}
+
+func (r TimeOfDayRule) Apply(st *MoodState) []mod.MoodReason {
+	h := st.Now.Hour()
+	var night bool
+	switch {
+	case r.NightFrom < r.NightTo:
+		night = h >= r.NightFrom && h < r.NightTo
+	case r.NightFrom > r.NightTo:
+		night = h >= r.NightFrom || h < r.NightTo
+	}
+	if !night {
+		return nil
+	}
+	return []mod.MoodReason{{Mood: MoodSleepy, Weight: 2, Reason: "night time " + st.Now.Format("15:04")}}
+}
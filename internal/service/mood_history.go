@@ -0,0 +1,126 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/tracing"
+)
+
+// MoodHistoryService - отметки смотрителей и история настроений. Вычисленные настроения
+// записывает MoodEngine
+type MoodHistoryService struct {
+	r       database.MoodRepository
+	animals database.AnimalRepository
+	v       *Validator
+	now     func() time.Time
+}
+
+var (
+	ErrInvalidMoodSource   error = errs.BadRequest("invalid source, expected keeper or computed")
+	ErrInvalidMoodGrouping error = errs.BadRequest("invalid grouping, expected enclosure or species")
+)
+
+func NewMoodHistoryService(r database.MoodRepository, animals database.AnimalRepository, v *Validator) *MoodHistoryService {
+	return &MoodHistoryService{r: r, animals: animals, v: v, now: time.Now}
+}
+
+func checkMoodSource(source mod.MoodSource) error {
+	switch source {
+	case "", mod.MoodSourceKeeper, mod.MoodSourceComputed:
+		return nil
+	}
+	return ErrInvalidMoodSource
+}
+
+// Record сохраняет настроение, отмеченное смотрителем (без ObservedAt - сейчас),
+// вместе с текущим вольером животного
+func (s *MoodHistoryService) Record(ctx context.Context, m *mod.MoodEntry) (_ *mod.MoodEntry, err error) {
+	ctx, span := tracing.Start(ctx, "MoodHistoryService.Record")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermBehaviourWrite); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if m.ObservedAt.IsZero() {
+		m.ObservedAt = now
+	}
+	m.Source = mod.MoodSourceKeeper
+	if err := s.v.MoodEntry(m, now); err != nil {
+		return nil, err
+	}
+	animal, err := s.animals.Get(ctx, m.IdAnim)
+	if err != nil {
+		return nil, err
+	}
+	m.IdEncl = animal.IdEncl
+	id, err := s.r.Add(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	res := *m
+	res.IdMood = id
+	return &res, nil
+}
+
+func (s *MoodHistoryService) History(ctx context.Context, f mod.MoodFilter) ([]mod.MoodEntry, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if err := checkMoodSource(f.Source); err != nil {
+		return nil, err
+	}
+	if _, err := s.animals.Get(ctx, f.IdAnim); err != nil {
+		return nil, err
+	}
+	return s.r.List(ctx, f)
+}
+
+// Distribution - доли настроений в каждой группе; группы по названию, настроения
+// от частых к редким, равные - в порядке moodPriority
+func (s *MoodHistoryService) Distribution(ctx context.Context, f mod.MoodDistributionFilter) (_ []mod.MoodDistribution, err error) {
+	ctx, span := tracing.Start(ctx, "MoodHistoryService.Distribution")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if f.GroupBy != mod.MoodByEnclosure && f.GroupBy != mod.MoodBySpecies {
+		return nil, ErrInvalidMoodGrouping
+	}
+	if err := checkMoodSource(f.Source); err != nil {
+		return nil, err
+	}
+	counts, err := s.r.Distribution(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]mod.MoodDistribution, 0)
+	for _, c := range counts {
+		if len(res) == 0 || res[len(res)-1].Group != c.Group {
+			res = append(res, mod.MoodDistribution{Group: c.Group})
+		}
+		g := &res[len(res)-1]
+		g.Total += c.Count
+		g.Moods = append(g.Moods, mod.MoodShare{Mood: c.Mood, Count: c.Count})
+	}
+	for i := range res {
+		g := &res[i]
+		for j := range g.Moods {
+			g.Moods[j].Share = float64(g.Moods[j].Count) / float64(g.Total)
+		}
+		slices.SortFunc(g.Moods, func(a, b mod.MoodShare) int {
+			return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(moodRank(a.Mood), moodRank(b.Mood)), cmp.Compare(a.Mood, b.Mood))
+		})
+	}
+	return res, nil
+}
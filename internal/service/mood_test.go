@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMoodEngine(st *database.MemStorage, cfg *service.MoodConfig, extra ...service.MoodRule) *service.MoodEngine {
+	rules := append(service.DefaultMoodRules(cfg), extra...)
+	return service.NewMoodEngine(rules, database.NewMemFeedingRepository(st), database.NewMemMedicalRepository(st),
+		database.NewMemEnclosureRepository(st), database.NewMemObservationRepository(st), database.NewMemMoodRepository(st), cfg)
+}
+
+// playfulRule - стороннее правило со своим настроением
+type playfulRule struct{}
+
+func (playfulRule) Name() string { return "toys" }
+
+func (playfulRule) Apply(st *service.MoodState) []models.MoodReason {
+	if st.Animal.Title != "cat" {
+		return nil
+	}
+	return []models.MoodReason{{Mood: "playful", Weight: 5, Reason: "new toy"}}
+}
+
+func TestMoodRules(t *testing.T) {
+	cfg := &service.MoodConfig{HungryAfter: 8 * time.Hour, FedRecently: time.Hour, MedicalWindow: 7 * 24 * time.Hour, NightFrom: 22, NightTo: 6}
+	e := newMoodEngine(database.NewMemStorage(), cfg)
+	noon := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 3, 10, 23, 30, 0, 0, time.UTC)
+	at := func(t time.Time, d time.Duration) *time.Time { v := t.Add(-d); return &v }
+	taking := &models.MedicalHistory{Medications: []models.Medication{{Drug: "amoxicillin", StartedAt: noon.AddDate(0, 0, -2), Days: 5}}}
+
+	for name, tc := range map[string]struct {
+		state models.Mood
+		st    service.MoodState
+		rules []string
+	}{
+		"nothing known": {service.MoodCalm, service.MoodState{Now: noon}, nil},
+		"hungry":        {service.MoodHungry, service.MoodState{Now: noon, LastFedAt: at(noon, 10*time.Hour)}, []string{"feeding"}},
+		"fed in spacious enclosure": {service.MoodContent, service.MoodState{Now: noon, LastFedAt: at(noon, 10*time.Minute),
+			Enclosure: &models.Enclosure{Name: "North", Capacity: 4, Occupancy: 1}}, []string{"feeding", "occupancy"}},
+		"sick at night": {service.MoodSick, service.MoodState{Now: night, Medical: taking}, []string{"medical", "time_of_day"}},
+		"full at night, tie by priority": {service.MoodStressed, service.MoodState{Now: night,
+			Enclosure: &models.Enclosure{Name: "North", Capacity: 2, Occupancy: 2}}, []string{"occupancy", "time_of_day"}},
+		"old diagnosis forgotten": {service.MoodCalm, service.MoodState{Now: noon, Medical: &models.MedicalHistory{
+			Records: []models.MedicalRecord{{Kind: "diagnosis", Title: "otitis", RecordedAt: noon.AddDate(0, -1, 0)}}}}, nil},
+	} {
+		mood, reasons := e.Evaluate(&tc.st)
+		assert.Equal(t, tc.state, mood, name)
+		var rules []string
+		for _, r := range reasons {
+			rules = append(rules, r.Rule)
+		}
+		assert.Equal(t, tc.rules, rules, name)
+
+		again, same := e.Evaluate(&tc.st)
+		assert.Equal(t, mood, again, name)
+		assert.Equal(t, reasons, same, name)
+	}
+
+	mood, reasons := newMoodEngine(database.NewMemStorage(), cfg, playfulRule{}).Evaluate(&service.MoodState{
+		Animal: models.Animal{Title: "cat"}, Now: noon, LastFedAt: at(noon, 9*time.Hour)})
+	assert.Equal(t, models.Mood("playful"), mood)
+	assert.Equal(t, []models.MoodReason{
+		{Rule: "toys", Mood: "playful", Weight: 5, Reason: "new toy"},
+		{Rule: "feeding", Mood: service.MoodHungry, Weight: 3, Reason: "last fed 9h0m ago"},
+	}, reasons)
+}
+
+func TestGetAnimalMood(t *testing.T) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	id, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: born, Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	tokens, err := service.NewPageTokenCodec("secret")
+	require.NoError(t, err)
+	// ночь отключена, чтобы результат не зависел от времени запуска
+	mood := newMoodEngine(st, &service.MoodConfig{HungryAfter: 8 * time.Hour, FedRecently: time.Hour, MedicalWindow: 7 * 24 * time.Hour})
+	s := service.NewAnimalService(animals, database.NewMemEnclosureRepository(st), database.NewMemLineageRepository(st), database.NewMemKeeperRepository(st), mood, tokens, service.NewValidator())
+
+	an, err := s.GetAnimal(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, service.MoodCalm, an.Mood)
+	assert.Empty(t, an.MoodReasons)
+
+	_, err = database.NewMemFeedingRepository(st).AddFeeding(ctx, &models.Feeding{IdAnim: id, Food: "fish", Quantity: 1, Unit: "kg", Keeper: "Ivan", FedAt: time.Now().Add(-12 * time.Hour)})
+	require.NoError(t, err)
+	_, err = database.NewMemMedicalRepository(st).AddRecord(ctx, &models.MedicalRecord{IdAnim: id, Kind: "diagnosis", Title: "otitis", Vet: "Petrov", RecordedAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+
+	an, err = s.GetAnimal(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, service.MoodHungry, an.Mood)
+	require.Len(t, an.MoodReasons, 2)
+	assert.Equal(t, "feeding", an.MoodReasons[0].Rule)
+	assert.Equal(t, models.MoodReason{Rule: "medical", Mood: service.MoodSick, Weight: 2, Reason: "diagnosed otitis 1h0m ago"}, an.MoodReasons[1])
+}
+
+func TestMoodHistory(t *testing.T) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	for _, title := range []string{"cat", "dog"} {
+		_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: title, Descrip: title})
+		require.NoError(t, err)
+	}
+	animals := database.NewMemAnimalRepository(st)
+	ids := make(map[string]int64)
+	for _, an := range []struct{ name, title string }{{"Klepa", "cat"}, {"Tom", "cat"}, {"Rex", "dog"}} {
+		id, err := animals.Add(ctx, &models.Animal{NameAn: an.name, BirthDate: born, Gender: "m", Title: an.title})
+		require.NoError(t, err)
+		ids[an.name] = id
+	}
+	moods := database.NewMemMoodRepository(st)
+	s := service.NewMoodHistoryService(moods, animals, service.NewValidator())
+
+	_, err := s.Record(ctx, &models.MoodEntry{IdAnim: ids["Klepa"], Mood: "playful"})
+	assert.ErrorIs(t, err, errs.ErrValidation, "keeper is required")
+	for _, m := range []struct {
+		name string
+		mood models.Mood
+	}{{"Klepa", "playful"}, {"Tom", "playful"}, {"Tom", service.MoodHungry}, {"Rex", "playful"}} {
+		rec, err := s.Record(ctx, &models.MoodEntry{IdAnim: ids[m.name], Mood: m.mood, Keeper: "Ivan"})
+		require.NoError(t, err)
+		assert.Equal(t, models.MoodSourceKeeper, rec.Source)
+		assert.False(t, rec.ObservedAt.IsZero())
+	}
+
+	// вычисленное настроение без изменений не пишется чаще RecordEvery
+	e := newMoodEngine(st, &service.MoodConfig{RecordEvery: time.Hour})
+	klepa, err := animals.Get(ctx, ids["Klepa"])
+	require.NoError(t, err)
+	for range 3 {
+		mood, _, err := e.Mood(ctx, klepa)
+		require.NoError(t, err)
+		assert.Equal(t, service.MoodCalm, mood)
+	}
+	computed, err := s.History(ctx, models.MoodFilter{IdAnim: ids["Klepa"], Source: models.MoodSourceComputed})
+	require.NoError(t, err)
+	assert.Len(t, computed, 1)
+	history, err := s.History(ctx, models.MoodFilter{IdAnim: ids["Klepa"]})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, models.MoodSourceComputed, history[0].Source, "newest first")
+
+	_, err = s.History(ctx, models.MoodFilter{IdAnim: ids["Klepa"], Source: "vet"})
+	assert.ErrorIs(t, err, service.ErrInvalidMoodSource)
+
+	dist, err := s.Distribution(ctx, models.MoodDistributionFilter{GroupBy: models.MoodBySpecies, Source: models.MoodSourceKeeper})
+	require.NoError(t, err)
+	assert.Equal(t, []models.MoodDistribution{
+		{Group: "cat", Total: 3, Moods: []models.MoodShare{{Mood: "playful", Count: 2, Share: 2.0 / 3}, {Mood: service.MoodHungry, Count: 1, Share: 1.0 / 3}}},
+		{Group: "dog", Total: 1, Moods: []models.MoodShare{{Mood: "playful", Count: 1, Share: 1}}},
+	}, dist)
+
+	dist, err = s.Distribution(ctx, models.MoodDistributionFilter{GroupBy: models.MoodByEnclosure})
+	require.NoError(t, err)
+	assert.Empty(t, dist, "nobody is housed")
+	_, err = s.Distribution(ctx, models.MoodDistributionFilter{GroupBy: "zone"})
+	assert.ErrorIs(t, err, service.ErrInvalidMoodGrouping)
+}
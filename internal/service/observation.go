@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	mod "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/tracing"
+)
+
+// DefaultSeverity - у наблюдения без severity
+const DefaultSeverity = "info"
+
+type ObservationService struct {
+	r       database.ObservationRepository
+	animals database.AnimalRepository
+	v       *Validator
+	now     func() time.Time
+}
+
+func NewObservationService(r database.ObservationRepository, animals database.AnimalRepository, v *Validator) *ObservationService {
+	return &ObservationService{r: r, animals: animals, v: v, now: time.Now}
+}
+
+// normalizeTag - метки без учета регистра и пробелов по краям
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// Record сохраняет наблюдение смотрителя; без ObservedAt - сейчас.
+// Метки приводятся к нижнему регистру, повторы убираются
+func (s *ObservationService) Record(ctx context.Context, o *mod.Observation) (_ *mod.Observation, err error) {
+	ctx, span := tracing.Start(ctx, "ObservationService.Record")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermBehaviourWrite); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if o.ObservedAt.IsZero() {
+		o.ObservedAt = now
+	}
+	if o.Severity == "" {
+		o.Severity = DefaultSeverity
+	}
+	tags := make([]string, 0, len(o.Tags))
+	for _, tag := range o.Tags {
+		tags = append(tags, normalizeTag(tag))
+	}
+	slices.Sort(tags)
+	o.Tags = slices.Compact(tags)
+	if err := s.v.Observation(o, now); err != nil {
+		return nil, err
+	}
+	id, err := s.r.Add(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	res := *o
+	res.IdObs = id
+	return &res, nil
+}
+
+// Search - наблюдения по животному, смотрителю, метке и периоду
+func (s *ObservationService) Search(ctx context.Context, f mod.ObservationFilter) ([]mod.Observation, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
+	if f.IdAnim != nil {
+		if _, err := s.animals.Get(ctx, *f.IdAnim); err != nil {
+			return nil, err
+		}
+	}
+	f.Tag = normalizeTag(f.Tag)
+	return s.r.List(ctx, f)
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservations(t *testing.T) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	klepa, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: born, Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	tom, err := animals.Add(ctx, &models.Animal{NameAn: "Tom", BirthDate: born, Gender: "m", Title: "cat"})
+	require.NoError(t, err)
+	s := service.NewObservationService(database.NewMemObservationRepository(st), animals, service.NewValidator())
+
+	now := time.Now().Truncate(time.Second)
+	rec, err := s.Record(ctx, &models.Observation{IdAnim: klepa, Keeper: "Ivan", ObservedAt: now.Add(-48 * time.Hour),
+		Stereotypic: true, Severity: "medium", Tags: []string{" Pacing", "pacing", "fence"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fence", "pacing"}, rec.Tags)
+	_, err = s.Record(ctx, &models.Observation{IdAnim: klepa, Keeper: "Olga", Appetite: "good"})
+	require.NoError(t, err)
+	last, err := s.Record(ctx, &models.Observation{IdAnim: tom, Keeper: "Ivan", Notes: "sleeps all day", Tags: []string{"lethargy"}})
+	require.NoError(t, err)
+	assert.Equal(t, service.DefaultSeverity, last.Severity)
+
+	for name, o := range map[string]models.Observation{
+		"nothing observed": {IdAnim: tom, Keeper: "Ivan"},
+		"unknown scale":    {IdAnim: tom, Keeper: "Ivan", Activity: "frantic"},
+		"no keeper":        {IdAnim: tom, Appetite: "poor"},
+		"future":           {IdAnim: tom, Keeper: "Ivan", Appetite: "poor", ObservedAt: now.Add(time.Hour)},
+	} {
+		_, err := s.Record(ctx, &o)
+		assert.ErrorIs(t, err, errs.ErrValidation, name)
+	}
+	_, err = s.Record(ctx, &models.Observation{IdAnim: tom, Keeper: "Ivan", Appetite: "poor", Tags: []string{"fence", strings.Repeat("x", service.MaxNameLength+1)}})
+	require.ErrorIs(t, err, errs.ErrValidation)
+	require.Len(t, errs.Fields(err), 1)
+	assert.Equal(t, "tags[1]", errs.Fields(err)[0].Field)
+	_, err = s.Record(ctx, &models.Observation{IdAnim: 404, Keeper: "Ivan", Appetite: "poor"})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	names := func(f models.ObservationFilter) []string {
+		obs, err := s.Search(ctx, f)
+		require.NoError(t, err)
+		var res []string
+		for _, o := range obs {
+			res = append(res, o.Keeper+":"+o.Notes+o.Appetite)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"Ivan:sleeps all day"}, names(models.ObservationFilter{Tag: "Lethargy"}))
+	assert.Equal(t, []string{"Ivan:sleeps all day", "Ivan:"}, names(models.ObservationFilter{Keeper: "Ivan"}))
+	assert.Equal(t, []string{"Olga:good", "Ivan:"}, names(models.ObservationFilter{IdAnim: &klepa}))
+	assert.Equal(t, []string{"Ivan:"}, names(models.ObservationFilter{To: now.Add(-24 * time.Hour)}))
+
+	missing := int64(404)
+	_, err = s.Search(ctx, models.ObservationFilter{IdAnim: &missing})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestObservationFeedsMood(t *testing.T) {
+	ctx := adminCtx()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	animals := database.NewMemAnimalRepository(st)
+	id, err := animals.Add(ctx, &models.Animal{NameAn: "Klepa", BirthDate: born, Gender: "f", Title: "cat"})
+	require.NoError(t, err)
+	obs := service.NewObservationService(database.NewMemObservationRepository(st), animals, service.NewValidator())
+	e := newMoodEngine(st, &service.MoodConfig{ObservationWindow: 24 * time.Hour})
+	klepa, err := animals.Get(ctx, id)
+	require.NoError(t, err)
+
+	_, err = obs.Record(ctx, &models.Observation{IdAnim: id, Keeper: "Ivan", ObservedAt: time.Now().Add(-30 * time.Hour), Activity: "high"})
+	require.NoError(t, err)
+	mood, _, err := e.Mood(ctx, klepa)
+	require.NoError(t, err)
+	assert.Equal(t, service.MoodCalm, mood, "observation is too old")
+
+	_, err = obs.Record(ctx, &models.Observation{IdAnim: id, Keeper: "Ivan", Stereotypic: true, Appetite: "good", Severity: "high"})
+	require.NoError(t, err)
+	mood, reasons, err := e.Mood(ctx, klepa)
+	require.NoError(t, err)
+	assert.Equal(t, service.MoodStressed, mood)
+	require.Len(t, reasons, 2)
+	assert.Equal(t, models.MoodReason{Rule: "observation", Mood: service.MoodContent, Weight: 4, Reason: "good appetite, noted by Ivan just now"}, reasons[0])
+	assert.Equal(t, service.MoodStressed, reasons[1].Mood)
+}
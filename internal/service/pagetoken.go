@@ -0,0 +1,103 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidPageToken error = errs.BadRequest("invalid page token")
+
+type (
+	// PageCursor - позиция, с которой продолжается выдача следующей страницы.
+	// Query - отпечаток фильтра и сортировки, с которыми токен был выдан
+	PageCursor struct {
+		AfterID   int64  `json:"a"`
+		Name      string `json:"n,omitempty"`
+		BirthDate string `json:"b,omitempty"`
+		Query     string `json:"q,omitempty"`
+	}
+
+	// PageTokenCodec превращает курсор в непрозрачный подписанный токен и обратно,
+	// чтобы клиент не мог подделать или подобрать позицию в таблице
+	PageTokenCodec struct {
+		secret []byte
+	}
+)
+
+// NewPageTokenCodec при пустом секрете генерирует случайный ключ,
+// тогда токены живут только до перезапуска процесса
+func NewPageTokenCodec(secret string) (*PageTokenCodec, error) {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	return &PageTokenCodec{secret: key}, nil
+}
+
+func (c *PageTokenCodec) Encode(cur PageCursor) (string, error) {
+	payload, err := json.Marshal(cur)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+func (c *PageTokenCodec) Decode(token string) (PageCursor, error) {
+	var cur PageCursor
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return cur, ErrInvalidPageToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return cur, ErrInvalidPageToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, c.sign(payload)) {
+		return cur, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(payload, &cur); err != nil || cur.AfterID < 0 {
+		return PageCursor{}, ErrInvalidPageToken
+	}
+	return cur, nil
+}
+
+func (c *PageTokenCodec) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, c.secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// queryFingerprint не дает продолжить выдачу токеном, полученным для другого запроса:
+// позиция в одной сортировке ничего не значит для другой
+func queryFingerprint(f mod.AnimalFilter, o mod.AnimalOrder) string {
+	payload, _ := json.Marshal(struct {
+		F mod.AnimalFilter
+		O mod.AnimalOrder
+	}{f, o})
+	sum := sha256.Sum256(payload)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	return min(limit, MaxPageSize)
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
-	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
 	mod "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/tracing"
 )
 
 type (
@@ -16,76 +20,276 @@ type (
 	}
 
 	AnimalService struct {
-		r    database.AnimalRepository
-		mood MoodService
+		r          database.AnimalRepository
+		enclosures database.EnclosureRepository
+		lineage    database.LineageRepository
+		keepers    database.KeeperRepository
+		mood       MoodService
+		tokens     *PageTokenCodec
+		v          *Validator
+		now        func() time.Time
 	}
 )
 
-func NewAnimalService(r database.AnimalRepository, ms MoodService) *AnimalService {
-	return &AnimalService{r: r, mood: ms}
+func NewAnimalService(r database.AnimalRepository, enclosures database.EnclosureRepository, lineage database.LineageRepository, keepers database.KeeperRepository, ms MoodService, tokens *PageTokenCodec, v *Validator) *AnimalService {
+	return &AnimalService{r: r, enclosures: enclosures, lineage: lineage, keepers: keepers, mood: ms, tokens: tokens, v: v, now: time.Now}
 }
 
-func (s *AnimalService) AddAnimal(ctx context.Context, individual *mod.Animal) error {
-	_, err := s.r.Add(ctx, individual)
+// AddAnimal сохраняет животное и возвращает его в том виде, в каком оно лежит в хранилище
+func (s *AnimalService) AddAnimal(ctx context.Context, individual *mod.Animal) (_ *mod.Animal, err error) {
+	ctx, span := tracing.Start(ctx, "AnimalService.AddAnimal")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermAnimalsWrite); err != nil {
+		return nil, err
+	}
+
+	normalizeBirth(individual)
+	if err := s.v.Animal(individual, s.now()); err != nil {
+		return nil, err
+	}
+	id, err := s.r.Add(ctx, individual)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return s.r.Get(ctx, id)
 }
 
-func (s *AnimalService) DeleteAnimal(ctx context.Context, idAnim int64) error {
+func (s *AnimalService) DeleteAnimal(ctx context.Context, idAnim int64) (err error) {
+	ctx, span := tracing.Start(ctx, "AnimalService.DeleteAnimal")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermAnimalsWrite); err != nil {
+		return err
+	}
 	return s.r.Delete(ctx, idAnim)
 }
 
-func (s *AnimalService) GetAnimal(ctx context.Context, idAnim int64) (*mod.AnimalFull, error) {
+func (s *AnimalService) GetAnimal(ctx context.Context, idAnim int64) (_ *mod.AnimalFull, err error) {
+	ctx, span := tracing.Start(ctx, "AnimalService.GetAnimal")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+
 	animal, err := s.r.Get(ctx, idAnim)
 	if err != nil {
 		return nil, err
 	}
 
-	return &mod.AnimalFull{Animal: *animal, Mood: s.mood.GetMood()}, nil
+	mood, reasons, err := s.mood.Mood(ctx, animal)
+	if err != nil {
+		return nil, err
+	}
+	return &mod.AnimalFull{Animal: *animal, Mood: mood, MoodReasons: reasons}, nil
 }
 
-func (s *AnimalService) GetAllAnimal(ctx context.Context, offset, limit int) ([]mod.Animal, error) {
-	return s.r.GetAll(ctx, offset, limit)
+var ErrInvalidSort error = errs.BadRequest("invalid sort field")
+
+// ParseAnimalOrder разбирает порядок вида "name" или "-age" (минус - по убыванию)
+func ParseAnimalOrder(order string) (mod.AnimalOrder, error) {
+	var o mod.AnimalOrder
+	if rest, ok := strings.CutPrefix(order, "-"); ok {
+		o.Desc = true
+		order = rest
+	}
+	switch field := mod.AnimalSortField(order); field {
+	case "":
+		o.Field = mod.SortByID
+	case mod.SortByID, mod.SortByName, mod.SortByAge:
+		o.Field = field
+	default:
+		return o, ErrInvalidSort
+	}
+	return o, nil
 }
 
-func (s *AnimalService) Update(ctx context.Context, individ *mod.Animal) error {
-	return s.r.Update(ctx, individ)
+// GetAllAnimal отдает страницу животных по фильтру и порядку, начиная с позиции из pageToken.
+// Пустой pageToken - первая страница, пустой NextPageToken в ответе - последняя
+func (s *AnimalService) GetAllAnimal(ctx context.Context, f mod.AnimalFilter, o mod.AnimalOrder, pageToken string, limit int) (_ *mod.AnimalPage, err error) {
+	ctx, span := tracing.Start(ctx, "AnimalService.GetAllAnimal")
+	defer func() { tracing.End(span, err) }()
 
-}
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
 
-var (
-	moodAngry = []mod.Mood{"happy", "angry", "sad", "cheerful", "I love Sencha", "I need more *4 svad`bi*"}
-)
+	if o.Field == "" {
+		o.Field = mod.SortByID
+	}
+	limit = pageSize(limit)
+	fp := queryFingerprint(f, o)
+	// берем на одну запись больше, чтобы понять, есть ли следующая страница
+	q := database.AnimalQuery{Filter: resolveAge(f, s.now()), Order: o, Limit: limit + 1}
+	if pageToken != "" {
+		cur, err := s.tokens.Decode(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		if cur.Query != fp {
+			return nil, ErrInvalidPageToken
+		}
+		q.After = &database.AnimalCursor{ID: cur.AfterID, Name: cur.Name}
+		if o.Field == mod.SortByAge {
+			if q.After.BirthDate, err = time.Parse(DateLayout, cur.BirthDate); err != nil {
+				return nil, ErrInvalidPageToken
+			}
+		}
+	}
 
-type (
-	MoodService interface {
-		GetMood() mod.Mood
+	animals, err := s.r.GetAll(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	page := &mod.AnimalPage{Animals: animals}
+	if len(animals) > limit {
+		page.Animals = animals[:limit]
+		last := page.Animals[limit-1]
+		next := PageCursor{AfterID: last.IdAnim, Query: fp}
+		switch o.Field {
+		case mod.SortByName:
+			next.Name = last.NameAn
+		case mod.SortByAge:
+			next.BirthDate = last.BirthDate.Format(DateLayout)
+		}
+		if page.NextPageToken, err = s.tokens.Encode(next); err != nil {
+			return nil, err
+		}
 	}
+	return page, nil
+}
+
+// Update целиком заменяет животное individ.IdAnim
+func (s *AnimalService) Update(ctx context.Context, individ *mod.Animal) (_ *mod.Animal, err error) {
+	ctx, span := tracing.Start(ctx, "AnimalService.Update")
+	defer func() { tracing.End(span, err) }()
 
-	MoodServiceImpl struct {
+	if err := authorize(ctx, PermAnimalsWrite); err != nil {
+		return nil, err
 	}
-)
 
-func NewMoodService() *MoodServiceImpl {
-	return &MoodServiceImpl{}
+	normalizeBirth(individ)
+	if err := s.v.Animal(individ, s.now()); err != nil {
+		return nil, err
+	}
+	current, err := s.r.Get(ctx, individ.IdAnim)
+	if err != nil {
+		return nil, err
+	}
+	individ.IdEncl = current.IdEncl
+	if err := s.checkSpeciesChange(ctx, current, individ); err != nil {
+		return nil, err
+	}
+	if err := s.checkBirthChange(ctx, current, individ); err != nil {
+		return nil, err
+	}
+	if err := s.r.Update(ctx, individ); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, individ.IdAnim)
 }
 
-func (m *MoodServiceImpl) Init(ctx context.Context) error {
-	return nil
+// Patch меняет только заданные в patch поля
+func (s *AnimalService) Patch(ctx context.Context, idAnim int64, patch *mod.AnimalPatch) (_ *mod.Animal, err error) {
+	ctx, span := tracing.Start(ctx, "AnimalService.Patch")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermAnimalsWrite); err != nil {
+		return nil, err
+	}
+
+	animal, err := s.r.Get(ctx, idAnim)
+	if err != nil {
+		return nil, err
+	}
+	oldBirth := animal.BirthDate
+	if patch.NameAn != nil {
+		animal.NameAn = *patch.NameAn
+	}
+	if patch.BirthDate != nil {
+		animal.BirthDate = *patch.BirthDate
+	}
+	if patch.BirthPrecision != nil {
+		animal.BirthPrecision = *patch.BirthPrecision
+	}
+	if patch.Gender != nil {
+		animal.Gender = *patch.Gender
+	}
+	oldTitle := animal.Title
+	if patch.Title != nil {
+		animal.Title = *patch.Title
+	}
+	normalizeBirth(animal)
+	if err := s.v.Animal(animal, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.checkSpeciesChange(ctx, &mod.Animal{Title: oldTitle}, animal); err != nil {
+		return nil, err
+	}
+	if err := s.checkBirthChange(ctx, &mod.Animal{BirthDate: oldBirth}, animal); err != nil {
+		return nil, err
+	}
+	if err := s.r.Update(ctx, animal); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, idAnim)
 }
 
-func (m *MoodServiceImpl) Ping(ctx context.Context) error {
-	return nil
+// AssignEnclosure переселяет животное в вольер, idEncl == nil - выселяет из текущего
+func (s *AnimalService) AssignEnclosure(ctx context.Context, idAnim int64, idEncl *int64) (_ *mod.Animal, err error) {
+	ctx, span := tracing.Start(ctx, "AnimalService.AssignEnclosure")
+	defer func() { tracing.End(span, err) }()
+
+	if err := authorize(ctx, PermAnimalsWrite); err != nil {
+		return nil, err
+	}
+
+	animal, err := s.r.Get(ctx, idAnim)
+	if err != nil {
+		return nil, err
+	}
+	if idEncl != nil {
+		enc, err := s.enclosures.Get(ctx, *idEncl)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkHousing(animal, enc); err != nil {
+			return nil, err
+		}
+	}
+	// вместимость репозиторий проверит еще раз атомарно, на случай параллельного заселения
+	if err := s.r.SetEnclosure(ctx, idAnim, idEncl); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, idAnim)
 }
 
-func (m *MoodServiceImpl) Close() error {
+// checkSpeciesChange не дает сменить вид животному, если новый вид недопустим в его вольере
+// или с ним не умеет работать закрепленный за животным смотритель
+func (s *AnimalService) checkSpeciesChange(ctx context.Context, current, next *mod.Animal) error {
+	if current.Title == next.Title {
+		return nil
+	}
+	err := checkAssignedKeepers(ctx, s.keepers, mod.AssignmentFilter{IdAnim: &next.IdAnim}, []string{next.Title}, "animal "+next.NameAn)
+	if err != nil || next.IdEncl == nil {
+		return err
+	}
+	enc, err := s.enclosures.Get(ctx, *next.IdEncl)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(enc.Species, next.Title) {
+		return errs.Conflict("species %s is not allowed in enclosure %s", next.Title, enc.Name)
+	}
 	return nil
 }
 
-func (m *MoodServiceImpl) GetMood() mod.Mood {
-	rand := rand.IntN(5)
-	return moodAngry[rand]
-
+// checkBirthChange не дает сдвинуть дату рождения так, что животное окажется
+// не младше родителя или не старше ребенка - иначе в родословной возможен цикл
+func (s *AnimalService) checkBirthChange(ctx context.Context, current, next *mod.Animal) error {
+	if current.BirthDate.Equal(next.BirthDate) {
+		return nil
+	}
+	return checkBirthOrder(ctx, s.lineage, next)
 }
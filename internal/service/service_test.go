@@ -1 +1,188 @@
 package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// adminCtx - контекст с правами на все операции
+func adminCtx() context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{Subject: "test", Role: models.RoleAdmin})
+}
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	codec, err := service.NewPageTokenCodec("secret")
+	require.NoError(t, err)
+
+	token, err := codec.Encode(service.PageCursor{AfterID: 42})
+	require.NoError(t, err)
+
+	cur, err := codec.Decode(token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), cur.AfterID)
+}
+
+func TestPageTokenRejectsForeignSignature(t *testing.T) {
+	codec, _ := service.NewPageTokenCodec("secret")
+	other, _ := service.NewPageTokenCodec("other secret")
+
+	token, err := other.Encode(service.PageCursor{AfterID: 42})
+	require.NoError(t, err)
+
+	_, err = codec.Decode(token)
+	assert.ErrorIs(t, err, service.ErrInvalidPageToken)
+}
+
+func TestPageTokenRejectsGarbage(t *testing.T) {
+	codec, _ := service.NewPageTokenCodec("secret")
+	for _, token := range []string{"42", "abc.def", "."} {
+		_, err := codec.Decode(token)
+		assert.ErrorIs(t, err, service.ErrInvalidPageToken, token)
+	}
+}
+
+func TestParseAnimalOrder(t *testing.T) {
+	o, err := service.ParseAnimalOrder("-age")
+	require.NoError(t, err)
+	assert.Equal(t, models.AnimalOrder{Field: models.SortByAge, Desc: true}, o)
+
+	o, err = service.ParseAnimalOrder("")
+	require.NoError(t, err)
+	assert.Equal(t, models.AnimalOrder{Field: models.SortByID}, o)
+
+	_, err = service.ParseAnimalOrder("weight")
+	assert.ErrorIs(t, err, service.ErrInvalidSort)
+}
+
+var born = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func newAnimalService(t *testing.T, names ...string) *service.AnimalService {
+	st := database.NewMemStorage()
+	ctx := adminCtx()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	r := database.NewMemAnimalRepository(st)
+	// i-е животное - i лет и полгода
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for i, name := range names {
+		_, err := r.Add(ctx, &models.Animal{NameAn: name, BirthDate: today.AddDate(-i, -6, 0), BirthPrecision: "exact", Gender: "f", Title: "cat"})
+		require.NoError(t, err)
+	}
+	tokens, err := service.NewPageTokenCodec("secret")
+	require.NoError(t, err)
+	return service.NewAnimalService(r, database.NewMemEnclosureRepository(st), database.NewMemLineageRepository(st), database.NewMemKeeperRepository(st), newMoodEngine(st, &service.MoodConfig{}), tokens, service.NewValidator())
+}
+
+func TestGetAllAnimalPages(t *testing.T) {
+	s := newAnimalService(t, "a", "b", "c", "d", "e")
+	ctx := adminCtx()
+	order := models.AnimalOrder{Field: models.SortByAge, Desc: true}
+
+	var names []string
+	var token string
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		page, err := s.GetAllAnimal(ctx, models.AnimalFilter{}, order, token, 2)
+		require.NoError(t, err)
+		for _, an := range page.Animals {
+			names = append(names, an.NameAn)
+		}
+		if token = page.NextPageToken; token == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"e", "d", "c", "b", "a"}, names)
+}
+
+func TestGetAllAnimalByAge(t *testing.T) {
+	s := newAnimalService(t, "a", "b", "c", "d", "e")
+	ctx := adminCtx()
+	minAge, maxAge := 1, 3
+	names := func(f models.AnimalFilter) []string {
+		page, err := s.GetAllAnimal(ctx, f, models.AnimalOrder{Field: models.SortByAge}, "", 10)
+		require.NoError(t, err)
+		var res []string
+		for _, an := range page.Animals {
+			res = append(res, an.NameAn)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"b", "c", "d"}, names(models.AnimalFilter{MinAge: &minAge, MaxAge: &maxAge}))
+
+	// граница из возраста не расширяет заданную явно
+	bornAfter := time.Now().AddDate(-3, 0, 0)
+	assert.Equal(t, []string{"b", "c"}, names(models.AnimalFilter{MinAge: &minAge, MaxAge: &maxAge, BornAfter: &bornAfter}))
+}
+
+func TestAgeAt(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		birth time.Time
+		want  models.Age
+	}{
+		{time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), models.Age{}},
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), models.Age{Years: 1, Months: 11}},
+		{time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), models.Age{Years: 2}},
+		{time.Date(2011, 12, 1, 0, 0, 0, 0, time.UTC), models.Age{Years: 14, Months: 3}},
+	} {
+		assert.Equal(t, tc.want, service.AgeAt(tc.birth, now), tc.birth)
+	}
+}
+
+func TestGetAllAnimalRejectsTokenOfOtherQuery(t *testing.T) {
+	s := newAnimalService(t, "a", "b", "c")
+	ctx := adminCtx()
+
+	page, err := s.GetAllAnimal(ctx, models.AnimalFilter{}, models.AnimalOrder{}, "", 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextPageToken)
+
+	_, err = s.GetAllAnimal(ctx, models.AnimalFilter{NamePrefix: "b"}, models.AnimalOrder{}, page.NextPageToken, 1)
+	assert.ErrorIs(t, err, service.ErrInvalidPageToken)
+}
+
+func TestValidatorSpecie(t *testing.T) {
+	v := service.NewValidator()
+	assert.NoError(t, v.Specie(&models.Specie{Title: "cat", Descrip: "meow"}))
+
+	err := v.Specie(&models.Specie{Title: strings.Repeat("я", service.MaxNameLength+1)})
+	assert.ErrorIs(t, err, errs.ErrValidation)
+	assert.Equal(t, []errs.FieldError{
+		{Field: "title", Rule: service.RuleMaxLength, Message: "title must be at most 40 characters"},
+		{Field: "description", Rule: service.RuleRequired, Message: "description must not be empty"},
+	}, errs.Fields(err))
+}
+
+func TestPatchValidatesMergedAnimal(t *testing.T) {
+	s := newAnimalService(t, "a")
+	future := time.Now().AddDate(0, 1, 0)
+	_, err := s.Patch(adminCtx(), 1, &models.AnimalPatch{BirthDate: &future})
+	assert.ErrorIs(t, err, errs.ErrValidation)
+	assert.Equal(t, []string{"birth_date:max"}, fieldRules(err))
+}
+
+func TestAddAnimalTruncatesBirthDate(t *testing.T) {
+	s := newAnimalService(t)
+	an, err := s.AddAnimal(adminCtx(), &models.Animal{
+		NameAn:         "Klepa",
+		BirthDate:      time.Date(2011, 3, 14, 0, 0, 0, 0, time.UTC),
+		BirthPrecision: "month",
+		Gender:         "f",
+		Title:          "cat",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2011, 3, 1, 0, 0, 0, 0, time.UTC), an.BirthDate)
+
+	_, err = s.AddAnimal(adminCtx(), &models.Animal{NameAn: "Tom", BirthPrecision: "roughly", Gender: "m", Title: "cat"})
+	assert.Equal(t, []string{"birth_date:required", "birth_precision:one_of"}, fieldRules(err))
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mi-raf/zooad/internal/database"
+	mod "github.com/mi-raf/zooad/internal/models"
+)
+
+type SpeciesService struct {
+	r database.SpeciesRepository
+	v *Validator
+}
+
+func NewSpeciesService(r database.SpeciesRepository, v *Validator) *SpeciesService {
+	return &SpeciesService{r: r, v: v}
+}
+
+func (s *SpeciesService) ListSpecies(ctx context.Context) ([]mod.Specie, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.List(ctx)
+}
+
+func (s *SpeciesService) GetSpecie(ctx context.Context, idSp int64) (*mod.Specie, error) {
+	if err := authorize(ctx, PermZooRead); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, idSp)
+}
+
+func (s *SpeciesService) AddSpecie(ctx context.Context, sp *mod.Specie) (int64, error) {
+	if err := authorize(ctx, PermSpeciesWrite); err != nil {
+		return -1, err
+	}
+
+	if err := s.v.Specie(sp); err != nil {
+		return -1, err
+	}
+	id, err := s.r.Add(ctx, sp)
+	if err != nil {
+		return -1, err
+	}
+	sp.IdSp = id
+	return id, nil
+}
+
+func (s *SpeciesService) UpdateSpecie(ctx context.Context, sp *mod.Specie) error {
+	if err := authorize(ctx, PermSpeciesWrite); err != nil {
+		return err
+	}
+
+	if err := s.v.Specie(sp); err != nil {
+		return err
+	}
+	return s.r.Update(ctx, sp)
+}
+
+func (s *SpeciesService) DeleteSpecie(ctx context.Context, idSp int64) error {
+	if err := authorize(ctx, PermSpeciesWrite); err != nil {
+		return err
+	}
+	return s.r.Delete(ctx, idSp)
+}
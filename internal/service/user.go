@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/mi-raf/zooad/internal/database"
+	mod "github.com/mi-raf/zooad/internal/models"
+)
+
+// UserService - справочник сотрудников и их ролей, доступен только admin
+type UserService struct {
+	r database.UserRepository
+	v *Validator
+}
+
+func NewUserService(r database.UserRepository, v *Validator) *UserService {
+	return &UserService{r: r, v: v}
+}
+
+func (s *UserService) ListUsers(ctx context.Context) ([]mod.User, error) {
+	if err := authorize(ctx, PermUsersManage); err != nil {
+		return nil, err
+	}
+	return s.r.List(ctx)
+}
+
+func (s *UserService) GetUser(ctx context.Context, idUser int64) (*mod.User, error) {
+	if err := authorize(ctx, PermUsersManage); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, idUser)
+}
+
+func (s *UserService) AddUser(ctx context.Context, u *mod.User) (*mod.User, error) {
+	if err := authorize(ctx, PermUsersManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.User(u); err != nil {
+		return nil, err
+	}
+	res := *u
+	if err := s.r.Add(ctx, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, u *mod.User) (*mod.User, error) {
+	if err := authorize(ctx, PermUsersManage); err != nil {
+		return nil, err
+	}
+
+	if err := s.v.User(u); err != nil {
+		return nil, err
+	}
+	if err := s.r.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return s.r.Get(ctx, u.IdUser)
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, idUser int64) error {
+	if err := authorize(ctx, PermUsersManage); err != nil {
+		return err
+	}
+	return s.r.Delete(ctx, idUser)
+}
+
+// RoleResolver дополняет личность ролью из справочника сотрудников.
+// Субъекты из admins - администраторы независимо от справочника, чтобы
+// было кому завести первых пользователей. Неизвестный субъект остается
+// без роли и получает 403 на любую операцию
+type RoleResolver struct {
+	next   auth.Authenticator
+	users  database.UserRepository
+	admins []string
+}
+
+func NewRoleResolver(next auth.Authenticator, users database.UserRepository, admins []string) *RoleResolver {
+	return &RoleResolver{next: next, users: users, admins: admins}
+}
+
+func (r *RoleResolver) Authenticate(ctx context.Context, c auth.Credentials) (*auth.Identity, error) {
+	id, err := r.next.Authenticate(ctx, c)
+	// роль анонимного доступа задана в конфиге
+	if err != nil || id.Role != "" {
+		return id, err
+	}
+	if slices.Contains(r.admins, id.Subject) {
+		id.Role = mod.RoleAdmin
+		return id, nil
+	}
+	u, err := r.users.GetByName(ctx, id.Subject)
+	if errors.Is(err, database.ErrUserNotFound) {
+		return id, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	id.Role = u.Role
+	return id, nil
+}
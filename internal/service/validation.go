@@ -0,0 +1,350 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mi-raf/zooad/internal/errs"
+	mod "github.com/mi-raf/zooad/internal/models"
+)
+
+// Ограничения совпадают со схемой: varchar(40), varchar(100) и varchar(400)
+const (
+	MaxNameLength        = 40
+	MaxTitleLength       = 100
+	MaxDescriptionLength = 400
+)
+
+var Genders = []string{"m", "f"}
+
+// Units - единицы количества корма
+var Units = []string{"g", "kg", "pcs", "ml", "l"}
+
+// RecordKinds - виды записей медицинской истории
+var RecordKinds = []string{"examination", "diagnosis", "treatment"}
+
+// DoseUnits - единицы дозы препарата
+var DoseUnits = []string{"mg", "g", "ml", "IU", "tablet", "drop"}
+
+// TimeOfDayLayout - формат времени кормления в плане
+const TimeOfDayLayout = "15:04"
+
+// DateLayout - формат даты рождения
+const DateLayout = "2006-01-02"
+
+// BirthPrecisions - насколько точно известна дата рождения
+var BirthPrecisions = []string{"exact", "month", "year", "estimated"}
+
+// Шкалы наблюдений за поведением; пустое значение - не оценивалось
+var (
+	ActivityLevels = []string{"low", "normal", "high"}
+	AppetiteLevels = []string{"none", "poor", "normal", "good"}
+	SocialLevels   = []string{"withdrawn", "normal", "affiliative", "aggressive"}
+	Severities     = []string{"info", "low", "medium", "high"}
+)
+
+// MaxTags - сколько меток можно повесить на одно наблюдение
+const MaxTags = 10
+
+// KeeperRoles - должности в справочнике смотрителей
+var KeeperRoles = []string{"keeper", "senior_keeper", "trainer", "vet", "curator"}
+
+var Habitats = []string{"savanna", "forest", "desert", "grassland", "mountain", "polar", "tropical", "aquatic", "aviary", "terrarium"}
+
+const (
+	RuleRequired  = "required"
+	RuleMaxLength = "max_length"
+	RuleMin       = "min"
+	RuleMax       = "max"
+	RuleOneOf     = "one_of"
+	RuleFormat    = "format"
+)
+
+type (
+	// Validator проверяет сущности до обращения к репозиторию
+	// и собирает все нарушения сразу, а не до первого
+	Validator struct{}
+
+	violations []errs.FieldError
+)
+
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+func (v *violations) add(field, rule, format string, args ...any) {
+	*v = append(*v, errs.FieldError{Field: field, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *violations) text(field, value string, maxLen int) {
+	switch {
+	case strings.TrimSpace(value) == "":
+		v.add(field, RuleRequired, "%s must not be empty", field)
+	case utf8.RuneCountInString(value) > maxLen:
+		v.add(field, RuleMaxLength, "%s must be at most %d characters", field, maxLen)
+	}
+}
+
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return errs.Invalid(v)
+}
+
+func (v *Validator) Animal(a *mod.Animal, now time.Time) error {
+	var vs violations
+	vs.text("name_animal", a.NameAn, MaxNameLength)
+	if a.BirthDate.IsZero() {
+		vs.add("birth_date", RuleRequired, "birth_date must be set")
+	} else {
+		vs.past("birth_date", a.BirthDate, now)
+	}
+	if !slices.Contains(BirthPrecisions, a.BirthPrecision) {
+		vs.add("birth_precision", RuleOneOf, "birth_precision must be one of %s", strings.Join(BirthPrecisions, ", "))
+	}
+	if !slices.Contains(Genders, a.Gender) {
+		vs.add("gender", RuleOneOf, "gender must be one of %s", strings.Join(Genders, ", "))
+	}
+	vs.text("title", a.Title, MaxNameLength)
+	return vs.err()
+}
+
+func (v *Validator) Specie(sp *mod.Specie) error {
+	var vs violations
+	vs.text("title", sp.Title, MaxNameLength)
+	vs.text("description", sp.Descrip, MaxDescriptionLength)
+	return vs.err()
+}
+
+func (v *Validator) Enclosure(enc *mod.Enclosure) error {
+	var vs violations
+	vs.text("name", enc.Name, MaxNameLength)
+	vs.text("zone", enc.Zone, MaxNameLength)
+	if enc.Capacity < 1 {
+		vs.add("capacity", RuleMin, "capacity must be at least 1")
+	}
+	if !slices.Contains(Habitats, enc.Habitat) {
+		vs.add("habitat", RuleOneOf, "habitat must be one of %s", strings.Join(Habitats, ", "))
+	}
+	if len(enc.Species) == 0 {
+		vs.add("species", RuleRequired, "species must list at least one allowed species")
+	}
+	for i, title := range enc.Species {
+		vs.text(fmt.Sprintf("species[%d]", i), title, MaxNameLength)
+	}
+	return vs.err()
+}
+
+func (v *Validator) FeedingPlan(plan *mod.FeedingPlan) error {
+	var vs violations
+	switch {
+	case plan.Species == "" && plan.IdAnim == nil:
+		vs.add("species", RuleRequired, "either species or animal_id must be set")
+	case plan.Species != "" && plan.IdAnim != nil:
+		vs.add("animal_id", RuleOneOf, "only one of species and animal_id may be set")
+	case plan.Species != "":
+		vs.text("species", plan.Species, MaxNameLength)
+	}
+	vs.food(plan.Food, plan.Quantity, plan.Unit)
+	if len(plan.Times) == 0 {
+		vs.add("times", RuleRequired, "times must list at least one time of day")
+	}
+	for i, t := range plan.Times {
+		if _, err := time.Parse(TimeOfDayLayout, t); err != nil {
+			vs.add(fmt.Sprintf("times[%d]", i), RuleFormat, "time of day must be HH:MM")
+		}
+	}
+	return vs.err()
+}
+
+// Feeding проверяет запись о кормлении; кормление из будущего не принимается
+func (v *Validator) Feeding(feed *mod.Feeding, now time.Time) error {
+	var vs violations
+	if feed.IdAnim <= 0 {
+		vs.add("animal_id", RuleRequired, "animal_id must be a positive id")
+	}
+	vs.food(feed.Food, feed.Quantity, feed.Unit)
+	vs.text("keeper", feed.Keeper, MaxNameLength)
+	vs.past("fed_at", feed.FedAt, now)
+	vs.optionalText("notes", feed.Notes, MaxDescriptionLength)
+	return vs.err()
+}
+
+func (v *violations) food(food string, quantity float64, unit string) {
+	v.text("food", food, MaxNameLength)
+	if quantity <= 0 {
+		v.add("quantity", RuleMin, "quantity must be positive")
+	}
+	if !slices.Contains(Units, unit) {
+		v.add("unit", RuleOneOf, "unit must be one of %s", strings.Join(Units, ", "))
+	}
+}
+
+func (v *Validator) MedicalRecord(rec *mod.MedicalRecord, now time.Time) error {
+	var vs violations
+	if !slices.Contains(RecordKinds, rec.Kind) {
+		vs.add("kind", RuleOneOf, "kind must be one of %s", strings.Join(RecordKinds, ", "))
+	}
+	vs.text("title", rec.Title, MaxTitleLength)
+	vs.optionalText("details", rec.Details, MaxDescriptionLength)
+	vs.text("vet", rec.Vet, MaxNameLength)
+	vs.past("recorded_at", rec.RecordedAt, now)
+	return vs.err()
+}
+
+// Medication допускает курс, назначенный на будущее
+func (v *Validator) Medication(med *mod.Medication) error {
+	var vs violations
+	vs.text("drug", med.Drug, MaxNameLength)
+	if med.Dose <= 0 {
+		vs.add("dose", RuleMin, "dose must be positive")
+	}
+	if !slices.Contains(DoseUnits, med.DoseUnit) {
+		vs.add("dose_unit", RuleOneOf, "dose_unit must be one of %s", strings.Join(DoseUnits, ", "))
+	}
+	vs.optionalText("frequency", med.Frequency, MaxNameLength)
+	if med.Days < 1 {
+		vs.add("days", RuleMin, "days must be at least 1")
+	}
+	vs.text("vet", med.Vet, MaxNameLength)
+	return vs.err()
+}
+
+func (v *Validator) Vaccination(vac *mod.Vaccination, now time.Time) error {
+	var vs violations
+	vs.text("vaccine", vac.Vaccine, MaxNameLength)
+	vs.past("given_at", vac.GivenAt, now)
+	if vac.DueAt != nil && !vac.DueAt.After(vac.GivenAt) {
+		vs.add("due_at", RuleMin, "due_at must be after given_at")
+	}
+	vs.text("vet", vac.Vet, MaxNameLength)
+	return vs.err()
+}
+
+func (v *violations) optionalText(field, value string, maxLen int) {
+	if utf8.RuneCountInString(value) > maxLen {
+		v.add(field, RuleMaxLength, "%s must be at most %d characters", field, maxLen)
+	}
+}
+
+// past - событие уже произошло
+func (v *violations) past(field string, t, now time.Time) {
+	if t.After(now) {
+		v.add(field, RuleMax, "%s must not be in the future", field)
+	}
+}
+
+// Measurement - хотя бы одно значение, упитанность по шкале 1-9
+func (v *Validator) Measurement(m *mod.Measurement, now time.Time) error {
+	var vs violations
+	if m.WeightKg == nil && m.LengthCm == nil && m.BodyCondition == nil {
+		vs.add("weight_kg", RuleRequired, "at least one of weight_kg, length_cm and body_condition must be set")
+	}
+	if m.WeightKg != nil && *m.WeightKg <= 0 {
+		vs.add("weight_kg", RuleMin, "weight_kg must be positive")
+	}
+	if m.LengthCm != nil && *m.LengthCm <= 0 {
+		vs.add("length_cm", RuleMin, "length_cm must be positive")
+	}
+	if m.BodyCondition != nil && (*m.BodyCondition < 1 || *m.BodyCondition > 9) {
+		vs.add("body_condition", RuleOneOf, "body_condition must be from 1 to 9")
+	}
+	vs.past("measured_at", m.MeasuredAt, now)
+	vs.optionalText("notes", m.Notes, MaxDescriptionLength)
+	return vs.err()
+}
+
+// MoodEntry - отметка смотрителя; настроение - любое слово, не только из правил
+func (v *Validator) MoodEntry(m *mod.MoodEntry, now time.Time) error {
+	var vs violations
+	vs.text("mood", string(m.Mood), MaxNameLength)
+	vs.text("keeper", m.Keeper, MaxNameLength)
+	vs.optionalText("notes", m.Notes, MaxDescriptionLength)
+	vs.past("observed_at", m.ObservedAt, now)
+	return vs.err()
+}
+
+// Observation - хоть что-то должно быть оценено или описано
+func (v *Validator) Observation(o *mod.Observation, now time.Time) error {
+	var vs violations
+	vs.text("keeper", o.Keeper, MaxNameLength)
+	vs.past("observed_at", o.ObservedAt, now)
+	for _, scale := range []struct {
+		field, value string
+		levels       []string
+	}{{"activity", o.Activity, ActivityLevels}, {"appetite", o.Appetite, AppetiteLevels}, {"social", o.Social, SocialLevels}} {
+		if scale.value != "" && !slices.Contains(scale.levels, scale.value) {
+			vs.add(scale.field, RuleOneOf, "%s must be one of %s", scale.field, strings.Join(scale.levels, ", "))
+		}
+	}
+	if o.Activity == "" && o.Appetite == "" && o.Social == "" && !o.Stereotypic && strings.TrimSpace(o.Notes) == "" {
+		vs.add("notes", RuleRequired, "at least one of activity, appetite, social, stereotypic and notes must be set")
+	}
+	if !slices.Contains(Severities, o.Severity) {
+		vs.add("severity", RuleOneOf, "severity must be one of %s", strings.Join(Severities, ", "))
+	}
+	vs.optionalText("notes", o.Notes, MaxDescriptionLength)
+	if len(o.Tags) > MaxTags {
+		vs.add("tags", RuleMax, "at most %d tags are allowed", MaxTags)
+	}
+	for i, tag := range o.Tags {
+		vs.text(fmt.Sprintf("tags[%d]", i), tag, MaxNameLength)
+	}
+	return vs.err()
+}
+
+func (v *Validator) User(u *mod.User) error {
+	var vs violations
+	vs.text("name", u.Name, MaxNameLength)
+	if !slices.Contains(Roles, u.Role) {
+		names := make([]string, 0, len(Roles))
+		for _, r := range Roles {
+			names = append(names, string(r))
+		}
+		vs.add("role", RuleOneOf, "role must be one of %s", strings.Join(names, ", "))
+	}
+	return vs.err()
+}
+
+func (v *Validator) Keeper(k *mod.Keeper) error {
+	var vs violations
+	vs.text("name", k.Name, MaxNameLength)
+	if !slices.Contains(KeeperRoles, k.Role) {
+		vs.add("role", RuleOneOf, "role must be one of %s", strings.Join(KeeperRoles, ", "))
+	}
+	vs.optionalText("contact", k.Contact, MaxTitleLength)
+	for i, sp := range k.Species {
+		vs.text(fmt.Sprintf("species[%d]", i), sp, MaxNameLength)
+	}
+	return vs.err()
+}
+
+func (v *Validator) KeeperAssignment(a *mod.KeeperAssignment) error {
+	var vs violations
+	switch {
+	case a.IdAnim == nil && a.IdEncl == nil:
+		vs.add("animal_id", RuleRequired, "either animal_id or enclosure_id must be set")
+	case a.IdAnim != nil && a.IdEncl != nil:
+		vs.add("enclosure_id", RuleOneOf, "only one of animal_id and enclosure_id may be set")
+	}
+	return vs.err()
+}
+
+// Parents - родитель либо животное зоопарка, либо внешний, но не оба сразу
+func (v *Validator) Parents(mother, father mod.Parent) error {
+	var vs violations
+	for _, p := range []struct {
+		field  string
+		parent mod.Parent
+	}{{"mother", mother}, {"father", father}} {
+		if p.parent.IdAnim != nil && p.parent.External != "" {
+			vs.add(p.field, RuleOneOf, "%s must be either an animal id or an external label", p.field)
+		}
+		vs.optionalText(p.field, p.parent.External, MaxTitleLength)
+	}
+	return vs.err()
+}
@@ -2,7 +2,8 @@ package internal
 
 import (
 	"context"
-	//"sync"
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -50,22 +51,106 @@ type ServiceKeeper struct {
 	PingTimeout     time.Duration
 	ShutdownTimeout time.Duration
 	stop            chan struct{}
+	// OnCheck вызывается после каждого Init и Ping ресурса, например для метрик
+	OnCheck func(ServiceStatus)
+
+	// результат последней проверки, индексы совпадают с Services
+	statusMux sync.RWMutex
+	status    []ServiceStatus
+}
+
+const (
+	StatusUnknown = "unknown"
+	StatusOK      = "ok"
+	StatusFailing = "failing"
+)
+
+// ServiceStatus - результат последнего Init или Ping ресурса
+type ServiceStatus struct {
+	Name      string
+	Status    string
+	Error     string
+	CheckedAt time.Time
+	Latency   time.Duration
+}
+
+// Named реализуют ресурсы, которые хотят называться в /readyz по-человечески
+type Named interface {
+	Name() string
+}
+
+func serviceName(srv service.Service) string {
+	if n, ok := srv.(Named); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", srv)
 }
 
 // не поняла зачем давать имя возращающей переменной
 func (s *ServiceKeeper) initAllServices(ctx context.Context) (initError error) {
 	initCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	s.statusMux.Lock()
+	s.status = make([]ServiceStatus, len(s.Services))
+	for i := range s.Services {
+		s.status[i] = ServiceStatus{Name: serviceName(s.Services[i]), Status: StatusUnknown}
+	}
+	s.statusMux.Unlock()
 	var p paralleRun
 	for i := range s.Services {
-		p.do(initCtx, s.Services[i].Init)
-		// if err := s.Services[i].Init(ctx); err != nil {
-		// 	return err
-		// }
+		p.do(initCtx, s.check(i, s.Services[i].Init))
 	}
 	return p.wait()
 }
 
+// check оборачивает Init или Ping ресурса i и запоминает результат
+func (s *ServiceKeeper) check(i int, f func(context.Context) error) func(context.Context) error {
+	return func(ctx context.Context) error {
+		start := time.Now()
+		err := f(ctx)
+		st := ServiceStatus{
+			Name:      serviceName(s.Services[i]),
+			Status:    StatusOK,
+			CheckedAt: start,
+			Latency:   time.Since(start),
+		}
+		if err != nil {
+			st.Status = StatusFailing
+			st.Error = err.Error()
+		}
+		s.statusMux.Lock()
+		s.status[i] = st
+		s.statusMux.Unlock()
+		if s.OnCheck != nil {
+			s.OnCheck(st)
+		}
+		return err
+	}
+}
+
+// Status отдает результат последней проверки каждого ресурса
+func (s *ServiceKeeper) Status() []ServiceStatus {
+	s.statusMux.RLock()
+	defer s.statusMux.RUnlock()
+	res := make([]ServiceStatus, len(s.status))
+	copy(res, s.status)
+	return res
+}
+
+// Ready - ресурсы наблюдаются и последняя проверка каждого прошла успешно.
+// Во время остановки приложение уже не готово принимать трафик
+func (s *ServiceKeeper) Ready() bool {
+	if atomic.LoadInt32(&s.state) != srvStateRunning {
+		return false
+	}
+	for _, st := range s.Status() {
+		if st.Status != StatusOK {
+			return false
+		}
+	}
+	return true
+}
+
 // если поменял значение
 func (s *ServiceKeeper) checkState(old, new int32) bool {
 	return atomic.CompareAndSwapInt32(&s.state, old, new)
@@ -114,7 +199,7 @@ func (s *ServiceKeeper) testServises(ctx context.Context) error {
 	defer cancel()
 	var p paralleRun
 	for i := range s.Services {
-		p.do(ctxPing, s.Services[i].Ping)
+		p.do(ctxPing, s.check(i, s.Services[i].Ping))
 	}
 	return p.wait()
 }
@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PgxTracer открывает спан на каждый запрос пула и пишет в него текст SQL
+type PgxTracer struct{}
+
+func NewPgxTracer() *PgxTracer {
+	return &PgxTracer{}
+}
+
+func (t *PgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = Tracer().Start(ctx, "postgres.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatement(data.SQL),
+		),
+	)
+	return ctx
+}
+
+func (t *PgxTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err == nil {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	End(span, data.Err)
+}
@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+
+	instrumentation = "github.com/mi-raf/zooad"
+)
+
+type Config struct {
+	ServiceName string
+	// none - спаны не выгружаются, но trace id все равно появляются в логах
+	Exporter string
+	// для file
+	File        string
+	SampleRatio float64
+}
+
+// Init ставит глобальные TracerProvider и пропагатор W3C tracecontext.
+// Адрес коллектора для otlp берется из стандартных OTEL_EXPORTER_OTLP_* переменных
+func Init(ctx context.Context, cfg *Config) (func(), error) {
+	var opts []sdktrace.TracerProviderOption
+	var closeOut = func() error { return nil }
+	switch cfg.Exporter {
+	case ExporterNone, "":
+	case ExporterOTLP:
+		exp, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	case ExporterStdout, ExporterFile:
+		var out io.Writer = os.Stdout
+		if cfg.Exporter == ExporterFile {
+			f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				return nil, err
+			}
+			out, closeOut = f, f.Close
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(out))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %s", cfg.Exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts,
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return func() {
+		// дописываем накопленные спаны, даже если контекст приложения уже отменен
+		if err := tp.Shutdown(context.Background()); err != nil {
+			otel.Handle(err)
+		}
+		if err := closeOut(); err != nil {
+			otel.Handle(err)
+		}
+	}, nil
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
+}
+
+// Start открывает внутренний спан, например для метода сервиса
+func Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name)
+}
+
+// End закрывает спан и помечает его ошибкой, если она есть
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Log добавляет в строку лога trace_id и span_id текущего спана,
+// trace_id служит идентификатором запроса
+func Log(ctx context.Context, ev *zerolog.Event) *zerolog.Event {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ev
+	}
+	return ev.Str("trace_id", sc.TraceID().String()).Str("span_id", sc.SpanID().String())
+}
@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authInterceptor стоит после errorInterceptor: отказ уходит клиенту как Unauthenticated
+func authInterceptor(a auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		c := metadataCarrier(md)
+		cred, err := auth.ParseCredentials(c.Get("authorization"), c.Get("x-api-key"))
+		if err != nil {
+			return nil, err
+		}
+		id, err := a.Authenticate(ctx, cred)
+		if err != nil {
+			return nil, err
+		}
+		trace.SpanFromContext(ctx).SetAttributes(semconv.EnduserID(id.Subject))
+		return handler(auth.WithIdentity(ctx, id), req)
+	}
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+	keys, err := auth.NewAPIKeys(map[string]string{"root": "root-key-0123456789", "feeder": "feeder-key-0123456789"})
+	require.NoError(t, err)
+	z := newTestZoo(t, keys)
+	z.addAnimal(t, "Klepa", "2011-03-14", "f")
+	c := NewAnimalServiceClient(z.conn)
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+	}
+
+	_, err = c.GetAnimal(context.Background(), &AnimalRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "credentials required", status.Convert(err).Message())
+
+	_, err = c.GetAnimal(withKey("wrong-key-0123456789"), &AnimalRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic dXNlcjpwYXNz")
+	_, err = c.GetAnimal(ctx, &AnimalRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// ключ верный, но у субъекта нет роли
+	_, err = c.GetAnimal(withKey("feeder-key-0123456789"), &AnimalRequest{Id: 1})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	res, err := c.GetAnimal(withKey("root-key-0123456789"), &AnimalRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Klepa", res.GetAnimalType().GetName())
+}
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/mi-raf/zooad/internal/errs"
+	"github.com/mi-raf/zooad/internal/tracing"
+	zl "github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var kindCode = map[errs.Kind]codes.Code{
+	errs.KindNotFound:        codes.NotFound,
+	errs.KindUnknownSpecies:  codes.InvalidArgument,
+	errs.KindValidation:      codes.InvalidArgument,
+	errs.KindConflict:        codes.FailedPrecondition,
+	errs.KindBadRequest:      codes.InvalidArgument,
+	errs.KindUnauthenticated: codes.Unauthenticated,
+	errs.KindForbidden:       codes.PermissionDenied,
+}
+
+// errorInterceptor переводит доменные ошибки в gRPC-статусы так же,
+// как errorHandler в api переводит их в HTTP-коды
+func errorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return res, err
+		}
+		code, ok := kindCode[errs.KindOf(err)]
+		if !ok {
+			tracing.Log(ctx, zl.Error()).Err(err).Str("method", info.FullMethod).Msg("grpc request failed")
+			code = codes.Internal
+		}
+		st := status.New(code, errs.Message(err))
+		if fields := errs.Fields(err); len(fields) > 0 {
+			br := &errdetails.BadRequest{}
+			for _, f := range fields {
+				br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field:       f.Field,
+					Description: f.Message,
+				})
+			}
+			if withDetails, err := st.WithDetails(br); err == nil {
+				st = withDetails
+			}
+		}
+		return nil, st.Err()
+	}
+}
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mi-raf/zooad/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorInterceptor(t *testing.T) {
+	intercept := errorInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/main.AnimalService/GetAnimal"}
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+		msg  string
+	}{
+		{errs.NotFound("animal not found"), codes.NotFound, "animal not found"},
+		{errs.UnknownSpecies("unicorn"), codes.InvalidArgument, ""},
+		{errs.Validation("bad age"), codes.InvalidArgument, "bad age"},
+		{errs.Conflict("species %s is in use", "cat"), codes.FailedPrecondition, "species cat is in use"},
+		{errs.BadRequest("id must be positive"), codes.InvalidArgument, "id must be positive"},
+		{errs.Unauthenticated("credentials required"), codes.Unauthenticated, "credentials required"},
+		{errs.Forbidden("not allowed"), codes.PermissionDenied, "not allowed"},
+		{status.Error(codes.Unavailable, "down"), codes.Unavailable, "down"},
+		{errors.New("connection reset"), codes.Internal, ""},
+	} {
+		_, err := intercept(context.Background(), nil, info, func(context.Context, any) (any, error) { return nil, tc.err })
+		st, ok := status.FromError(err)
+		require.True(t, ok, tc.err)
+		assert.Equal(t, tc.code, st.Code(), tc.err)
+		if tc.msg != "" {
+			assert.Equal(t, tc.msg, st.Message())
+		}
+	}
+
+	_, err := intercept(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, errs.Invalid([]errs.FieldError{{Field: "name", Rule: "required", Message: "name is required"}})
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	br, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, br.GetFieldViolations(), 1)
+	assert.Equal(t, "name", br.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "name is required", br.GetFieldViolations()[0].GetDescription())
+}
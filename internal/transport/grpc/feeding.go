@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/errs"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type feedingServer struct {
+	UnimplementedFeedingServiceServer
+
+	s *service.FeedingService
+}
+
+func (g *feedingServer) ListFeedingPlans(ctx context.Context, req *ListFeedingPlansRequest) (*FeedingPlansResponse, error) {
+	plans, err := g.s.ListPlans(ctx, models.FeedingPlanFilter{Species: req.GetSpeciesTitle(), IdAnim: req.AnimalId})
+	if err != nil {
+		return nil, err
+	}
+	res := &FeedingPlansResponse{Plans: make([]*FeedingPlan, 0, len(plans))}
+	for _, p := range plans {
+		res.Plans = append(res.Plans, &FeedingPlan{
+			Id:           p.IdPlan,
+			SpeciesTitle: p.Species,
+			AnimalId:     p.IdAnim,
+			Food:         p.Food,
+			Quantity:     p.Quantity,
+			Unit:         p.Unit,
+			Times:        p.Times,
+		})
+	}
+	return res, nil
+}
+
+func (g *feedingServer) RecordFeeding(ctx context.Context, req *RecordFeedingRequest) (*FeedingResponse, error) {
+	if req.GetAnimalId() <= 0 {
+		return nil, errs.BadRequest("animal_id must be positive")
+	}
+	feed := &models.Feeding{
+		IdAnim:   req.GetAnimalId(),
+		IdPlan:   req.PlanId,
+		Food:     req.GetFood(),
+		Quantity: req.GetQuantity(),
+		Unit:     req.GetUnit(),
+		Keeper:   req.GetKeeper(),
+		Notes:    req.GetNotes(),
+	}
+	if req.FedAt != nil {
+		feed.FedAt = req.GetFedAt().AsTime()
+	}
+	feed, err := g.s.RecordFeeding(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedingResponse{
+		Id:       feed.IdFeed,
+		AnimalId: feed.IdAnim,
+		PlanId:   feed.IdPlan,
+		Food:     feed.Food,
+		Quantity: feed.Quantity,
+		Unit:     feed.Unit,
+		Keeper:   feed.Keeper,
+		FedAt:    timestamppb.New(feed.FedAt),
+		Notes:    feed.Notes,
+	}, nil
+}
+
+func (g *feedingServer) ListOverdueFeedings(ctx context.Context, req *ListOverdueFeedingsRequest) (*OverdueFeedingsResponse, error) {
+	overdue, err := g.s.Overdue(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	res := &OverdueFeedingsResponse{Feedings: make([]*OverdueFeeding, 0, len(overdue))}
+	for _, o := range overdue {
+		f := &OverdueFeeding{
+			AnimalId:     o.IdAnim,
+			Name:         o.NameAn,
+			SpeciesTitle: o.Title,
+			PlanId:       o.IdPlan,
+			Food:         o.Food,
+			DueAt:        timestamppb.New(o.DueAt),
+		}
+		if o.LastFedAt != nil {
+			f.LastFedAt = timestamppb.New(*o.LastFedAt)
+		}
+		res.Feedings = append(res.Feedings, f)
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFeedingService(t *testing.T) {
+	z := newTestZoo(t, auth.Anonymous{Role: models.RoleAdmin})
+	klepa := z.addAnimal(t, "Klepa", "2023-05-01", "f")
+	_, err := z.feedings.AddPlan(auth.WithIdentity(context.Background(), auth.System),
+		&models.FeedingPlan{Species: "cat", Food: "fish", Quantity: 200, Unit: "g", Times: []string{"00:00"}})
+	require.NoError(t, err)
+	c := NewFeedingServiceClient(z.conn)
+	ctx := context.Background()
+
+	plans, err := c.ListFeedingPlans(ctx, &ListFeedingPlansRequest{SpeciesTitle: "cat"})
+	require.NoError(t, err)
+	require.Len(t, plans.GetPlans(), 1)
+	plan := plans.GetPlans()[0]
+	assert.Equal(t, "fish", plan.GetFood())
+	assert.Equal(t, []string{"00:00"}, plan.GetTimes())
+	assert.Nil(t, plan.AnimalId)
+
+	plans, err = c.ListFeedingPlans(ctx, &ListFeedingPlansRequest{SpeciesTitle: "dog"})
+	require.NoError(t, err)
+	assert.Empty(t, plans.GetPlans())
+
+	// кормлений еще не было - Клепа в списке пропущенных
+	overdue, err := c.ListOverdueFeedings(ctx, &ListOverdueFeedingsRequest{})
+	require.NoError(t, err)
+	require.Len(t, overdue.GetFeedings(), 1)
+	assert.Equal(t, "Klepa", overdue.GetFeedings()[0].GetName())
+	assert.Equal(t, plan.GetId(), overdue.GetFeedings()[0].GetPlanId())
+	assert.Nil(t, overdue.GetFeedings()[0].GetLastFedAt())
+
+	fedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	feed, err := c.RecordFeeding(ctx, &RecordFeedingRequest{AnimalId: klepa.IdAnim, PlanId: &plan.Id, Keeper: "Ivan", FedAt: timestamppb.New(fedAt)})
+	require.NoError(t, err)
+	assert.Equal(t, "fish", feed.GetFood())
+	assert.Equal(t, 200.0, feed.GetQuantity())
+	assert.Equal(t, "g", feed.GetUnit())
+	assert.True(t, fedAt.Equal(feed.GetFedAt().AsTime()))
+
+	overdue, err = c.ListOverdueFeedings(ctx, &ListOverdueFeedingsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, overdue.GetFeedings())
+
+	_, err = c.RecordFeeding(ctx, &RecordFeedingRequest{Food: "fish"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = c.RecordFeeding(ctx, &RecordFeedingRequest{AnimalId: 42, PlanId: &plan.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = c.RecordFeeding(ctx, &RecordFeedingRequest{AnimalId: klepa.IdAnim, Food: "fish", Quantity: -1, Unit: "g"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/metrics"
+	"github.com/mi-raf/zooad/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// observeInterceptor стоит перед errorInterceptor, поэтому видит уже итоговый код ответа
+func observeInterceptor(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		m.ObserveGRPC(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return res, err
+	}
+}
+
+// metadataCarrier позволяет пропагатору читать traceparent из входящих метаданных
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// traceInterceptor - первый в цепочке: спан покрывает и метрики, и разбор ошибок
+func traceInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+		}
+		srv, method, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+		ctx, span := tracing.Tracer().Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				semconv.RPCService(srv),
+				semconv.RPCMethod(method),
+			),
+		)
+		defer span.End()
+
+		res, err := handler(ctx, req)
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return res, err
+	}
+}
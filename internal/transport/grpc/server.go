@@ -0,0 +1,174 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	"github.com/mi-raf/zooad/internal/errs"
+	"github.com/mi-raf/zooad/internal/metrics"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	zl "github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+)
+
+type (
+	Config struct {
+		Addr string
+	}
+
+	Server struct {
+		UnimplementedAnimalServiceServer
+
+		srv  *grpc.Server
+		s    *service.AnimalService
+		addr string
+	}
+)
+
+func New(ctx context.Context, cfg *Config, s *service.AnimalService, fd *service.FeedingService, authn auth.Authenticator, m *metrics.Metrics) (*Server, error) {
+	g := &Server{
+		srv: grpc.NewServer(grpc.ChainUnaryInterceptor(
+			traceInterceptor(),
+			observeInterceptor(m),
+			errorInterceptor(),
+			authInterceptor(authn),
+		)),
+		s:    s,
+		addr: cfg.Addr,
+	}
+	RegisterAnimalServiceServer(g.srv, g)
+	RegisterFeedingServiceServer(g.srv, &feedingServer{s: fd})
+	return g, nil
+}
+
+func (g *Server) GetAnimal(ctx context.Context, req *AnimalRequest) (*AnimalResponse, error) {
+	if req.GetId() <= 0 {
+		return nil, errs.BadRequest("id must be positive")
+	}
+	animal, err := g.s.GetAnimal(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &AnimalResponse{AnimalType: toAnimalType(&animal.Animal)}, nil
+}
+
+func (g *Server) List(ctx context.Context, req *ListAnimalsRequest) (*AnimalsResponse, error) {
+	page := req.GetPaginateAnimals()
+	if page.GetPageSize() < 0 {
+		return nil, errs.BadRequest("page_size must not be negative")
+	}
+
+	filter, order, err := toAnimalFilter(req.GetFilterAnimals())
+	if err != nil {
+		return nil, err
+	}
+
+	animals, err := g.s.GetAllAnimal(ctx, filter, order, page.GetPageToken(), int(min(page.GetPageSize(), service.MaxPageSize)))
+	if err != nil {
+		return nil, err
+	}
+	res := &AnimalsResponse{
+		Animal:        make([]*AnimalResponse, 0, len(animals.Animals)),
+		NextPageToken: animals.NextPageToken,
+	}
+	for i := range animals.Animals {
+		res.Animal = append(res.Animal, &AnimalResponse{AnimalType: toAnimalType(&animals.Animals[i])})
+	}
+	return res, nil
+}
+
+func toAnimalFilter(f *FilterAnimals) (models.AnimalFilter, models.AnimalOrder, error) {
+	// фильтр в запросе необязателен, а у optional-полей проверяем сами указатели
+	if f == nil {
+		f = &FilterAnimals{}
+	}
+	filter := models.AnimalFilter{
+		Species:    f.GetSpeciesTitle(),
+		NamePrefix: f.GetNamePrefix(),
+	}
+	if f.Gender != nil {
+		filter.Gender = "m"
+		if f.GetGender() == Gender_FEMALE {
+			filter.Gender = "f"
+		}
+	}
+	for _, p := range []struct {
+		name string
+		v    *int32
+		dst  **int
+	}{{"min_age", f.MinAge, &filter.MinAge}, {"max_age", f.MaxAge, &filter.MaxAge}} {
+		if p.v == nil {
+			continue
+		}
+		// как в REST: отрицательный возраст - ошибка, а не пустая выборка
+		if *p.v < 0 {
+			return filter, models.AnimalOrder{}, errs.BadRequest("incorrect %s", p.name)
+		}
+		age := int(*p.v)
+		*p.dst = &age
+	}
+	for _, p := range []struct {
+		name string
+		v    *string
+		dst  **time.Time
+	}{{"born_after", f.BornAfter, &filter.BornAfter}, {"born_before", f.BornBefore, &filter.BornBefore}} {
+		if p.v == nil {
+			continue
+		}
+		d, err := time.Parse(service.DateLayout, *p.v)
+		if err != nil {
+			return filter, models.AnimalOrder{}, errs.BadRequest("incorrect %s, expected YYYY-MM-DD", p.name)
+		}
+		*p.dst = &d
+	}
+	if f.EnclosureId != nil {
+		id := f.GetEnclosureId()
+		filter.Enclosure = &id
+	}
+	order, err := service.ParseAnimalOrder(f.GetOrderBy())
+	return filter, order, err
+}
+
+func toAnimalType(a *models.Animal) *AnimalType {
+	gender := Gender_MAN
+	if a.Gender == "f" {
+		gender = Gender_FEMALE
+	}
+	age := service.AgeAt(a.BirthDate, time.Now())
+	return &AnimalType{
+		Id:             a.IdAnim,
+		Name:           a.NameAn,
+		Description:    a.Descrip,
+		Age:            int32(age.Years),
+		AgeMonths:      int32(age.Months),
+		BirthDate:      a.BirthDate.Format(service.DateLayout),
+		BirthPrecision: a.BirthPrecision,
+		RainbowSex:     gender,
+		Type:           Species(Species_value[strings.ToUpper(a.Title)]),
+		SpeciesTitle:   a.Title,
+		EnclosureId:    a.IdEncl,
+	}
+}
+
+func (g *Server) Start() error {
+	lis, err := net.Listen("tcp", g.addr)
+	if err != nil {
+		return err
+	}
+	zl.Debug().Msgf("grpc listening on %v", g.addr)
+	err = g.srv.Serve(lis)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+	return err
+}
+
+func (g *Server) Close() error {
+	g.srv.GracefulStop()
+	return nil
+}
@@ -0,0 +1,139 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mi-raf/zooad/internal/auth"
+	database "github.com/mi-raf/zooad/internal/database"
+	"github.com/mi-raf/zooad/internal/metrics"
+	models "github.com/mi-raf/zooad/internal/models"
+	"github.com/mi-raf/zooad/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testZoo struct {
+	conn     *grpc.ClientConn
+	animals  *service.AnimalService
+	feedings *service.FeedingService
+}
+
+// newTestZoo поднимает сервер на bufconn; роли субъектов authn берутся из справочника, root - администратор
+func newTestZoo(t *testing.T, authn auth.Authenticator) *testZoo {
+	ctx := context.Background()
+	st := database.NewMemStorage()
+	_, err := database.NewMemSpeciesRepository(st).Add(ctx, &models.Specie{Title: "cat", Descrip: "meow"})
+	require.NoError(t, err)
+	tokens, err := service.NewPageTokenCodec("secret")
+	require.NoError(t, err)
+	v := service.NewValidator()
+	animals := database.NewMemAnimalRepository(st)
+	enclosures := database.NewMemEnclosureRepository(st)
+	moods := database.NewMemMoodRepository(st)
+	moodCfg := &service.MoodConfig{HungryAfter: 8 * time.Hour, FedRecently: time.Hour, MedicalWindow: 7 * 24 * time.Hour, ObservationWindow: 24 * time.Hour}
+	mood := service.NewMoodEngine(service.DefaultMoodRules(moodCfg), database.NewMemFeedingRepository(st),
+		database.NewMemMedicalRepository(st), enclosures, database.NewMemObservationRepository(st), moods, moodCfg)
+	z := &testZoo{
+		animals: service.NewAnimalService(animals, enclosures, database.NewMemLineageRepository(st),
+			database.NewMemKeeperRepository(st), mood, tokens, v),
+		feedings: service.NewFeedingService(database.NewMemFeedingRepository(st), animals, v, &service.FeedingConfig{Grace: 30 * time.Minute}),
+	}
+	authn = service.NewRoleResolver(authn, database.NewMemUserRepository(st), []string{"root"})
+	g, err := New(ctx, &Config{}, z.animals, z.feedings, authn, metrics.New())
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	go g.srv.Serve(lis)
+	t.Cleanup(g.srv.Stop)
+	z.conn, err = grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { z.conn.Close() })
+	return z
+}
+
+func (z *testZoo) addAnimal(t *testing.T, name, birth, gender string) *models.Animal {
+	b, err := time.Parse(service.DateLayout, birth)
+	require.NoError(t, err)
+	an, err := z.animals.AddAnimal(auth.WithIdentity(context.Background(), auth.System),
+		&models.Animal{NameAn: name, BirthDate: b, Gender: gender, Title: "cat"})
+	require.NoError(t, err)
+	return an
+}
+
+func TestListPaging(t *testing.T) {
+	z := newTestZoo(t, auth.Anonymous{Role: models.RoleAdmin})
+	for _, an := range []struct{ name, birth, gender string }{
+		{"Klepa", "2011-03-14", "f"},
+		{"Barsik", "2015-06-01", "m"},
+		{"Kuzya", "2018-01-20", "m"},
+		{"Kira", "2020-09-09", "f"},
+	} {
+		z.addAnimal(t, an.name, an.birth, an.gender)
+	}
+	c := NewAnimalServiceClient(z.conn)
+	ctx := context.Background()
+
+	var names []string
+	req := &ListAnimalsRequest{
+		PaginateAnimals: &PaginateAnimals{PageSize: 1},
+		FilterAnimals:   &FilterAnimals{NamePrefix: "K", OrderBy: "name"},
+	}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 4, "paging does not end")
+		res, err := c.List(ctx, req)
+		require.NoError(t, err)
+		for _, a := range res.GetAnimal() {
+			names = append(names, a.GetAnimalType().GetName())
+		}
+		if res.GetNextPageToken() == "" {
+			break
+		}
+		req.PaginateAnimals.PageToken = res.GetNextPageToken()
+	}
+	assert.Equal(t, []string{"Kira", "Klepa", "Kuzya"}, names)
+
+	female := Gender_FEMALE
+	bornAfter := "2012-01-01"
+	res, err := c.List(ctx, &ListAnimalsRequest{FilterAnimals: &FilterAnimals{Gender: &female, BornAfter: &bornAfter}})
+	require.NoError(t, err)
+	require.Len(t, res.GetAnimal(), 1)
+	kira := res.GetAnimal()[0].GetAnimalType()
+	assert.Equal(t, "Kira", kira.GetName())
+	assert.Equal(t, Gender_FEMALE, kira.GetRainbowSex())
+	assert.Equal(t, Species_CAT, kira.GetType())
+	assert.Equal(t, "2020-09-09", kira.GetBirthDate())
+
+	minAge := int32(100)
+	res, err = c.List(ctx, &ListAnimalsRequest{FilterAnimals: &FilterAnimals{MinAge: &minAge}})
+	require.NoError(t, err)
+	assert.Empty(t, res.GetAnimal())
+
+	negative := int32(-1)
+	bad := "9.9.2020"
+	for _, r := range []*ListAnimalsRequest{
+		{PaginateAnimals: &PaginateAnimals{PageSize: -1}},
+		{FilterAnimals: &FilterAnimals{MinAge: &negative}},
+		{FilterAnimals: &FilterAnimals{MaxAge: &negative}},
+		{FilterAnimals: &FilterAnimals{BornBefore: &bad}},
+		{FilterAnimals: &FilterAnimals{OrderBy: "weight"}},
+		{PaginateAnimals: &PaginateAnimals{PageToken: "garbage"}},
+	} {
+		_, err := c.List(ctx, r)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), r.String())
+	}
+
+	_, err = c.GetAnimal(ctx, &AnimalRequest{Id: 42})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = c.GetAnimal(ctx, &AnimalRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.33.0
-// 	protoc        v5.26.0
+// 	protoc        (unknown)
 // source: api/zoo.proto
 
 package grpc
@@ -9,6 +9,7 @@ package grpc
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -24,19 +25,19 @@ const (
 type Gender int32
 
 const (
-	Gender_MAN   Gender = 0
-	Gender_WOMEN Gender = 1
+	Gender_MAN    Gender = 0
+	Gender_FEMALE Gender = 1
 )
 
 // Enum value maps for Gender.
 var (
 	Gender_name = map[int32]string{
 		0: "MAN",
-		1: "WOMEN",
+		1: "FEMALE",
 	}
 	Gender_value = map[string]int32{
-		"MAN":   0,
-		"WOMEN": 1,
+		"MAN":    0,
+		"FEMALE": 1,
 	}
 )
 
@@ -122,12 +123,23 @@ type AnimalType struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description string  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Age         int32   `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
-	RainbowSex  Gender  `protobuf:"varint,5,opt,name=rainbowSex,proto3,enum=main.Gender" json:"rainbowSex,omitempty"`
-	Type        Species `protobuf:"varint,6,opt,name=type,proto3,enum=main.Species" json:"type,omitempty"`
+	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Полных лет на момент ответа, вычисляется из birth_date
+	Age        int32   `protobuf:"varint,4,opt,name=age,proto3" json:"age,omitempty"`
+	RainbowSex Gender  `protobuf:"varint,5,opt,name=rainbowSex,proto3,enum=main.Gender" json:"rainbowSex,omitempty"`
+	Type       Species `protobuf:"varint,6,opt,name=type,proto3,enum=main.Species" json:"type,omitempty"`
+	// Название вида, type заполняется только для известных enum-видов
+	SpeciesTitle string `protobuf:"bytes,7,opt,name=species_title,json=speciesTitle,proto3" json:"species_title,omitempty"`
+	// Вольер, в котором живет животное; не задан - животное не размещено
+	EnclosureId *int64 `protobuf:"varint,8,opt,name=enclosure_id,json=enclosureId,proto3,oneof" json:"enclosure_id,omitempty"`
+	// Дата рождения в формате 2006-01-02
+	BirthDate string `protobuf:"bytes,9,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
+	// exact, month, year или estimated
+	BirthPrecision string `protobuf:"bytes,10,opt,name=birth_precision,json=birthPrecision,proto3" json:"birth_precision,omitempty"`
+	// Месяцев сверх полных лет
+	AgeMonths int32 `protobuf:"varint,11,opt,name=age_months,json=ageMonths,proto3" json:"age_months,omitempty"`
 }
 
 func (x *AnimalType) Reset() {
@@ -204,6 +216,41 @@ func (x *AnimalType) GetType() Species {
 	return Species_CAT
 }
 
+func (x *AnimalType) GetSpeciesTitle() string {
+	if x != nil {
+		return x.SpeciesTitle
+	}
+	return ""
+}
+
+func (x *AnimalType) GetEnclosureId() int64 {
+	if x != nil && x.EnclosureId != nil {
+		return *x.EnclosureId
+	}
+	return 0
+}
+
+func (x *AnimalType) GetBirthDate() string {
+	if x != nil {
+		return x.BirthDate
+	}
+	return ""
+}
+
+func (x *AnimalType) GetBirthPrecision() string {
+	if x != nil {
+		return x.BirthPrecision
+	}
+	return ""
+}
+
+func (x *AnimalType) GetAgeMonths() int32 {
+	if x != nil {
+		return x.AgeMonths
+	}
+	return 0
+}
+
 type AnimalResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -412,18 +459,133 @@ func (x *PaginateAnimals) GetPageToken() string {
 	return ""
 }
 
+// Фильтр и сортировка списка, пустые поля не фильтруют
+type FilterAnimals struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SpeciesTitle string  `protobuf:"bytes,1,opt,name=species_title,json=speciesTitle,proto3" json:"species_title,omitempty"`
+	Gender       *Gender `protobuf:"varint,2,opt,name=gender,proto3,enum=main.Gender,oneof" json:"gender,omitempty"`
+	NamePrefix   string  `protobuf:"bytes,3,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	MinAge       *int32  `protobuf:"varint,4,opt,name=min_age,json=minAge,proto3,oneof" json:"min_age,omitempty"`
+	MaxAge       *int32  `protobuf:"varint,5,opt,name=max_age,json=maxAge,proto3,oneof" json:"max_age,omitempty"`
+	// id, name или age, с минусом впереди - по убыванию
+	OrderBy     string `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	EnclosureId *int64 `protobuf:"varint,7,opt,name=enclosure_id,json=enclosureId,proto3,oneof" json:"enclosure_id,omitempty"`
+	// Родились строго после и строго до даты в формате 2006-01-02
+	BornAfter  *string `protobuf:"bytes,8,opt,name=born_after,json=bornAfter,proto3,oneof" json:"born_after,omitempty"`
+	BornBefore *string `protobuf:"bytes,9,opt,name=born_before,json=bornBefore,proto3,oneof" json:"born_before,omitempty"`
+}
+
+func (x *FilterAnimals) Reset() {
+	*x = FilterAnimals{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterAnimals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterAnimals) ProtoMessage() {}
+
+func (x *FilterAnimals) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterAnimals.ProtoReflect.Descriptor instead.
+func (*FilterAnimals) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FilterAnimals) GetSpeciesTitle() string {
+	if x != nil {
+		return x.SpeciesTitle
+	}
+	return ""
+}
+
+func (x *FilterAnimals) GetGender() Gender {
+	if x != nil && x.Gender != nil {
+		return *x.Gender
+	}
+	return Gender_MAN
+}
+
+func (x *FilterAnimals) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *FilterAnimals) GetMinAge() int32 {
+	if x != nil && x.MinAge != nil {
+		return *x.MinAge
+	}
+	return 0
+}
+
+func (x *FilterAnimals) GetMaxAge() int32 {
+	if x != nil && x.MaxAge != nil {
+		return *x.MaxAge
+	}
+	return 0
+}
+
+func (x *FilterAnimals) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *FilterAnimals) GetEnclosureId() int64 {
+	if x != nil && x.EnclosureId != nil {
+		return *x.EnclosureId
+	}
+	return 0
+}
+
+func (x *FilterAnimals) GetBornAfter() string {
+	if x != nil && x.BornAfter != nil {
+		return *x.BornAfter
+	}
+	return ""
+}
+
+func (x *FilterAnimals) GetBornBefore() string {
+	if x != nil && x.BornBefore != nil {
+		return *x.BornBefore
+	}
+	return ""
+}
+
 type ListAnimalsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	PaginateAnimals *PaginateAnimals `protobuf:"bytes,1,opt,name=paginateAnimals,proto3" json:"paginateAnimals,omitempty"`
+	FilterAnimals   *FilterAnimals   `protobuf:"bytes,2,opt,name=filterAnimals,proto3" json:"filterAnimals,omitempty"`
 }
 
 func (x *ListAnimalsRequest) Reset() {
 	*x = ListAnimalsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_zoo_proto_msgTypes[5]
+		mi := &file_api_zoo_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -436,7 +598,7 @@ func (x *ListAnimalsRequest) String() string {
 func (*ListAnimalsRequest) ProtoMessage() {}
 
 func (x *ListAnimalsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_zoo_proto_msgTypes[5]
+	mi := &file_api_zoo_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +611,7 @@ func (x *ListAnimalsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAnimalsRequest.ProtoReflect.Descriptor instead.
 func (*ListAnimalsRequest) Descriptor() ([]byte, []int) {
-	return file_api_zoo_proto_rawDescGZIP(), []int{5}
+	return file_api_zoo_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListAnimalsRequest) GetPaginateAnimals() *PaginateAnimals {
@@ -459,136 +621,902 @@ func (x *ListAnimalsRequest) GetPaginateAnimals() *PaginateAnimals {
 	return nil
 }
 
-var File_api_zoo_proto protoreflect.FileDescriptor
+func (x *ListAnimalsRequest) GetFilterAnimals() *FilterAnimals {
+	if x != nil {
+		return x.FilterAnimals
+	}
+	return nil
+}
 
-var file_api_zoo_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x61, 0x70, 0x69, 0x2f, 0x7a, 0x6f, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x04, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0xb5, 0x01, 0x0a, 0x0a, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x0a,
-	0x72, 0x61, 0x69, 0x6e, 0x62, 0x6f, 0x77, 0x53, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x0c, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x0a,
-	0x72, 0x61, 0x69, 0x6e, 0x62, 0x6f, 0x77, 0x53, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
-	0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x42, 0x0a,
-	0x0e, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x30, 0x0a, 0x0a, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x41, 0x6e, 0x69, 0x6d, 0x61,
-	0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x54, 0x79, 0x70,
-	0x65, 0x22, 0x1f, 0x0a, 0x0d, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
-	0x69, 0x64, 0x22, 0x67, 0x0a, 0x0f, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x41, 0x6e, 0x69,
-	0x6d, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x41, 0x6e, 0x69,
-	0x6d, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
-	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
-	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x4d, 0x0a, 0x0f, 0x50,
-	0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x12, 0x1b,
-	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
-	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x55, 0x0a, 0x12, 0x4c, 0x69,
-	0x73, 0x74, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x3f, 0x0a, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x69, 0x6d,
-	0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
-	0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73,
-	0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c,
-	0x73, 0x2a, 0x1c, 0x0a, 0x06, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x4d,
-	0x41, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x57, 0x4f, 0x4d, 0x45, 0x4e, 0x10, 0x01, 0x2a,
-	0x24, 0x0a, 0x07, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x41,
-	0x54, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x4f, 0x47, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03,
-	0x52, 0x41, 0x54, 0x10, 0x02, 0x32, 0x80, 0x01, 0x0a, 0x0d, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x6e,
-	0x69, 0x6d, 0x61, 0x6c, 0x12, 0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x41, 0x6e, 0x69, 0x6d,
-	0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
-	0x2e, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x37, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x15, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x19, 0x5a, 0x17, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x2f, 0x67,
-	0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+// План кормления вида или конкретного животного
+type FeedingPlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SpeciesTitle string  `protobuf:"bytes,2,opt,name=species_title,json=speciesTitle,proto3" json:"species_title,omitempty"`
+	AnimalId     *int64  `protobuf:"varint,3,opt,name=animal_id,json=animalId,proto3,oneof" json:"animal_id,omitempty"`
+	Food         string  `protobuf:"bytes,4,opt,name=food,proto3" json:"food,omitempty"`
+	Quantity     float64 `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit         string  `protobuf:"bytes,6,opt,name=unit,proto3" json:"unit,omitempty"`
+	// время кормлений в течение суток, HH:MM
+	Times []string `protobuf:"bytes,7,rep,name=times,proto3" json:"times,omitempty"`
 }
 
-var (
-	file_api_zoo_proto_rawDescOnce sync.Once
-	file_api_zoo_proto_rawDescData = file_api_zoo_proto_rawDesc
-)
+func (x *FeedingPlan) Reset() {
+	*x = FeedingPlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_api_zoo_proto_rawDescGZIP() []byte {
-	file_api_zoo_proto_rawDescOnce.Do(func() {
-		file_api_zoo_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_zoo_proto_rawDescData)
-	})
-	return file_api_zoo_proto_rawDescData
+func (x *FeedingPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_api_zoo_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_zoo_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_api_zoo_proto_goTypes = []interface{}{
-	(Gender)(0),                // 0: main.Gender
-	(Species)(0),               // 1: main.Species
-	(*AnimalType)(nil),         // 2: main.AnimalType
-	(*AnimalResponse)(nil),     // 3: main.AnimalResponse
-	(*AnimalRequest)(nil),      // 4: main.AnimalRequest
-	(*AnimalsResponse)(nil),    // 5: main.AnimalsResponse
-	(*PaginateAnimals)(nil),    // 6: main.PaginateAnimals
-	(*ListAnimalsRequest)(nil), // 7: main.ListAnimalsRequest
+func (*FeedingPlan) ProtoMessage() {}
+
+func (x *FeedingPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_api_zoo_proto_depIdxs = []int32{
-	0, // 0: main.AnimalType.rainbowSex:type_name -> main.Gender
-	1, // 1: main.AnimalType.type:type_name -> main.Species
-	2, // 2: main.AnimalResponse.animalType:type_name -> main.AnimalType
-	3, // 3: main.AnimalsResponse.Animal:type_name -> main.AnimalResponse
-	6, // 4: main.ListAnimalsRequest.paginateAnimals:type_name -> main.PaginateAnimals
-	4, // 5: main.AnimalService.GetAnimal:input_type -> main.AnimalRequest
-	7, // 6: main.AnimalService.List:input_type -> main.ListAnimalsRequest
-	3, // 7: main.AnimalService.GetAnimal:output_type -> main.AnimalResponse
-	5, // 8: main.AnimalService.List:output_type -> main.AnimalsResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+
+// Deprecated: Use FeedingPlan.ProtoReflect.Descriptor instead.
+func (*FeedingPlan) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{7}
 }
 
-func init() { file_api_zoo_proto_init() }
-func file_api_zoo_proto_init() {
-	if File_api_zoo_proto != nil {
-		return
+func (x *FeedingPlan) GetId() int64 {
+	if x != nil {
+		return x.Id
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_zoo_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AnimalType); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return 0
+}
+
+func (x *FeedingPlan) GetSpeciesTitle() string {
+	if x != nil {
+		return x.SpeciesTitle
+	}
+	return ""
+}
+
+func (x *FeedingPlan) GetAnimalId() int64 {
+	if x != nil && x.AnimalId != nil {
+		return *x.AnimalId
+	}
+	return 0
+}
+
+func (x *FeedingPlan) GetFood() string {
+	if x != nil {
+		return x.Food
+	}
+	return ""
+}
+
+func (x *FeedingPlan) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *FeedingPlan) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *FeedingPlan) GetTimes() []string {
+	if x != nil {
+		return x.Times
+	}
+	return nil
+}
+
+type ListFeedingPlansRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SpeciesTitle string `protobuf:"bytes,1,opt,name=species_title,json=speciesTitle,proto3" json:"species_title,omitempty"`
+	AnimalId     *int64 `protobuf:"varint,2,opt,name=animal_id,json=animalId,proto3,oneof" json:"animal_id,omitempty"`
+}
+
+func (x *ListFeedingPlansRequest) Reset() {
+	*x = ListFeedingPlansRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFeedingPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedingPlansRequest) ProtoMessage() {}
+
+func (x *ListFeedingPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_api_zoo_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AnimalResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedingPlansRequest.ProtoReflect.Descriptor instead.
+func (*ListFeedingPlansRequest) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListFeedingPlansRequest) GetSpeciesTitle() string {
+	if x != nil {
+		return x.SpeciesTitle
+	}
+	return ""
+}
+
+func (x *ListFeedingPlansRequest) GetAnimalId() int64 {
+	if x != nil && x.AnimalId != nil {
+		return *x.AnimalId
+	}
+	return 0
+}
+
+type FeedingPlansResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Plans []*FeedingPlan `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+}
+
+func (x *FeedingPlansResponse) Reset() {
+	*x = FeedingPlansResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedingPlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedingPlansResponse) ProtoMessage() {}
+
+func (x *FeedingPlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_api_zoo_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AnimalRequest); i {
-			case 0:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedingPlansResponse.ProtoReflect.Descriptor instead.
+func (*FeedingPlansResponse) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FeedingPlansResponse) GetPlans() []*FeedingPlan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+// Запись о кормлении; food, quantity и unit берутся из плана, если не заданы
+type RecordFeedingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AnimalId int64   `protobuf:"varint,1,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	PlanId   *int64  `protobuf:"varint,2,opt,name=plan_id,json=planId,proto3,oneof" json:"plan_id,omitempty"`
+	Food     string  `protobuf:"bytes,3,opt,name=food,proto3" json:"food,omitempty"`
+	Quantity float64 `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit     string  `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	Keeper   string  `protobuf:"bytes,6,opt,name=keeper,proto3" json:"keeper,omitempty"`
+	// не задано - кормление сейчас
+	FedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=fed_at,json=fedAt,proto3" json:"fed_at,omitempty"`
+	Notes string                 `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+}
+
+func (x *RecordFeedingRequest) Reset() {
+	*x = RecordFeedingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordFeedingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordFeedingRequest) ProtoMessage() {}
+
+func (x *RecordFeedingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordFeedingRequest.ProtoReflect.Descriptor instead.
+func (*RecordFeedingRequest) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RecordFeedingRequest) GetAnimalId() int64 {
+	if x != nil {
+		return x.AnimalId
+	}
+	return 0
+}
+
+func (x *RecordFeedingRequest) GetPlanId() int64 {
+	if x != nil && x.PlanId != nil {
+		return *x.PlanId
+	}
+	return 0
+}
+
+func (x *RecordFeedingRequest) GetFood() string {
+	if x != nil {
+		return x.Food
+	}
+	return ""
+}
+
+func (x *RecordFeedingRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *RecordFeedingRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *RecordFeedingRequest) GetKeeper() string {
+	if x != nil {
+		return x.Keeper
+	}
+	return ""
+}
+
+func (x *RecordFeedingRequest) GetFedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FedAt
+	}
+	return nil
+}
+
+func (x *RecordFeedingRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type FeedingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AnimalId int64                  `protobuf:"varint,2,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	PlanId   *int64                 `protobuf:"varint,3,opt,name=plan_id,json=planId,proto3,oneof" json:"plan_id,omitempty"`
+	Food     string                 `protobuf:"bytes,4,opt,name=food,proto3" json:"food,omitempty"`
+	Quantity float64                `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit     string                 `protobuf:"bytes,6,opt,name=unit,proto3" json:"unit,omitempty"`
+	Keeper   string                 `protobuf:"bytes,7,opt,name=keeper,proto3" json:"keeper,omitempty"`
+	FedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=fed_at,json=fedAt,proto3" json:"fed_at,omitempty"`
+	Notes    string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+}
+
+func (x *FeedingResponse) Reset() {
+	*x = FeedingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedingResponse) ProtoMessage() {}
+
+func (x *FeedingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedingResponse.ProtoReflect.Descriptor instead.
+func (*FeedingResponse) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FeedingResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FeedingResponse) GetAnimalId() int64 {
+	if x != nil {
+		return x.AnimalId
+	}
+	return 0
+}
+
+func (x *FeedingResponse) GetPlanId() int64 {
+	if x != nil && x.PlanId != nil {
+		return *x.PlanId
+	}
+	return 0
+}
+
+func (x *FeedingResponse) GetFood() string {
+	if x != nil {
+		return x.Food
+	}
+	return ""
+}
+
+func (x *FeedingResponse) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *FeedingResponse) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *FeedingResponse) GetKeeper() string {
+	if x != nil {
+		return x.Keeper
+	}
+	return ""
+}
+
+func (x *FeedingResponse) GetFedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FedAt
+	}
+	return nil
+}
+
+func (x *FeedingResponse) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type ListOverdueFeedingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListOverdueFeedingsRequest) Reset() {
+	*x = ListOverdueFeedingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListOverdueFeedingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOverdueFeedingsRequest) ProtoMessage() {}
+
+func (x *ListOverdueFeedingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOverdueFeedingsRequest.ProtoReflect.Descriptor instead.
+func (*ListOverdueFeedingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{12}
+}
+
+type OverdueFeeding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AnimalId     int64                  `protobuf:"varint,1,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SpeciesTitle string                 `protobuf:"bytes,3,opt,name=species_title,json=speciesTitle,proto3" json:"species_title,omitempty"`
+	PlanId       int64                  `protobuf:"varint,4,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	Food         string                 `protobuf:"bytes,5,opt,name=food,proto3" json:"food,omitempty"`
+	DueAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	LastFedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_fed_at,json=lastFedAt,proto3" json:"last_fed_at,omitempty"`
+}
+
+func (x *OverdueFeeding) Reset() {
+	*x = OverdueFeeding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OverdueFeeding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverdueFeeding) ProtoMessage() {}
+
+func (x *OverdueFeeding) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverdueFeeding.ProtoReflect.Descriptor instead.
+func (*OverdueFeeding) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OverdueFeeding) GetAnimalId() int64 {
+	if x != nil {
+		return x.AnimalId
+	}
+	return 0
+}
+
+func (x *OverdueFeeding) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OverdueFeeding) GetSpeciesTitle() string {
+	if x != nil {
+		return x.SpeciesTitle
+	}
+	return ""
+}
+
+func (x *OverdueFeeding) GetPlanId() int64 {
+	if x != nil {
+		return x.PlanId
+	}
+	return 0
+}
+
+func (x *OverdueFeeding) GetFood() string {
+	if x != nil {
+		return x.Food
+	}
+	return ""
+}
+
+func (x *OverdueFeeding) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *OverdueFeeding) GetLastFedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFedAt
+	}
+	return nil
+}
+
+type OverdueFeedingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Feedings []*OverdueFeeding `protobuf:"bytes,1,rep,name=feedings,proto3" json:"feedings,omitempty"`
+}
+
+func (x *OverdueFeedingsResponse) Reset() {
+	*x = OverdueFeedingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_zoo_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OverdueFeedingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverdueFeedingsResponse) ProtoMessage() {}
+
+func (x *OverdueFeedingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_zoo_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverdueFeedingsResponse.ProtoReflect.Descriptor instead.
+func (*OverdueFeedingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_zoo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OverdueFeedingsResponse) GetFeedings() []*OverdueFeeding {
+	if x != nil {
+		return x.Feedings
+	}
+	return nil
+}
+
+var File_api_zoo_proto protoreflect.FileDescriptor
+
+var file_api_zoo_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x61, 0x70, 0x69, 0x2f, 0x7a, 0x6f, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x04, 0x6d, 0x61, 0x69, 0x6e, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfa, 0x02, 0x0a, 0x0a, 0x41, 0x6e, 0x69, 0x6d, 0x61,
+	0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x61,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a,
+	0x0a, 0x72, 0x61, 0x69, 0x6e, 0x62, 0x6f, 0x77, 0x53, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0c, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52,
+	0x0a, 0x72, 0x61, 0x69, 0x6e, 0x62, 0x6f, 0x77, 0x53, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
+	0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x54, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x26, 0x0a, 0x0c, 0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x6e, 0x63,
+	0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x69, 0x72, 0x74, 0x68, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x62, 0x69, 0x72, 0x74, 0x68, 0x44, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x69,
+	0x72, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x62, 0x69, 0x72, 0x74, 0x68, 0x50, 0x72, 0x65, 0x63, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x6e, 0x74,
+	0x68, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65,
+	0x5f, 0x69, 0x64, 0x22, 0x42, 0x0a, 0x0e, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0a, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
+	0x2e, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x61, 0x6e, 0x69,
+	0x6d, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x22, 0x1f, 0x0a, 0x0d, 0x41, 0x6e, 0x69, 0x6d, 0x61,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x67, 0x0a, 0x0f, 0x41, 0x6e, 0x69, 0x6d,
+	0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x41,
+	0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x52, 0x06, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x4d, 0x0a, 0x0f, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x69,
+	0x6d, 0x61, 0x6c, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0x9c, 0x03, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x6e, 0x69, 0x6d, 0x61,
+	0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x5f, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x70, 0x65, 0x63, 0x69,
+	0x65, 0x73, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x88,
+	0x01, 0x01, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x12, 0x1c, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x41, 0x67, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x1c, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x48, 0x02, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x26, 0x0a, 0x0c, 0x65, 0x6e,
+	0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x48, 0x03, 0x52, 0x0b, 0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x49, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x62, 0x6f, 0x72, 0x6e, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x09, 0x62, 0x6f, 0x72, 0x6e, 0x41, 0x66,
+	0x74, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x62, 0x6f, 0x72, 0x6e, 0x5f, 0x62,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x0a, 0x62,
+	0x6f, 0x72, 0x6e, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x69, 0x6e, 0x5f,
+	0x61, 0x67, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x42,
+	0x0f, 0x0a, 0x0d, 0x5f, 0x65, 0x6e, 0x63, 0x6c, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x64,
+	0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x62, 0x6f, 0x72, 0x6e, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x42,
+	0x0e, 0x0a, 0x0c, 0x5f, 0x62, 0x6f, 0x72, 0x6e, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22,
+	0x90, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x41,
+	0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x65,
+	0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x12, 0x39, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x6e, 0x69, 0x6d,
+	0x61, 0x6c, 0x73, 0x52, 0x0d, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x6e, 0x69, 0x6d, 0x61,
+	0x6c, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x0b, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c,
+	0x61, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x5f, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x70, 0x65, 0x63, 0x69,
+	0x65, 0x73, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x09, 0x61, 0x6e, 0x69, 0x6d, 0x61,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x61, 0x6e,
+	0x69, 0x6d, 0x61, 0x6c, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6f, 0x6f,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x6f, 0x6f, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x69,
+	0x64, 0x22, 0x6e, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67,
+	0x50, 0x6c, 0x61, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x54, 0x69, 0x74, 0x6c,
+	0x65, 0x12, 0x20, 0x0a, 0x09, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x49, 0x64,
+	0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x69,
+	0x64, 0x22, 0x3f, 0x0a, 0x14, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x70, 0x6c, 0x61,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
+	0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x05, 0x70, 0x6c, 0x61,
+	0x6e, 0x73, 0x22, 0x82, 0x02, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x65, 0x65,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x61,
+	0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x70, 0x6c, 0x61,
+	0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6f, 0x6f, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x6f, 0x6f, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65,
+	0x65, 0x70, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x65, 0x70,
+	0x65, 0x72, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05,
+	0x66, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x22, 0x8d, 0x02, 0x0a, 0x0f, 0x46, 0x65, 0x65, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61,
+	0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x61, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x70, 0x6c, 0x61,
+	0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6f, 0x6f, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x6f, 0x6f, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65,
+	0x65, 0x70, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x65, 0x70,
+	0x65, 0x72, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05,
+	0x66, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x22, 0x1c, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x4f,
+	0x76, 0x65, 0x72, 0x64, 0x75, 0x65, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x82, 0x02, 0x0a, 0x0e, 0x4f, 0x76, 0x65, 0x72, 0x64, 0x75,
+	0x65, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6e, 0x69, 0x6d,
+	0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x6e, 0x69,
+	0x6d, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x65, 0x73, 0x5f, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x70, 0x6c, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6f, 0x6f, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x6f, 0x6f, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x64,
+	0x75, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x64, 0x75, 0x65, 0x41, 0x74, 0x12, 0x3a,
+	0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x66, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4b, 0x0a, 0x17, 0x4f, 0x76,
+	0x65, 0x72, 0x64, 0x75, 0x65, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x66, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4f,
+	0x76, 0x65, 0x72, 0x64, 0x75, 0x65, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x66,
+	0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2a, 0x1d, 0x0a, 0x06, 0x47, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x41, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x45,
+	0x4d, 0x41, 0x4c, 0x45, 0x10, 0x01, 0x2a, 0x24, 0x0a, 0x07, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65,
+	0x73, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x41, 0x54, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x4f,
+	0x47, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x41, 0x54, 0x10, 0x02, 0x32, 0x80, 0x01, 0x0a,
+	0x0d, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36,
+	0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x13, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
+	0x41, 0x6e, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
+	0xfb, 0x01, 0x0a, 0x0e, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x4d, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e,
+	0x67, 0x50, 0x6c, 0x61, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x46, 0x65, 0x65,
+	0x64, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x42, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x46, 0x65, 0x65, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x1a, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x76, 0x65,
+	0x72, 0x64, 0x75, 0x65, 0x46, 0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x20, 0x2e, 0x6d,
+	0x61, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x64, 0x75, 0x65, 0x46,
+	0x65, 0x65, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x64, 0x75, 0x65, 0x46, 0x65, 0x65,
+	0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x19, 0x5a,
+	0x17, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_zoo_proto_rawDescOnce sync.Once
+	file_api_zoo_proto_rawDescData = file_api_zoo_proto_rawDesc
+)
+
+func file_api_zoo_proto_rawDescGZIP() []byte {
+	file_api_zoo_proto_rawDescOnce.Do(func() {
+		file_api_zoo_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_zoo_proto_rawDescData)
+	})
+	return file_api_zoo_proto_rawDescData
+}
+
+var file_api_zoo_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_zoo_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_api_zoo_proto_goTypes = []interface{}{
+	(Gender)(0),                        // 0: main.Gender
+	(Species)(0),                       // 1: main.Species
+	(*AnimalType)(nil),                 // 2: main.AnimalType
+	(*AnimalResponse)(nil),             // 3: main.AnimalResponse
+	(*AnimalRequest)(nil),              // 4: main.AnimalRequest
+	(*AnimalsResponse)(nil),            // 5: main.AnimalsResponse
+	(*PaginateAnimals)(nil),            // 6: main.PaginateAnimals
+	(*FilterAnimals)(nil),              // 7: main.FilterAnimals
+	(*ListAnimalsRequest)(nil),         // 8: main.ListAnimalsRequest
+	(*FeedingPlan)(nil),                // 9: main.FeedingPlan
+	(*ListFeedingPlansRequest)(nil),    // 10: main.ListFeedingPlansRequest
+	(*FeedingPlansResponse)(nil),       // 11: main.FeedingPlansResponse
+	(*RecordFeedingRequest)(nil),       // 12: main.RecordFeedingRequest
+	(*FeedingResponse)(nil),            // 13: main.FeedingResponse
+	(*ListOverdueFeedingsRequest)(nil), // 14: main.ListOverdueFeedingsRequest
+	(*OverdueFeeding)(nil),             // 15: main.OverdueFeeding
+	(*OverdueFeedingsResponse)(nil),    // 16: main.OverdueFeedingsResponse
+	(*timestamppb.Timestamp)(nil),      // 17: google.protobuf.Timestamp
+}
+var file_api_zoo_proto_depIdxs = []int32{
+	0,  // 0: main.AnimalType.rainbowSex:type_name -> main.Gender
+	1,  // 1: main.AnimalType.type:type_name -> main.Species
+	2,  // 2: main.AnimalResponse.animalType:type_name -> main.AnimalType
+	3,  // 3: main.AnimalsResponse.Animal:type_name -> main.AnimalResponse
+	0,  // 4: main.FilterAnimals.gender:type_name -> main.Gender
+	6,  // 5: main.ListAnimalsRequest.paginateAnimals:type_name -> main.PaginateAnimals
+	7,  // 6: main.ListAnimalsRequest.filterAnimals:type_name -> main.FilterAnimals
+	9,  // 7: main.FeedingPlansResponse.plans:type_name -> main.FeedingPlan
+	17, // 8: main.RecordFeedingRequest.fed_at:type_name -> google.protobuf.Timestamp
+	17, // 9: main.FeedingResponse.fed_at:type_name -> google.protobuf.Timestamp
+	17, // 10: main.OverdueFeeding.due_at:type_name -> google.protobuf.Timestamp
+	17, // 11: main.OverdueFeeding.last_fed_at:type_name -> google.protobuf.Timestamp
+	15, // 12: main.OverdueFeedingsResponse.feedings:type_name -> main.OverdueFeeding
+	4,  // 13: main.AnimalService.GetAnimal:input_type -> main.AnimalRequest
+	8,  // 14: main.AnimalService.List:input_type -> main.ListAnimalsRequest
+	10, // 15: main.FeedingService.ListFeedingPlans:input_type -> main.ListFeedingPlansRequest
+	12, // 16: main.FeedingService.RecordFeeding:input_type -> main.RecordFeedingRequest
+	14, // 17: main.FeedingService.ListOverdueFeedings:input_type -> main.ListOverdueFeedingsRequest
+	3,  // 18: main.AnimalService.GetAnimal:output_type -> main.AnimalResponse
+	5,  // 19: main.AnimalService.List:output_type -> main.AnimalsResponse
+	11, // 20: main.FeedingService.ListFeedingPlans:output_type -> main.FeedingPlansResponse
+	13, // 21: main.FeedingService.RecordFeeding:output_type -> main.FeedingResponse
+	16, // 22: main.FeedingService.ListOverdueFeedings:output_type -> main.OverdueFeedingsResponse
+	18, // [18:23] is the sub-list for method output_type
+	13, // [13:18] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_api_zoo_proto_init() }
+func file_api_zoo_proto_init() {
+	if File_api_zoo_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_zoo_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnimalType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnimalResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnimalRequest); i {
+			case 0:
 				return &v.state
 			case 1:
 				return &v.sizeCache
@@ -623,6 +1551,18 @@ func file_api_zoo_proto_init() {
 			}
 		}
 		file_api_zoo_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterAnimals); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListAnimalsRequest); i {
 			case 0:
 				return &v.state
@@ -634,16 +1574,118 @@ func file_api_zoo_proto_init() {
 				return nil
 			}
 		}
+		file_api_zoo_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedingPlan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFeedingPlansRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedingPlansResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordFeedingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListOverdueFeedingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OverdueFeeding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_zoo_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OverdueFeedingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_api_zoo_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_api_zoo_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_api_zoo_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_api_zoo_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	file_api_zoo_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_api_zoo_proto_msgTypes[11].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_zoo_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   6,
+			NumMessages:   15,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_zoo_proto_goTypes,
 		DependencyIndexes: file_api_zoo_proto_depIdxs,
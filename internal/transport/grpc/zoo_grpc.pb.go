@@ -0,0 +1,310 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/zoo.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	AnimalService_GetAnimal_FullMethodName = "/main.AnimalService/GetAnimal"
+	AnimalService_List_FullMethodName      = "/main.AnimalService/List"
+)
+
+// AnimalServiceClient is the client API for AnimalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AnimalServiceClient interface {
+	GetAnimal(ctx context.Context, in *AnimalRequest, opts ...grpc.CallOption) (*AnimalResponse, error)
+	List(ctx context.Context, in *ListAnimalsRequest, opts ...grpc.CallOption) (*AnimalsResponse, error)
+}
+
+type animalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnimalServiceClient(cc grpc.ClientConnInterface) AnimalServiceClient {
+	return &animalServiceClient{cc}
+}
+
+func (c *animalServiceClient) GetAnimal(ctx context.Context, in *AnimalRequest, opts ...grpc.CallOption) (*AnimalResponse, error) {
+	out := new(AnimalResponse)
+	err := c.cc.Invoke(ctx, AnimalService_GetAnimal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *animalServiceClient) List(ctx context.Context, in *ListAnimalsRequest, opts ...grpc.CallOption) (*AnimalsResponse, error) {
+	out := new(AnimalsResponse)
+	err := c.cc.Invoke(ctx, AnimalService_List_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnimalServiceServer is the server API for AnimalService service.
+// All implementations must embed UnimplementedAnimalServiceServer
+// for forward compatibility
+type AnimalServiceServer interface {
+	GetAnimal(context.Context, *AnimalRequest) (*AnimalResponse, error)
+	List(context.Context, *ListAnimalsRequest) (*AnimalsResponse, error)
+	mustEmbedUnimplementedAnimalServiceServer()
+}
+
+// UnimplementedAnimalServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAnimalServiceServer struct {
+}
+
+func (UnimplementedAnimalServiceServer) GetAnimal(context.Context, *AnimalRequest) (*AnimalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnimal not implemented")
+}
+func (UnimplementedAnimalServiceServer) List(context.Context, *ListAnimalsRequest) (*AnimalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedAnimalServiceServer) mustEmbedUnimplementedAnimalServiceServer() {}
+
+// UnsafeAnimalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnimalServiceServer will
+// result in compilation errors.
+type UnsafeAnimalServiceServer interface {
+	mustEmbedUnimplementedAnimalServiceServer()
+}
+
+func RegisterAnimalServiceServer(s grpc.ServiceRegistrar, srv AnimalServiceServer) {
+	s.RegisterService(&AnimalService_ServiceDesc, srv)
+}
+
+func _AnimalService_GetAnimal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnimalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimalServiceServer).GetAnimal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimalService_GetAnimal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimalServiceServer).GetAnimal(ctx, req.(*AnimalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnimalService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAnimalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimalServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimalService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimalServiceServer).List(ctx, req.(*ListAnimalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnimalService_ServiceDesc is the grpc.ServiceDesc for AnimalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnimalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "main.AnimalService",
+	HandlerType: (*AnimalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAnimal",
+			Handler:    _AnimalService_GetAnimal_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _AnimalService_List_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/zoo.proto",
+}
+
+const (
+	FeedingService_ListFeedingPlans_FullMethodName    = "/main.FeedingService/ListFeedingPlans"
+	FeedingService_RecordFeeding_FullMethodName       = "/main.FeedingService/RecordFeeding"
+	FeedingService_ListOverdueFeedings_FullMethodName = "/main.FeedingService/ListOverdueFeedings"
+)
+
+// FeedingServiceClient is the client API for FeedingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FeedingServiceClient interface {
+	ListFeedingPlans(ctx context.Context, in *ListFeedingPlansRequest, opts ...grpc.CallOption) (*FeedingPlansResponse, error)
+	RecordFeeding(ctx context.Context, in *RecordFeedingRequest, opts ...grpc.CallOption) (*FeedingResponse, error)
+	ListOverdueFeedings(ctx context.Context, in *ListOverdueFeedingsRequest, opts ...grpc.CallOption) (*OverdueFeedingsResponse, error)
+}
+
+type feedingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeedingServiceClient(cc grpc.ClientConnInterface) FeedingServiceClient {
+	return &feedingServiceClient{cc}
+}
+
+func (c *feedingServiceClient) ListFeedingPlans(ctx context.Context, in *ListFeedingPlansRequest, opts ...grpc.CallOption) (*FeedingPlansResponse, error) {
+	out := new(FeedingPlansResponse)
+	err := c.cc.Invoke(ctx, FeedingService_ListFeedingPlans_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) RecordFeeding(ctx context.Context, in *RecordFeedingRequest, opts ...grpc.CallOption) (*FeedingResponse, error) {
+	out := new(FeedingResponse)
+	err := c.cc.Invoke(ctx, FeedingService_RecordFeeding_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) ListOverdueFeedings(ctx context.Context, in *ListOverdueFeedingsRequest, opts ...grpc.CallOption) (*OverdueFeedingsResponse, error) {
+	out := new(OverdueFeedingsResponse)
+	err := c.cc.Invoke(ctx, FeedingService_ListOverdueFeedings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeedingServiceServer is the server API for FeedingService service.
+// All implementations must embed UnimplementedFeedingServiceServer
+// for forward compatibility
+type FeedingServiceServer interface {
+	ListFeedingPlans(context.Context, *ListFeedingPlansRequest) (*FeedingPlansResponse, error)
+	RecordFeeding(context.Context, *RecordFeedingRequest) (*FeedingResponse, error)
+	ListOverdueFeedings(context.Context, *ListOverdueFeedingsRequest) (*OverdueFeedingsResponse, error)
+	mustEmbedUnimplementedFeedingServiceServer()
+}
+
+// UnimplementedFeedingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFeedingServiceServer struct {
+}
+
+func (UnimplementedFeedingServiceServer) ListFeedingPlans(context.Context, *ListFeedingPlansRequest) (*FeedingPlansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeedingPlans not implemented")
+}
+func (UnimplementedFeedingServiceServer) RecordFeeding(context.Context, *RecordFeedingRequest) (*FeedingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordFeeding not implemented")
+}
+func (UnimplementedFeedingServiceServer) ListOverdueFeedings(context.Context, *ListOverdueFeedingsRequest) (*OverdueFeedingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverdueFeedings not implemented")
+}
+func (UnimplementedFeedingServiceServer) mustEmbedUnimplementedFeedingServiceServer() {}
+
+// UnsafeFeedingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeedingServiceServer will
+// result in compilation errors.
+type UnsafeFeedingServiceServer interface {
+	mustEmbedUnimplementedFeedingServiceServer()
+}
+
+func RegisterFeedingServiceServer(s grpc.ServiceRegistrar, srv FeedingServiceServer) {
+	s.RegisterService(&FeedingService_ServiceDesc, srv)
+}
+
+func _FeedingService_ListFeedingPlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeedingPlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).ListFeedingPlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_ListFeedingPlans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).ListFeedingPlans(ctx, req.(*ListFeedingPlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_RecordFeeding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordFeedingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).RecordFeeding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_RecordFeeding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).RecordFeeding(ctx, req.(*RecordFeedingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_ListOverdueFeedings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverdueFeedingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).ListOverdueFeedings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_ListOverdueFeedings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).ListOverdueFeedings(ctx, req.(*ListOverdueFeedingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FeedingService_ServiceDesc is the grpc.ServiceDesc for FeedingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FeedingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "main.FeedingService",
+	HandlerType: (*FeedingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFeedingPlans",
+			Handler:    _FeedingService_ListFeedingPlans_Handler,
+		},
+		{
+			MethodName: "RecordFeeding",
+			Handler:    _FeedingService_RecordFeeding_Handler,
+		},
+		{
+			MethodName: "ListOverdueFeedings",
+			Handler:    _FeedingService_ListOverdueFeedings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/zoo.proto",
+}
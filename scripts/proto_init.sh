@@ -1 +1 @@
-protoc --go_out=.. ..\api\zoo.proto
\ No newline at end of file
+protoc --go_out=.. --go-grpc_out=.. ..\api\zoo.proto
\ No newline at end of file